@@ -3,6 +3,7 @@ package api
 import (
 	"log"
 
+	"github.com/jorgepiresg/ChallangePismo/api/health"
 	v1 "github.com/jorgepiresg/ChallangePismo/api/v1"
 	"github.com/jorgepiresg/ChallangePismo/app"
 	"github.com/labstack/echo/v4"
@@ -11,11 +12,18 @@ import (
 type Options struct {
 	Group *echo.Group
 	App   app.App
+
+	// Health is the group of the probes, kept out of the versioned API.
+	Health *echo.Group
 }
 
 func New(opts Options) {
 
 	v1.Register(opts.Group, opts.App)
 
+	if opts.Health != nil {
+		health.Register(opts.Health, opts.App)
+	}
+
 	log.Println("API Created")
 }
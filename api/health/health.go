@@ -0,0 +1,40 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/jorgepiresg/ChallangePismo/app"
+	modelHealth "github.com/jorgepiresg/ChallangePismo/model/health"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	app app.App
+}
+
+func Register(g *echo.Group, app app.App) {
+	h := handler{
+		app: app,
+	}
+
+	g.GET("/live", h.live)
+	g.GET("/ready", h.ready)
+}
+
+// live is the liveness probe: 200 while the process serves requests.
+func (h handler) live(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.app.Health.Live(c.Request().Context()))
+}
+
+// ready is the readiness probe: 200 when the service is up or degraded, with the status and latency of each dependency,
+// and 503 when it is down.
+func (h handler) ready(c echo.Context) error {
+
+	res := h.app.Health.Ready(c.Request().Context())
+
+	if res.Status == modelHealth.StatusDown {
+		return c.JSON(http.StatusServiceUnavailable, res)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jorgepiresg/ChallangePismo/app"
+	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
+	modelHealth "github.com/jorgepiresg/ChallangePismo/model/health"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+
+	t.Run("register group", func(t *testing.T) {
+		Register(echo.New().Group(""), app.App{})
+	})
+}
+
+func TestLive(t *testing.T) {
+
+	t.Run("should be able to report live", func(t *testing.T) {
+
+		ctrl := gomock.NewController(t)
+		healthMock := mocksApp.NewMockIHealth(ctrl)
+		healthMock.EXPECT().Live(gomock.Any()).Times(1).Return(modelHealth.Report{Status: modelHealth.StatusUp, Checks: []modelHealth.Check{}})
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+		h := &handler{app: app.App{Health: healthMock}}
+
+		assert.NoError(t, h.live(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"status":"UP","checks":[]}`+"\n", rec.Body.String())
+	})
+}
+
+func TestReady(t *testing.T) {
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	tests := map[string]struct {
+		input    modelHealth.Report
+		expected expected
+	}{
+		"should be able to report ready": {
+			input: modelHealth.Report{Status: modelHealth.StatusUp, Checks: []modelHealth.Check{
+				{Name: modelHealth.DependencyDatabase, Status: modelHealth.StatusUp, LatencyMS: 1.2},
+				{Name: modelHealth.DependencyCache, Status: modelHealth.StatusUp, LatencyMS: 0.4},
+			}},
+			expected: expected{
+				Status:   200,
+				Response: `{"status":"UP","checks":[{"name":"postgres","status":"UP","latency_ms":1.2},{"name":"redis","status":"UP","latency_ms":0.4}]}`,
+			},
+		},
+		"should be able to report ready degraded with cache down": {
+			input: modelHealth.Report{Status: modelHealth.StatusDegraded, Checks: []modelHealth.Check{
+				{Name: modelHealth.DependencyDatabase, Status: modelHealth.StatusUp, LatencyMS: 1.2},
+				{Name: modelHealth.DependencyCache, Status: modelHealth.StatusDown, LatencyMS: 2000, Error: "i/o timeout"},
+			}},
+			expected: expected{
+				Status:   200,
+				Response: `{"status":"DEGRADED","checks":[{"name":"postgres","status":"UP","latency_ms":1.2},{"name":"redis","status":"DOWN","latency_ms":2000,"error":"i/o timeout"}]}`,
+			},
+		},
+		"should not be able to report ready with database down": {
+			input: modelHealth.Report{Status: modelHealth.StatusDown, Checks: []modelHealth.Check{
+				{Name: modelHealth.DependencyDatabase, Status: modelHealth.StatusDown, LatencyMS: 3.1, Error: "connection refused"},
+				{Name: modelHealth.DependencyCache, Status: modelHealth.StatusUp, LatencyMS: 0.4},
+			}},
+			expected: expected{
+				Status:   503,
+				Response: `{"status":"DOWN","checks":[{"name":"postgres","status":"DOWN","latency_ms":3.1,"error":"connection refused"},{"name":"redis","status":"UP","latency_ms":0.4}]}`,
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+			healthMock := mocksApp.NewMockIHealth(ctrl)
+			healthMock.EXPECT().Ready(gomock.Any()).Times(1).Return(tt.input)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			h := &handler{app: app.App{Health: healthMock}}
+
+			assert.NoError(t, h.ready(c))
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jorgepiresg/ChallangePismo/app"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/labstack/echo/v4"
 )
@@ -21,7 +22,16 @@ func Register(g *echo.Group, app app.App) {
 	}
 
 	g.POST("", h.create)
+	g.GET("", h.list)
 	g.GET("/:account_id", h.getByAccountID)
+	g.PATCH("/:account_id", h.update)
+	g.GET("/:account_id/transactions", h.listTransactions)
+	g.GET("/:account_id/balance", h.getBalance)
+	g.GET("/:account_id/installments", h.listFutureInstallments)
+	g.GET("/:account_id/statements", h.listStatements)
+	g.GET("/:account_id/statements/:statement_id", h.getStatement)
+	g.PUT("/:account_id/status", h.changeStatus)
+	g.GET("/:account_id/status-history", h.listStatusHistory)
 }
 
 // create godoc
@@ -33,6 +43,8 @@ func Register(g *echo.Group, app app.App) {
 // @Param request body modelAccounts.Create true "input"
 // @Success      201  {object}  modelAccounts.CreateResponse
 // @Failure      400  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
 // @Router       /accounts [post]
 func (h handler) create(c echo.Context) error {
 
@@ -47,7 +59,7 @@ func (h handler) create(c echo.Context) error {
 
 	account, err := h.app.Accounts.Create(ctx, payload)
 	if err != nil {
-		return utils.NewError(http.StatusBadRequest, "fail to create a new account", err.Error())
+		return err
 	}
 
 	res := modelAccounts.CreateResponse{
@@ -68,6 +80,8 @@ func (h handler) create(c echo.Context) error {
 // @Param        account_id   path      string  true  "Account ID"
 // @Success      200  {object}  modelAccounts.Account
 // @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
 // @Router       /accounts/{account_id} [get]
 func (h handler) getByAccountID(c echo.Context) error {
 
@@ -78,7 +92,297 @@ func (h handler) getByAccountID(c echo.Context) error {
 
 	res, err := h.app.Accounts.GetByAccountID(ctx, accountID)
 	if err != nil {
-		return utils.NewError(http.StatusBadRequest, err.Error(), nil)
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// list godoc
+// @Summary Accounts
+// @Description list the accounts, newest first, paginated by cursor
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        document_number   query      string  false  "Document number, or its first digits"
+// @Param        start_date   query      string  false  "Created from (RFC3339)"
+// @Param        end_date   query      string  false  "Created until (RFC3339)"
+// @Param        cursor   query      string  false  "Cursor returned by the previous page"
+// @Param        limit   query      int  false  "Page size (default 50, max 100)"
+// @Success      200  {object}  modelAccounts.AccountsPage
+// @Failure      400  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts [get]
+func (h handler) list(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var filter modelAccounts.ListFilter
+
+	if err := c.Bind(&filter); err != nil {
+		return utils.NewError(http.StatusBadRequest, "filter invalid", err.Error())
+	}
+
+	res, err := h.app.Accounts.List(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// update godoc
+// @Summary Account update
+// @Description update the document number, the statement closing day or the available credit limit of an account. Fields left out are kept.
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Param request body modelAccounts.Update true "input"
+// @Success      200  {object}  modelAccounts.Account
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id} [patch]
+func (h handler) update(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var payload modelAccounts.Update
+
+	if err := c.Bind(&payload); err != nil {
+		return utils.NewError(http.StatusBadRequest, "payload invalid ", err.Error())
+	}
+
+	res, err := h.app.Accounts.Update(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// listTransactions godoc
+// @Summary Account transactions
+// @Description list the transactions of an account, newest first, paginated by cursor
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Param        operation_type_id   query      int  false  "Operation type ID"
+// @Param        start_date   query      string  false  "Start date (RFC3339)"
+// @Param        end_date   query      string  false  "End date (RFC3339)"
+// @Param        min_amount   query      number  false  "Minimum absolute amount"
+// @Param        max_amount   query      number  false  "Maximum absolute amount"
+// @Param        open_balance_only   query      bool  false  "Only transactions with balance to settle"
+// @Param        cursor   query      string  false  "Cursor returned by the previous page"
+// @Param        limit   query      int  false  "Page size (default 50, max 100)"
+// @Success      200  {object}  modelTransactions.TransactionsPage
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/transactions [get]
+func (h handler) listTransactions(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var filter modelTransactions.ListFilter
+
+	if err := c.Bind(&filter); err != nil {
+		return utils.NewError(http.StatusBadRequest, "filter invalid", err.Error())
+	}
+
+	res, err := h.app.Transactions.ListByAccountID(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// getBalance godoc
+// @Summary Account balance
+// @Description get the outstanding debt and unapplied credit of an account, by operation type
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Success      200  {object}  modelTransactions.BalanceSummary
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/balance [get]
+func (h handler) getBalance(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	accountID := c.Param("account_id")
+
+	res, err := h.app.Transactions.GetBalance(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// listFutureInstallments godoc
+// @Summary Account future installments
+// @Description list the installments of an account not due yet, in the order they are due
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Success      200  {object}  modelTransactions.FutureInstallments
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/installments [get]
+func (h handler) listFutureInstallments(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	accountID := c.Param("account_id")
+
+	res, err := h.app.Transactions.ListFutureInstallments(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// listStatements godoc
+// @Summary Account statements
+// @Description list the statements of an account, one per billing cycle and currency, the most recent cycle first
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Success      200  {object}  modelStatements.AccountStatements
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/statements [get]
+func (h handler) listStatements(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	accountID := c.Param("account_id")
+
+	res, err := h.app.Statements.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// getStatement godoc
+// @Summary Account statement
+// @Description get a statement of an account by id
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Param        statement_id   path      string  true  "Statement ID"
+// @Success      200  {object}  modelStatements.Statement
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/statements/{statement_id} [get]
+func (h handler) getStatement(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.app.Statements.GetByID(ctx, c.Param("account_id"), c.Param("statement_id"))
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// changeStatus godoc
+// @Summary Account status
+// @Description block, unblock or close an account. A closed account is never reopened and can only be closed with nothing left to settle.
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Param request body modelAccounts.ChangeStatus true "input"
+// @Success      200  {object}  modelAccounts.Account
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/status [put]
+func (h handler) changeStatus(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var payload modelAccounts.ChangeStatus
+
+	if err := c.Bind(&payload); err != nil {
+		return utils.NewError(http.StatusBadRequest, "payload invalid ", err.Error())
+	}
+
+	res, err := h.app.Accounts.ChangeStatus(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	c.JSON(http.StatusOK, res)
+
+	return nil
+}
+
+// listStatusHistory godoc
+// @Summary Account status history
+// @Description list the status changes of an account, oldest first
+// @Tags         Account
+// @Accept       json
+// @Produce      json
+// @Param        account_id   path      string  true  "Account ID"
+// @Success      200  {object}  modelAccounts.StatusHistory
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /accounts/{account_id}/status-history [get]
+func (h handler) listStatusHistory(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.app.Accounts.ListStatusHistory(ctx, c.Param("account_id"))
+	if err != nil {
+		return err
 	}
 
 	c.JSON(http.StatusOK, res)
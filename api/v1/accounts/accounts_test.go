@@ -10,8 +10,15 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jorgepiresg/ChallangePismo/app"
+	appAccounts "github.com/jorgepiresg/ChallangePismo/app/accounts"
+	appStatements "github.com/jorgepiresg/ChallangePismo/app/statements"
 	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -56,6 +63,20 @@ func TestCreate(t *testing.T) {
 			},
 			err: fmt.Errorf("any error"),
 		},
+		"should not be able to create a new account with error document number invalid": {
+			input: `{"document_number":"529.982.247-26"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, modelAccounts.ErrDocumentNumberCheckDigits)
+			},
+			err: modelAccounts.ErrDocumentNumberCheckDigits,
+		},
+		"should not be able to create a new account with error account already exists": {
+			input: `{"document_number":"529.982.247-25"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrAccountAlreadyExists)
+			},
+			err: appAccounts.ErrAccountAlreadyExists,
+		},
 		"should not be able to create a new account with error in app.create": {
 			input: `{"document_number":"111.111.111-11"}`,
 			prepare: func(f *fields) {
@@ -120,11 +141,11 @@ func TestGetByAccountID(t *testing.T) {
 		"success: status 200": {
 			input: `id`,
 			prepare: func(f *fields) {
-				f.accounts.EXPECT().GetByAccountID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", CreatedAt: time.Now()}, nil)
+				f.accounts.EXPECT().GetByAccountID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", Currency: "BRL", StatementClosingDay: 1, Status: modelAccounts.StatusActive, CreatedAt: time.Now()}, nil)
 			},
 			expected: expected{
 				Status:   200,
-				Response: `{"account_id":"id","document_number":"11111111111"}`,
+				Response: `{"account_id":"id","document_number":"11111111111","available_credit_limit":0.00,"currency":"BRL","statement_closing_day":1,"status":"ACTIVE"}`,
 			},
 		},
 		"error: status 400 error any": {
@@ -172,3 +193,831 @@ func TestGetByAccountID(t *testing.T) {
 		})
 	}
 }
+
+func TestList(t *testing.T) {
+
+	type fields struct {
+		accounts *mocksApp.MockIAccounts
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	createdAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		query    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			query: "document_number=111&start_date=2023-08-01T10:00:00Z&limit=1",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().List(gomock.Any(), modelAccounts.ListFilter{
+					DocumentNumber: "111",
+					StartDate:      createdAt,
+					Limit:          1,
+				}).Times(1).Return(modelAccounts.AccountsPage{
+					Accounts: []modelAccounts.Account{
+						{ID: "id", DocumentNumber: "11111111111", Currency: "BRL", StatementClosingDay: 1, Status: modelAccounts.StatusActive, CreatedAt: createdAt},
+					},
+					NextCursor: "next",
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"accounts":[{"account_id":"id","document_number":"11111111111","available_credit_limit":0.00,"currency":"BRL","statement_closing_day":1,"status":"ACTIVE"}],"next_cursor":"next"}`,
+			},
+		},
+		"error: status 400 filter invalid": {
+			query:   "limit=abc",
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("any"),
+		},
+		"error: status 400 error any": {
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.AccountsPage{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksApp.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts")
+
+			h := &handler{
+				app: app.App{
+					Accounts: accountsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.list(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.list(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	type fields struct {
+		accounts *mocksApp.MockIAccounts
+	}
+
+	type expected struct {
+		Status   int
+		Code     string
+		Response string
+	}
+
+	closingDay := 10
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: `{"statement_closing_day":10}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Update(gomock.Any(), modelAccounts.Update{AccountID: "id", StatementClosingDay: &closingDay}).Times(1).Return(modelAccounts.Account{
+					ID:                  "id",
+					DocumentNumber:      "11111111111",
+					Currency:            "BRL",
+					StatementClosingDay: 10,
+					Status:              modelAccounts.StatusActive,
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","document_number":"11111111111","available_credit_limit":0.00,"currency":"BRL","statement_closing_day":10,"status":"ACTIVE"}`,
+			},
+		},
+		"error: status 400 payload invalid": {
+			input:    `{"statement_closing_day":"x"}`,
+			prepare:  func(f *fields) {},
+			expected: expected{Status: 400, Code: "BAD_REQUEST"},
+		},
+		"error: status 400 document number invalid": {
+			input: `{"document_number":"529.982.247-26"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, modelAccounts.ErrDocumentNumberCheckDigits)
+			},
+			expected: expected{Status: 400, Code: "DOCUMENT_NUMBER_CHECK_DIGITS_INVALID"},
+		},
+		"error: status 404 account not found": {
+			input: `{"statement_closing_day":10}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrAccountNotFound)
+			},
+			expected: expected{Status: 404, Code: "ACCOUNT_NOT_FOUND"},
+		},
+		"error: status 409 account already exists": {
+			input: `{"document_number":"52998224725"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrAccountAlreadyExists)
+			},
+			expected: expected{Status: 409, Code: "ACCOUNT_ALREADY_EXISTS"},
+		},
+		"error: status 503 store unavailable": {
+			input: `{"statement_closing_day":10}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, modelErrors.Unavailable("fail to update account", fmt.Errorf("any")))
+			},
+			expected: expected{Status: 503, Code: "UNAVAILABLE"},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksApp.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.input))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id")
+			c.SetParamNames("account_id")
+			c.SetParamValues("id")
+
+			h := &handler{
+				app: app.App{
+					Accounts: accountsMock,
+				},
+			}
+
+			err := h.update(c)
+			if err != nil {
+				assert.Equal(t, tt.expected.Status, utils.GetHTTPCode(err))
+				assert.Equal(t, tt.expected.Code, utils.GetError(err).Code)
+				return
+			}
+
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+		})
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		query    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: "id",
+			query: "operation_type_id=1&start_date=2023-08-01T10:00:00Z&open_balance_only=true&limit=1",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListByAccountID(gomock.Any(), modelTransactions.ListFilter{
+					AccountID:       "id",
+					OperationTypeID: 1,
+					StartDate:       eventDate,
+					OpenBalanceOnly: true,
+					Limit:           1,
+				}).Times(1).Return(modelTransactions.TransactionsPage{
+					Transactions: []modelTransactions.Transaction{
+						{TransactionID: "1", AccountID: "id", OperationTypeID: 1, Amount: modelMoney.MustParse("-10"), Balance: modelMoney.MustParse("-10"), Currency: "BRL", EventDate: eventDate},
+					},
+					NextCursor: "next",
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"transactions":[{"transaction_id":"1","account_id":"id","operation_type_id":1,"amount":-10.00,"balance":-10.00,"currency":"BRL","event_date":"2023-08-01T10:00:00Z"}],"next_cursor":"next"}`,
+			},
+		},
+		"error: status 400 filter invalid": {
+			input:   "id",
+			query:   "limit=abc",
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("any"),
+		},
+		"error: status 400 error any": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListByAccountID(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.TransactionsPage{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/transactions")
+			c.SetParamNames("account_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.listTransactions(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.listTransactions(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetBalance(gomock.Any(), "id").Times(1).Return(modelTransactions.BalanceSummary{
+					AccountID:       "id",
+					Currency:        "BRL",
+					OutstandingDebt: modelMoney.MustParse("10"),
+					Balance:         modelMoney.MustParse("-10"),
+					OperationsType: []modelTransactions.OperationTypeBalance{
+						{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "BRL", OutstandingDebt: modelMoney.MustParse("10"), OpenTransactions: 1},
+					},
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","currency":"BRL","outstanding_debt":10.00,"unapplied_credit":0.00,"balance":-10.00,"operations_type":[{"operation_type_id":1,"description":"COMPRA A VISTA","currency":"BRL","outstanding_debt":10.00,"unapplied_credit":0.00,"open_transactions":1}]}`,
+			},
+		},
+		"error: status 400 error any": {
+			input: "invalid_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetBalance(gomock.Any(), "invalid_id").Times(1).Return(modelTransactions.BalanceSummary{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/balance")
+			c.SetParamNames("account_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.getBalance(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.getBalance(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestListFutureInstallments(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	parentID, number := "parent_id", 2
+	dueDate := time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListFutureInstallments(gomock.Any(), "id").Times(1).Return(modelTransactions.FutureInstallments{
+					AccountID: "id",
+					Installments: []modelTransactions.Transaction{
+						{
+							TransactionID:       "2",
+							AccountID:           "id",
+							OperationTypeID:     2,
+							Amount:              modelMoney.MustParse("-10"),
+							Balance:             modelMoney.MustParse("-10"),
+							Currency:            "BRL",
+							ParentTransactionID: &parentID,
+							InstallmentNumber:   &number,
+							DueDate:             &dueDate,
+							EventDate:           dueDate.AddDate(0, -1, 0),
+						},
+					},
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","installments":[{"transaction_id":"2","account_id":"id","operation_type_id":2,"amount":-10.00,"balance":-10.00,"currency":"BRL","parent_transaction_id":"parent_id","installment_number":2,"due_date":"2023-09-01T10:00:00Z","event_date":"2023-08-01T10:00:00Z"}]}`,
+			},
+		},
+		"error: status 400 error any": {
+			input: "invalid_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListFutureInstallments(gomock.Any(), "invalid_id").Times(1).Return(modelTransactions.FutureInstallments{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/installments")
+			c.SetParamNames("account_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.listFutureInstallments(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.listFutureInstallments(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestListStatements(t *testing.T) {
+
+	type fields struct {
+		statements *mocksApp.MockIStatements
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	closingDate := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.statements.EXPECT().ListByAccountID(gomock.Any(), "id").Times(1).Return(modelStatements.AccountStatements{
+					AccountID: "id",
+					Statements: []modelStatements.Statement{
+						{
+							StatementID:    "statement_id",
+							AccountID:      "id",
+							Currency:       "BRL",
+							ClosingDate:    closingDate,
+							OpeningBalance: modelMoney.MustParse("0"),
+							Purchases:      modelMoney.MustParse("100"),
+							Payments:       modelMoney.MustParse("0"),
+							ClosingBalance: modelMoney.MustParse("100"),
+							MinimumPayment: modelMoney.MustParse("15"),
+							DueDate:        closingDate.AddDate(0, 0, modelStatements.DueDays),
+							CreatedAt:      closingDate,
+						},
+					},
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","statements":[{"statement_id":"statement_id","account_id":"id","currency":"BRL","closing_date":"2023-09-01T00:00:00Z","opening_balance":0.00,"purchases":100.00,"payments":0.00,"closing_balance":100.00,"minimum_payment":15.00,"due_date":"2023-09-11T00:00:00Z","created_at":"2023-09-01T00:00:00Z"}]}`,
+			},
+		},
+		"error: status 400 error any": {
+			input: "invalid_id",
+			prepare: func(f *fields) {
+				f.statements.EXPECT().ListByAccountID(gomock.Any(), "invalid_id").Times(1).Return(modelStatements.AccountStatements{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			statementsMock := mocksApp.NewMockIStatements(ctrl)
+
+			tt.prepare(&fields{
+				statements: statementsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/statements")
+			c.SetParamNames("account_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Statements: statementsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.listStatements(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.listStatements(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestGetStatement(t *testing.T) {
+
+	type fields struct {
+		statements *mocksApp.MockIStatements
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	periodStart := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	closingDate := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    []string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: []string{"id", "statement_id"},
+			prepare: func(f *fields) {
+				f.statements.EXPECT().GetByID(gomock.Any(), "id", "statement_id").Times(1).Return(modelStatements.Statement{
+					StatementID:    "statement_id",
+					AccountID:      "id",
+					Currency:       "BRL",
+					PeriodStart:    &periodStart,
+					ClosingDate:    closingDate,
+					OpeningBalance: modelMoney.MustParse("100"),
+					Purchases:      modelMoney.MustParse("50"),
+					Payments:       modelMoney.MustParse("100"),
+					ClosingBalance: modelMoney.MustParse("50"),
+					MinimumPayment: modelMoney.MustParse("7.50"),
+					DueDate:        closingDate.AddDate(0, 0, modelStatements.DueDays),
+					CreatedAt:      closingDate,
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"statement_id":"statement_id","account_id":"id","currency":"BRL","period_start":"2023-08-01T00:00:00Z","closing_date":"2023-09-01T00:00:00Z","opening_balance":100.00,"purchases":50.00,"payments":100.00,"closing_balance":50.00,"minimum_payment":7.50,"due_date":"2023-09-11T00:00:00Z","created_at":"2023-09-01T00:00:00Z"}`,
+			},
+		},
+		"error: status 400 error statement not found": {
+			input: []string{"id", "invalid_id"},
+			prepare: func(f *fields) {
+				f.statements.EXPECT().GetByID(gomock.Any(), "id", "invalid_id").Times(1).Return(modelStatements.Statement{}, appStatements.ErrStatementNotFound)
+			},
+			err: appStatements.ErrStatementNotFound,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			statementsMock := mocksApp.NewMockIStatements(ctrl)
+
+			tt.prepare(&fields{
+				statements: statementsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/statements/:statement_id")
+			c.SetParamNames("account_id", "statement_id")
+			c.SetParamValues(tt.input...)
+
+			h := &handler{
+				app: app.App{
+					Statements: statementsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.getStatement(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.getStatement(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+
+	type fields struct {
+		accounts *mocksApp.MockIAccounts
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: `{"status":"BLOCKED","reason":"suspected fraud"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ChangeStatus(gomock.Any(), modelAccounts.ChangeStatus{AccountID: "id", Status: "BLOCKED", Reason: "suspected fraud"}).Times(1).Return(modelAccounts.Account{
+					ID:                  "id",
+					DocumentNumber:      "11111111111",
+					Currency:            "BRL",
+					StatementClosingDay: 1,
+					Status:              modelAccounts.StatusBlocked,
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","document_number":"11111111111","available_credit_limit":0.00,"currency":"BRL","statement_closing_day":1,"status":"BLOCKED"}`,
+			},
+		},
+		"error: status 400 payload invalid": {
+			input:    `{"status":1}`,
+			prepare:  func(f *fields) {},
+			expected: expected{Status: 400},
+		},
+		"error: status 409 status change not allowed": {
+			input: `{"status":"ACTIVE","reason":"reopen"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ChangeStatus(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrStatusChangeNotAllowed)
+			},
+			expected: expected{Status: 409},
+		},
+		"error: status 422 balance not zero": {
+			input: `{"status":"CLOSED","reason":"requested by the cardholder"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ChangeStatus(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrBalanceNotZero)
+			},
+			expected: expected{Status: 422},
+		},
+		"error: status 400 reason invalid": {
+			input: `{"status":"BLOCKED"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ChangeStatus(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, modelAccounts.ErrReasonInvalid)
+			},
+			expected: expected{Status: 400},
+		},
+		"error: status 404 account not found": {
+			input: `{"status":"BLOCKED","reason":"suspected fraud"}`,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ChangeStatus(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, appAccounts.ErrAccountNotFound)
+			},
+			expected: expected{Status: 404},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksApp.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tt.input))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/status")
+			c.SetParamNames("account_id")
+			c.SetParamValues("id")
+
+			h := &handler{
+				app: app.App{
+					Accounts: accountsMock,
+				},
+			}
+
+			err := h.changeStatus(c)
+			if err != nil {
+				assert.Equal(t, tt.expected.Status, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+		})
+	}
+}
+
+func TestListStatusHistory(t *testing.T) {
+
+	type fields struct {
+		accounts *mocksApp.MockIAccounts
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	changedAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"success: status 200": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListStatusHistory(gomock.Any(), "id").Times(1).Return(modelAccounts.StatusHistory{
+					AccountID: "id",
+					Changes: []modelAccounts.StatusChange{
+						{ChangeID: 1, AccountID: "id", FromStatus: "ACTIVE", ToStatus: "BLOCKED", Reason: "suspected fraud", ChangedAt: changedAt},
+					},
+				}, nil)
+			},
+			expected: expected{
+				Status:   200,
+				Response: `{"account_id":"id","changes":[{"change_id":1,"account_id":"id","from_status":"ACTIVE","to_status":"BLOCKED","reason":"suspected fraud","changed_at":"2023-08-01T10:00:00Z"}]}`,
+			},
+		},
+		"error: status 400 error account not found": {
+			input: "invalid_id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListStatusHistory(gomock.Any(), "invalid_id").Times(1).Return(modelAccounts.StatusHistory{}, appAccounts.ErrAccountNotFound)
+			},
+			err: appAccounts.ErrAccountNotFound,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksApp.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/accounts/:account_id/status-history")
+			c.SetParamNames("account_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Accounts: accountsMock,
+				},
+			}
+
+			if tt.err == nil && assert.NoError(t, h.listStatusHistory(c)) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+			}
+
+			if tt.err != nil && !assert.Error(t, h.listStatusHistory(c)) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
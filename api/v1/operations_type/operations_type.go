@@ -0,0 +1,145 @@
+package operationsType
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jorgepiresg/ChallangePismo/app"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type handler struct {
+	app app.App
+}
+
+func Register(g *echo.Group, app app.App) {
+	h := handler{
+		app: app,
+	}
+
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.PATCH("/:operation_type_id", h.update)
+	g.DELETE("/:operation_type_id", h.deactivate)
+}
+
+// create godoc
+// @Summary Operation type create
+// @Description create an operation type, with operation -1 for debits and 1 for credits
+// @Tags         Operation Type
+// @Accept       json
+// @Produce      json
+// @Param request body modelOperaTionsType.Create true "input"
+// @Success      201  {object}  modelOperaTionsType.OperationType
+// @Failure      400  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /operations-types [post]
+func (h handler) create(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var payload modelOperaTionsType.Create
+
+	if err := c.Bind(&payload); err != nil {
+		return utils.NewError(http.StatusBadRequest, "payload invalid ", nil)
+	}
+
+	res, err := h.app.OperationsType.Create(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// list godoc
+// @Summary Operation types
+// @Description list the operation types, including the deactivated ones
+// @Tags         Operation Type
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}  modelOperaTionsType.OperationType
+// @Failure      400  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /operations-types [get]
+func (h handler) list(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.app.OperationsType.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// update godoc
+// @Summary Operation type update
+// @Description update the description or the operation of an operation type. Transactions already made keep their amount.
+// @Tags         Operation Type
+// @Accept       json
+// @Produce      json
+// @Param        operation_type_id   path      int  true  "Operation type ID"
+// @Param request body modelOperaTionsType.Update true "input"
+// @Success      200  {object}  modelOperaTionsType.OperationType
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /operations-types/{operation_type_id} [patch]
+func (h handler) update(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	var payload modelOperaTionsType.Update
+
+	if err := c.Bind(&payload); err != nil {
+		return utils.NewError(http.StatusBadRequest, "payload invalid ", nil)
+	}
+
+	res, err := h.app.OperationsType.Update(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// deactivate godoc
+// @Summary Operation type deactivate
+// @Description deactivate an operation type, new transactions cannot be made with it
+// @Tags         Operation Type
+// @Accept       json
+// @Produce      json
+// @Param        operation_type_id   path      int  true  "Operation type ID"
+// @Success      200  {object}  modelOperaTionsType.OperationType
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /operations-types/{operation_type_id} [delete]
+func (h handler) deactivate(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	operationTypeID, err := strconv.Atoi(c.Param("operation_type_id"))
+	if err != nil {
+		return utils.NewError(http.StatusBadRequest, "operation type id invalid", nil)
+	}
+
+	res, err := h.app.OperationsType.Deactivate(ctx, operationTypeID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
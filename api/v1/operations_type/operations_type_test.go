@@ -0,0 +1,314 @@
+package operationsType
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jorgepiresg/ChallangePismo/app"
+	appOperationsType "github.com/jorgepiresg/ChallangePismo/app/operations_type"
+	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+
+	t.Run("register group", func(t *testing.T) {
+		Register(echo.New().Group(""), app.App{})
+	})
+}
+
+func TestCreate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksApp.MockIOperationsType
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected expected
+		prepare  func(f *fields)
+	}{
+		"should be able to create operation type": {
+			input: `{"description":"TARIFA","operation":-1}`,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Create(gomock.Any(), modelOperaTionsType.Create{Description: "TARIFA", Operation: -1}).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1000,
+					Description:     "TARIFA",
+					Operation:       -1,
+				}, nil)
+			},
+			expected: expected{
+				Status:   201,
+				Response: `{"operation_type_id":1000,"description":"TARIFA","operation":-1}`,
+			},
+		},
+		"should not be able to create operation type with payload invalid": {
+			input:   `{"description":1}`,
+			prepare: func(f *fields) {},
+			expected: expected{
+				Status: 400,
+			},
+		},
+		"should not be able to create operation type with error in app.create": {
+			input: `{"description":"TARIFA","operation":2}`,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelOperaTionsType.OperationType{}, modelOperaTionsType.ErrOperationInvalid)
+			},
+			expected: expected{
+				Status: 400,
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksApp.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.input))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := &handler{
+				app: app.App{
+					OperationsType: operationsTypeMock,
+				},
+			}
+
+			err := h.create(c)
+			if err != nil {
+				assert.Equal(t, tt.expected.Status, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			assert.Equal(t, tt.expected.Response+"\n", rec.Body.String())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksApp.MockIOperationsType
+	}
+
+	tests := map[string]struct {
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to list operations type": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().List(gomock.Any()).Times(1).Return([]modelOperaTionsType.OperationType{{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1}}, nil)
+			},
+			expected: 200,
+		},
+		"should not be able to list operations type with error in app.list": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().List(gomock.Any()).Times(1).Return(nil, modelErrors.Unavailable("fail to list operations type", fmt.Errorf("any")))
+			},
+			expected: 503,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksApp.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := &handler{
+				app: app.App{
+					OperationsType: operationsTypeMock,
+				},
+			}
+
+			err := h.list(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksApp.MockIOperationsType
+	}
+
+	operation := 1
+
+	tests := map[string]struct {
+		input    string
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to update operation type": {
+			input: `{"operation":1}`,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Update(gomock.Any(), modelOperaTionsType.Update{OperationTypeID: 1000, Operation: &operation}).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1000,
+					Description:     "TARIFA",
+					Operation:       1,
+				}, nil)
+			},
+			expected: 200,
+		},
+		"should not be able to update operation type with payload invalid": {
+			input:    `{"operation":"x"}`,
+			prepare:  func(f *fields) {},
+			expected: 400,
+		},
+		"should not be able to update operation type with error in app.update": {
+			input: `{"operation":1}`,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelOperaTionsType.OperationType{}, appOperationsType.ErrOperationTypeNotAllowed)
+			},
+			expected: 422,
+		},
+		"should not be able to update operation type not found": {
+			input: `{"operation":1}`,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelOperaTionsType.OperationType{}, appOperationsType.ErrOperationTypeNotFound)
+			},
+			expected: 404,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksApp.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.input))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("operation_type_id")
+			c.SetParamValues("1000")
+
+			h := &handler{
+				app: app.App{
+					OperationsType: operationsTypeMock,
+				},
+			}
+
+			err := h.update(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestDeactivate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksApp.MockIOperationsType
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to deactivate operation type": {
+			input: "1000",
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Deactivate(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000}, nil)
+			},
+			expected: 200,
+		},
+		"should not be able to deactivate operation type with id invalid": {
+			input:    "x",
+			prepare:  func(f *fields) {},
+			expected: 400,
+		},
+		"should not be able to deactivate operation type with error in app.deactivate": {
+			input: "5",
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Deactivate(gomock.Any(), 5).Times(1).Return(modelOperaTionsType.OperationType{}, appOperationsType.ErrOperationTypeNotAllowed)
+			},
+			expected: 422,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksApp.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("operation_type_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					OperationsType: operationsTypeMock,
+				},
+			}
+
+			err := h.deactivate(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyFinishDeadline = 5 * time.Second
+)
+
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotent stores the response of requests sent with an Idempotency-Key header and replays it for retries of the same
+// payload. Server errors are not stored, so the request can be retried. The key travels in the request context, so the
+// transaction the request makes is recorded with it and a retry never makes it again, even if the response was lost.
+func (h handler) idempotent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		key := c.Request().Header.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return next(c)
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return utils.NewError(http.StatusBadRequest, "idempotency key invalid", nil)
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return utils.NewError(http.StatusBadRequest, "payload invalid ", nil)
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := h.app.Idempotency.Start(c.Request().Context(), key, requestHash(c.Request(), body))
+		if err != nil {
+			return err
+		}
+
+		if record.Completed() {
+			c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+			if record.Body == "" {
+				return c.NoContent(record.StatusCode)
+			}
+			return c.JSONBlob(record.StatusCode, []byte(record.Body))
+		}
+
+		c.SetRequest(c.Request().WithContext(modelIdempotency.WithKey(c.Request().Context(), key)))
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+
+		handlerErr := next(c)
+
+		record.StatusCode = c.Response().Status
+		record.Body = recorder.body.String()
+
+		if handlerErr != nil {
+			record.StatusCode = utils.GetHTTPCode(handlerErr)
+			record.Body = string(utils.ToJSON(utils.GetError(handlerErr)))
+		}
+
+		// the response is already decided, it must be stored even if the client went away
+		ctx, cancel := context.WithTimeout(context.Background(), idempotencyFinishDeadline)
+		defer cancel()
+
+		if record.StatusCode >= http.StatusInternalServerError {
+			err = h.app.Idempotency.Release(ctx, key)
+		} else {
+			err = h.app.Idempotency.Finish(ctx, record)
+		}
+		if err != nil {
+			c.Logger().Error(err)
+		}
+
+		return handlerErr
+	}
+}
+
+func requestHash(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
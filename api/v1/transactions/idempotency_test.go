@@ -0,0 +1,182 @@
+package transactions
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jorgepiresg/ChallangePismo/app"
+	appIdempotency "github.com/jorgepiresg/ChallangePismo/app/idempotency"
+	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotent(t *testing.T) {
+
+	type fields struct {
+		idempotency *mocksApp.MockIIdempotency
+	}
+
+	type expected struct {
+		Status   int
+		Response string
+		Calls    int
+	}
+
+	payload := `{"account_id":"id", "operation_type_id": 1, "amount": 1}`
+
+	tests := map[string]struct {
+		key      string
+		next     echo.HandlerFunc
+		expected expected
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to call handler without idempotency key": {
+			prepare: func(f *fields) {},
+			next: func(c echo.Context) error {
+				return c.NoContent(http.StatusCreated)
+			},
+			expected: expected{Status: 201, Calls: 1},
+		},
+		"should be able to store the response of the first request": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+				f.idempotency.EXPECT().Finish(gomock.Any(), modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}).Times(1).Return(nil)
+			},
+			next: func(c echo.Context) error {
+				return c.NoContent(http.StatusCreated)
+			},
+			expected: expected{Status: 201, Calls: 1},
+		},
+		"should be able to pass the key to the handler": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+				f.idempotency.EXPECT().Finish(gomock.Any(), modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}).Times(1).Return(nil)
+			},
+			next: func(c echo.Context) error {
+				if key, _ := modelIdempotency.KeyFrom(c.Request().Context()); key != "key" {
+					return fmt.Errorf("key not passed")
+				}
+				return c.NoContent(http.StatusCreated)
+			},
+			expected: expected{Status: 201, Calls: 1},
+		},
+		"should be able to store the error response of the first request": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+				f.idempotency.EXPECT().Finish(gomock.Any(), modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 400, Body: `{"code":"AMOUNT_INVALID","message":"amount invalid"}`}).Times(1).Return(nil)
+			},
+			next: func(c echo.Context) error {
+				return modelTransactions.ErrAmountInvalid
+			},
+			err: modelTransactions.ErrAmountInvalid,
+		},
+		"should be able to release the key when the store is unavailable": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+				f.idempotency.EXPECT().Release(gomock.Any(), "key").Times(1).Return(nil)
+			},
+			next: func(c echo.Context) error {
+				return modelErrors.Unavailable("fail to make transaction", fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to make transaction"),
+		},
+		"should be able to release the key when the request fails on server": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+				f.idempotency.EXPECT().Release(gomock.Any(), "key").Times(1).Return(nil)
+			},
+			next: func(c echo.Context) error {
+				return fmt.Errorf("any")
+			},
+			err: fmt.Errorf("any"),
+		},
+		"should be able to replay a completed request": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 400, Body: `{"message":"amount invalid"}`}, nil)
+			},
+			next: func(c echo.Context) error {
+				return c.NoContent(http.StatusCreated)
+			},
+			expected: expected{Status: 400, Response: `{"message":"amount invalid"}`},
+		},
+		"should not be able to call handler with error key reused": {
+			key: "key",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().Start(gomock.Any(), "key", gomock.Any()).Times(1).Return(modelIdempotency.Record{}, appIdempotency.ErrKeyReused)
+			},
+			next: func(c echo.Context) error {
+				return c.NoContent(http.StatusCreated)
+			},
+			err: appIdempotency.ErrKeyReused,
+		},
+		"should not be able to call handler with error key too long": {
+			key:     strings.Repeat("k", maxIdempotencyKeyLength+1),
+			prepare: func(f *fields) {},
+			next: func(c echo.Context) error {
+				return c.NoContent(http.StatusCreated)
+			},
+			err: fmt.Errorf("idempotency key invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			idempotencyMock := mocksApp.NewMockIIdempotency(ctrl)
+
+			tt.prepare(&fields{
+				idempotency: idempotencyMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.key != "" {
+				req.Header.Set(HeaderIdempotencyKey, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			h := &handler{
+				app: app.App{
+					Idempotency: idempotencyMock,
+				},
+			}
+
+			calls := 0
+			next := func(c echo.Context) error {
+				calls++
+				return tt.next(c)
+			}
+
+			err := h.idempotent(next)(c)
+
+			if tt.err == nil && assert.NoError(t, err) {
+				assert.Equal(t, tt.expected.Status, rec.Code)
+				assert.Equal(t, tt.expected.Response, rec.Body.String())
+				assert.Equal(t, tt.expected.Calls, calls)
+			}
+
+			if tt.err != nil && !assert.Error(t, err) {
+				t.Errorf(`Expected err: "%s"`, tt.err)
+			}
+		})
+	}
+}
@@ -20,7 +20,10 @@ func Register(g *echo.Group, app app.App) {
 		app: app,
 	}
 
-	g.POST("", h.make)
+	g.POST("", h.make, h.idempotent)
+	g.POST("/:transaction_id/reverse", h.reverse, h.idempotent)
+	g.POST("/:transaction_id/refund", h.refund, h.idempotent)
+	g.GET("/:transaction_id/allocations", h.listAllocations)
 }
 
 // get godoc
@@ -30,8 +33,13 @@ func Register(g *echo.Group, app app.App) {
 // @Accept       json
 // @Produce      json
 // @Param request body modelTransactions.MakeTransaction true "input"
+// @Param        Idempotency-Key   header      string  false  "Key to safely retry the request, replays return the first response"
 // @Success      201
 // @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
 // @Router       /transactions [post]
 func (h handler) make(c echo.Context) error {
 
@@ -46,9 +54,95 @@ func (h handler) make(c echo.Context) error {
 
 	err := h.app.Transactions.Make(ctx, payload)
 	if err != nil {
-		return utils.NewError(http.StatusBadRequest, err.Error(), nil)
+		return err
 	}
 
 	c.NoContent(http.StatusCreated)
 	return nil
 }
+
+// get godoc
+// @Summary Reverse transaction
+// @Description reverse all that is left of a transaction, with a compensating transaction linked to it. Payments it settled, or debits it paid, are reopened.
+// @Tags         Transactions
+// @Produce      json
+// @Param        transaction_id   path      string  true  "Transaction ID"
+// @Param        Idempotency-Key   header      string  false  "Key to safely retry the request, replays return the first response"
+// @Success      201  {object}  modelTransactions.Transaction
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /transactions/{transaction_id}/reverse [post]
+func (h handler) reverse(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Minute)
+	defer cancel()
+
+	res, err := h.app.Transactions.Reverse(ctx, c.Param("transaction_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// get godoc
+// @Summary Refund transaction
+// @Description refund part of a transaction, with a compensating transaction linked to it. Refunds add up to at most the transaction amount.
+// @Tags         Transactions
+// @Accept       json
+// @Produce      json
+// @Param        transaction_id   path      string  true  "Transaction ID"
+// @Param request body modelTransactions.Refund true "input"
+// @Param        Idempotency-Key   header      string  false  "Key to safely retry the request, replays return the first response"
+// @Success      201  {object}  modelTransactions.Transaction
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      409  {object}  utils.Error
+// @Failure      422  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /transactions/{transaction_id}/refund [post]
+func (h handler) refund(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Minute)
+	defer cancel()
+
+	var payload modelTransactions.Refund
+
+	if err := c.Bind(&payload); err != nil {
+		return utils.NewError(http.StatusBadRequest, "payload invalid ", nil)
+	}
+
+	res, err := h.app.Transactions.Refund(ctx, payload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+// get godoc
+// @Summary Transaction allocations
+// @Description list how a transaction was applied: the debits a payment paid, or the payments that paid a debit, in the order they were applied. Reopened settlements are listed with a negative amount.
+// @Tags         Transactions
+// @Produce      json
+// @Param        transaction_id   path      string  true  "Transaction ID"
+// @Success      200  {object}  modelAllocations.TransactionAllocations
+// @Failure      400  {object}  utils.Error
+// @Failure      404  {object}  utils.Error
+// @Failure      503  {object}  utils.Error
+// @Router       /transactions/{transaction_id}/allocations [get]
+func (h handler) listAllocations(c echo.Context) error {
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.app.Transactions.ListAllocations(ctx, c.Param("transaction_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -9,7 +9,13 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jorgepiresg/ChallangePismo/app"
+	appTransactions "github.com/jorgepiresg/ChallangePismo/app/transactions"
 	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +51,32 @@ func TestMake(t *testing.T) {
 			},
 			err: fmt.Errorf("any error"),
 		},
+		"should not be able to make a new transaction with error credit limit exceeded": {
+			input: `{"account_id":"id", "operation_type_id": 1, "amount": 1}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Make(gomock.Any(), gomock.Any()).Times(1).Return(appTransactions.ErrCreditLimitExceeded)
+			},
+			err: appTransactions.ErrCreditLimitExceeded,
+		},
+		"should not be able to make a new transaction with error exchange rate not available": {
+			input: `{"account_id":"id", "operation_type_id": 1, "amount": 1, "currency": "USD"}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Make(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					OperationTypeID: 1,
+					Amount:          modelMoney.MustParse("1"),
+					Currency:        "USD",
+				}).Times(1).Return(appTransactions.ErrExchangeRateNotAvailable)
+			},
+			err: appTransactions.ErrExchangeRateNotAvailable,
+		},
+		"should not be able to make a new transaction with error account blocked": {
+			input: `{"account_id":"id", "operation_type_id": 1, "amount": 1}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Make(gomock.Any(), gomock.Any()).Times(1).Return(appTransactions.ErrAccountBlocked)
+			},
+			err: appTransactions.ErrAccountBlocked,
+		},
 		"should not be able to make a new transaction with error in app.transaction": {
 			input: `{"account_id":"id", "operation_type_id": 1, "amount": 1}`,
 			prepare: func(f *fields) {
@@ -87,3 +119,231 @@ func TestMake(t *testing.T) {
 		})
 	}
 }
+
+func TestReverse(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to reverse a transaction": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{TransactionID: "reversal_id"}, nil)
+			},
+			expected: 201,
+		},
+		"should not be able to reverse a transaction already reversed": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrTransactionReversed)
+			},
+			expected: 409,
+		},
+		"should not be able to reverse a compensating transaction": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrTransactionNotReversible)
+			},
+			expected: 422,
+		},
+		"should not be able to reverse a transaction of a closed account": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrAccountClosed)
+			},
+			expected: 422,
+		},
+		"should not be able to reverse a transaction with transaction not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrTransactionNotFound)
+			},
+			expected: 404,
+		},
+		"should not be able to reverse a transaction with store unavailable": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Reverse(gomock.Any(), "id").Times(1).Return(modelTransactions.Transaction{}, modelErrors.Unavailable("fail to reverse transaction", fmt.Errorf("any")))
+			},
+			expected: 503,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("transaction_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			err := h.reverse(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestRefund(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to refund a transaction": {
+			input: `{"amount": 10.50}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Refund(gomock.Any(), modelTransactions.Refund{TransactionID: "id", Amount: modelMoney.MustParse("10.50")}).Times(1).Return(modelTransactions.Transaction{TransactionID: "refund_id"}, nil)
+			},
+			expected: 201,
+		},
+		"should not be able to refund a transaction with payload invalid": {
+			input: `{"amount": "x"}`,
+			prepare: func(f *fields) {
+			},
+			expected: 400,
+		},
+		"should not be able to refund more than is left of the transaction": {
+			input: `{"amount": 10.50}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrRefundAmountExceeded)
+			},
+			expected: 422,
+		},
+		"should not be able to refund a payment with error credit limit exceeded": {
+			input: `{"amount": 10.50}`,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, appTransactions.ErrCreditLimitExceeded)
+			},
+			expected: 422,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.input))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("transaction_id")
+			c.SetParamValues("id")
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			err := h.refund(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestListAllocations(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksApp.MockITransactions
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected int
+		prepare  func(f *fields)
+	}{
+		"should be able to list allocations of a transaction": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListAllocations(gomock.Any(), "id").Times(1).Return(modelAllocations.TransactionAllocations{TransactionID: "id", Allocations: []modelAllocations.Allocation{}}, nil)
+			},
+			expected: 200,
+		},
+		"should not be able to list allocations with transaction not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().ListAllocations(gomock.Any(), "id").Times(1).Return(modelAllocations.TransactionAllocations{}, appTransactions.ErrTransactionNotFound)
+			},
+			expected: 404,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+			})
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("transaction_id")
+			c.SetParamValues(tt.input)
+
+			h := &handler{
+				app: app.App{
+					Transactions: transactionsMock,
+				},
+			}
+
+			err := h.listAllocations(c)
+			if err != nil {
+				assert.Equal(t, tt.expected, utils.GetHTTPCode(err))
+				return
+			}
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"github.com/jorgepiresg/ChallangePismo/api/v1/accounts"
+	operationsType "github.com/jorgepiresg/ChallangePismo/api/v1/operations_type"
 	"github.com/jorgepiresg/ChallangePismo/api/v1/transactions"
 	"github.com/jorgepiresg/ChallangePismo/app"
 	"github.com/labstack/echo/v4"
@@ -17,4 +18,5 @@ func Register(e *echo.Group, app app.App) {
 
 	accounts.Register(v1.Group("/accounts"), app)
 	transactions.Register(v1.Group("/transactions"), app)
+	operationsType.Register(v1.Group("/operations-types"), app)
 }
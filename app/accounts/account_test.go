@@ -2,19 +2,27 @@ package accounts
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/jorgepiresg/ChallangePismo/store"
+	storeAccounts "github.com/jorgepiresg/ChallangePismo/store/accounts"
 	"github.com/sirupsen/logrus"
 )
 
 func TestCreate(t *testing.T) {
 
+	limit, given := modelMoney.MustParse("1000"), modelMoney.MustParse("0")
+
 	type fields struct {
 		accounts *mocksStore.MockIAccounts
 	}
@@ -27,45 +35,113 @@ func TestCreate(t *testing.T) {
 	}{
 		"should be able to create a new account": {
 			input: modelAccounts.Create{
-				DocumentNumber: "111.111.111-11",
+				DocumentNumber: "529.982.247-25",
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Create(gomock.Any(), modelAccounts.Create{DocumentNumber: "52998224725", DocumentType: "CPF", AvailableCreditLimit: &limit, Currency: "BRL", StatementClosingDay: 1}).Times(1).Return(modelAccounts.Account{
+					ID:             "id",
+					DocumentNumber: "52998224725",
+					Currency:       "BRL",
+				}, nil)
+			},
+			expected: modelAccounts.Account{
+				ID:             "id",
+				DocumentNumber: "52998224725",
+				Currency:       "BRL",
+			},
+		},
+		"should be able to create a new account with currency and statement closing day": {
+			input: modelAccounts.Create{
+				DocumentNumber:       "52998224725",
+				AvailableCreditLimit: &given,
+				Currency:             "usd",
+				StatementClosingDay:  10,
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().Create(gomock.Any(), modelAccounts.Create{DocumentNumber: "52998224725", DocumentType: "CPF", AvailableCreditLimit: &given, Currency: "USD", StatementClosingDay: 10}).Times(1).Return(modelAccounts.Account{
+					ID:             "id",
+					DocumentNumber: "52998224725",
+					Currency:       "USD",
+				}, nil)
+			},
+			expected: modelAccounts.Account{
+				ID:             "id",
+				DocumentNumber: "52998224725",
+				Currency:       "USD",
+			},
+		},
+		"should not be able to create a new account with error currency invalid": {
+			input: modelAccounts.Create{
+				DocumentNumber: "52998224725",
+				Currency:       "ABC",
+			},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("currency invalid"),
+		},
+		"should not be able to create a new account with error statement closing day invalid": {
+			input: modelAccounts.Create{
+				DocumentNumber:      "52998224725",
+				StatementClosingDay: 31,
+			},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("statement closing day invalid"),
+		},
+		"should be able to create a new business account": {
+			input: modelAccounts.Create{
+				DocumentNumber: "11.222.333/0001-81",
 			},
 			prepare: func(f *fields) {
-				f.accounts.EXPECT().GetByDocument(gomock.Any(), "11111111111").Times(1).Return(modelAccounts.Account{}, fmt.Errorf("any"))
-				f.accounts.EXPECT().Create(gomock.Any(), modelAccounts.Create{DocumentNumber: "11111111111"}).Times(1).Return(modelAccounts.Account{
+				f.accounts.EXPECT().Create(gomock.Any(), modelAccounts.Create{DocumentNumber: "11222333000181", DocumentType: "CNPJ", AvailableCreditLimit: &limit, Currency: "BRL", StatementClosingDay: 1}).Times(1).Return(modelAccounts.Account{
 					ID:             "id",
-					DocumentNumber: "11111111111",
+					DocumentNumber: "11222333000181",
+					DocumentType:   "CNPJ",
+					Currency:       "BRL",
 				}, nil)
 			},
 			expected: modelAccounts.Account{
 				ID:             "id",
-				DocumentNumber: "11111111111",
+				DocumentNumber: "11222333000181",
+				DocumentType:   "CNPJ",
+				Currency:       "BRL",
 			},
 		},
 		"should not be able to create a new account with error document number invalid caracters": {
 			input: modelAccounts.Create{
-				DocumentNumber: "111.111.111-AB",
+				DocumentNumber: "529.982.247-AB",
 			},
 			prepare: func(f *fields) {},
-			err:     fmt.Errorf("document number invalid"),
+			err:     modelAccounts.ErrDocumentNumberNotNumeric,
 		},
-		"should not be able to create a new account with error document number invalid": {
+		"should not be able to create a new account with error document number length invalid": {
 			input: modelAccounts.Create{
 				DocumentNumber: "111",
 			},
 			prepare: func(f *fields) {},
-			err:     fmt.Errorf("document number invalid"),
+			err:     modelAccounts.ErrDocumentNumberLength,
+		},
+		"should not be able to create a new account with error document number check digits invalid": {
+			input: modelAccounts.Create{
+				DocumentNumber: "529.982.247-26",
+			},
+			prepare: func(f *fields) {},
+			err:     modelAccounts.ErrDocumentNumberCheckDigits,
+		},
+		"should not be able to create a new account with error document number of another type": {
+			input: modelAccounts.Create{
+				DocumentNumber: "529.982.247-25",
+				DocumentType:   "cnpj",
+			},
+			prepare: func(f *fields) {},
+			err:     modelAccounts.ErrDocumentNumberTypeMismatch,
 		},
 		"should not be able to create a new account with error account alredy exist": {
 			input: modelAccounts.Create{
-				DocumentNumber: "11111111111",
+				DocumentNumber: "52998224725",
 			},
 			prepare: func(f *fields) {
-				f.accounts.EXPECT().GetByDocument(gomock.Any(), "11111111111").Times(1).Return(modelAccounts.Account{
-					ID:             "id",
-					DocumentNumber: "11111111111",
-				}, nil)
+				f.accounts.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, storeAccounts.ErrDocumentNumberTaken)
 			},
-			err: fmt.Errorf("account alredy exist"),
+			err: ErrAccountAlreadyExists,
 		},
 	}
 
@@ -84,12 +160,13 @@ func TestCreate(t *testing.T) {
 				Store: store.Store{
 					Accounts: accountsMock,
 				},
-				Log: logrus.New(),
+				Log:                logrus.New(),
+				DefaultCreditLimit: limit,
 			})
 
 			res, err := a.Create(context.Background(), tt.input)
 
-			if err != nil && err.Error() != tt.err.Error() {
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
 			}
 			if res != tt.expected {
@@ -119,11 +196,18 @@ func TestGetByAccountID(t *testing.T) {
 			expected: modelAccounts.Account{ID: "id", DocumentNumber: "11111111111"},
 		},
 		"should not be able to get account by id with error account not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to get account by id with error in store": {
 			input: "id",
 			prepare: func(f *fields) {
 				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, fmt.Errorf("any"))
 			},
-			err: fmt.Errorf("account not found"),
+			err: modelErrors.Unavailable("fail to get account", nil),
 		},
 	}
 
@@ -155,3 +239,379 @@ func TestGetByAccountID(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeStatus(t *testing.T) {
+	type fields struct {
+		accounts     *mocksStore.MockIAccounts
+		transactions *mocksStore.MockITransactions
+	}
+
+	active := modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", Status: modelAccounts.StatusActive}
+	blocked := modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", Status: modelAccounts.StatusBlocked}
+	closed := modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", Status: modelAccounts.StatusClosed}
+
+	tests := map[string]struct {
+		input    modelAccounts.ChangeStatus
+		expected modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to block an account": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "blocked", Reason: " suspected fraud "},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(active, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.accounts.EXPECT().UpdateStatus(gomock.Any(), modelAccounts.StatusChange{AccountID: "id", FromStatus: "ACTIVE", ToStatus: "BLOCKED", Reason: "suspected fraud"}).Times(1).Return(blocked, nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), active).Times(1)
+			},
+			expected: blocked,
+		},
+		"should be able to close an account with nothing open": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "CLOSED", Reason: "requested by the cardholder"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(blocked, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetBalanceByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.OperationTypeBalance{{OperationTypeID: 1, Currency: "BRL"}}, nil)
+				f.accounts.EXPECT().UpdateStatus(gomock.Any(), modelAccounts.StatusChange{AccountID: "id", FromStatus: "BLOCKED", ToStatus: "CLOSED", Reason: "requested by the cardholder"}).Times(1).Return(closed, nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), blocked).Times(1)
+			},
+			expected: closed,
+		},
+		"should not be able to close an account with open transactions": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "CLOSED", Reason: "requested by the cardholder"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(active, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetBalanceByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.OperationTypeBalance{{OperationTypeID: 1, Currency: "BRL", OpenTransactions: 1}}, nil)
+			},
+			err: ErrBalanceNotZero,
+		},
+		"should not be able to reopen a closed account": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "ACTIVE", Reason: "reopen"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(closed, nil)
+			},
+			err: ErrStatusChangeNotAllowed,
+		},
+		"should not be able to change the status changed meanwhile": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "BLOCKED", Reason: "suspected fraud"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(active, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.accounts.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, storeAccounts.ErrStatusChanged)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), active).Times(1)
+			},
+			err: ErrStatusChangeNotAllowed,
+		},
+		"should not be able to change status with error reason invalid": {
+			input:   modelAccounts.ChangeStatus{AccountID: "id", Status: "BLOCKED"},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("reason invalid"),
+		},
+		"should not be able to change status with error account not found": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "BLOCKED", Reason: "suspected fraud"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to change status with error at store": {
+			input: modelAccounts.ChangeStatus{AccountID: "id", Status: "BLOCKED", Reason: "suspected fraud"},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(active, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to change status"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+				},
+			})
+
+			res, err := a.ChangeStatus(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListStatusHistory(t *testing.T) {
+	type fields struct {
+		accounts *mocksStore.MockIAccounts
+	}
+
+	change := modelAccounts.StatusChange{ChangeID: 1, AccountID: "id", FromStatus: "ACTIVE", ToStatus: "BLOCKED", Reason: "suspected fraud"}
+
+	tests := map[string]struct {
+		input    string
+		expected modelAccounts.StatusHistory
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list status history": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().ListStatusHistory(gomock.Any(), "id").Times(1).Return([]modelAccounts.StatusChange{change}, nil)
+			},
+			expected: modelAccounts.StatusHistory{AccountID: "id", Changes: []modelAccounts.StatusChange{change}},
+		},
+		"should be able to list no status history": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().ListStatusHistory(gomock.Any(), "id").Times(1).Return(nil, nil)
+			},
+			expected: modelAccounts.StatusHistory{AccountID: "id", Changes: []modelAccounts.StatusChange{}},
+		},
+		"should not be able to list status history with error account not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			expected: modelAccounts.StatusHistory{AccountID: "id", Changes: []modelAccounts.StatusChange{}},
+			err:      ErrAccountNotFound,
+		},
+		"should not be able to list status history with error at store": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().ListStatusHistory(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			expected: modelAccounts.StatusHistory{AccountID: "id", Changes: []modelAccounts.StatusChange{}},
+			err:      fmt.Errorf("fail to list status history"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts: accountsMock,
+				},
+			})
+
+			res, err := a.ListStatusHistory(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	type fields struct {
+		accounts *mocksStore.MockIAccounts
+	}
+
+	createdAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	first := modelAccounts.Account{ID: "2", DocumentNumber: "11111111112", CreatedAt: createdAt.Add(time.Hour)}
+	second := modelAccounts.Account{ID: "1", DocumentNumber: "11111111111", CreatedAt: createdAt}
+
+	tests := map[string]struct {
+		input    modelAccounts.ListFilter
+		expected modelAccounts.AccountsPage
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list accounts with next page": {
+			input: modelAccounts.ListFilter{DocumentNumber: "111.111", Limit: 1},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().List(gomock.Any(), modelAccounts.ListFilter{DocumentNumber: "111111", Limit: 2}).Times(1).Return([]modelAccounts.Account{first, second}, nil)
+			},
+			expected: modelAccounts.AccountsPage{
+				Accounts:   []modelAccounts.Account{first},
+				NextCursor: modelAccounts.Cursor{CreatedAt: first.CreatedAt, AccountID: "2"}.Encode(),
+			},
+		},
+		"should be able to list no accounts": {
+			input: modelAccounts.ListFilter{},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().List(gomock.Any(), modelAccounts.ListFilter{Limit: modelAccounts.DefaultListLimit + 1}).Times(1).Return(nil, nil)
+			},
+			expected: modelAccounts.AccountsPage{Accounts: []modelAccounts.Account{}},
+		},
+		"should not be able to list accounts with error filter invalid": {
+			input:    modelAccounts.ListFilter{Limit: modelAccounts.MaxListLimit + 1},
+			prepare:  func(f *fields) {},
+			expected: modelAccounts.AccountsPage{Accounts: []modelAccounts.Account{}},
+			err:      fmt.Errorf("limit invalid"),
+		},
+		"should not be able to list accounts with error at store": {
+			input: modelAccounts.ListFilter{},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			expected: modelAccounts.AccountsPage{Accounts: []modelAccounts.Account{}},
+			err:      fmt.Errorf("fail to list accounts"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts: accountsMock,
+				},
+			})
+
+			res, err := a.List(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type fields struct {
+		accounts *mocksStore.MockIAccounts
+	}
+
+	document := "123.456.789-09"
+	cleanDocument := "12345678909"
+	sameDocument := "52998224725"
+	businessDocument := "11222333000181"
+	closingDay := 10
+
+	account := modelAccounts.Account{ID: "id", DocumentNumber: "52998224725", DocumentType: "CPF", StatementClosingDay: 1}
+	updated := modelAccounts.Account{ID: "id", DocumentNumber: "12345678909", DocumentType: "CPF", StatementClosingDay: 1}
+
+	tests := map[string]struct {
+		input    modelAccounts.Update
+		expected modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to update the document and clear the cache of both documents": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &document},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Update(gomock.Any(), modelAccounts.Update{AccountID: "id", DocumentNumber: &cleanDocument}).Times(1).Return(updated, nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), updated).Times(1)
+			},
+			expected: updated,
+		},
+		"should be able to update the statement closing day keeping the document": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &sameDocument, StatementClosingDay: &closingDay},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{ID: "id", DocumentNumber: "52998224725", DocumentType: "CPF", StatementClosingDay: 10}, nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+			expected: modelAccounts.Account{ID: "id", DocumentNumber: "52998224725", DocumentType: "CPF", StatementClosingDay: 10},
+		},
+		"should not be able to update the document of another account": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &document},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, storeAccounts.ErrDocumentNumberTaken)
+			},
+			err: ErrAccountAlreadyExists,
+		},
+		"should not be able to update the document with a document of another type": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &businessDocument},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+			},
+			err: modelAccounts.ErrDocumentNumberTypeMismatch,
+		},
+		"should not be able to update with error payload invalid": {
+			input:   modelAccounts.Update{AccountID: "id"},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("payload invalid"),
+		},
+		"should not be able to update with error account not found": {
+			input: modelAccounts.Update{AccountID: "id", StatementClosingDay: &closingDay},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to update with error at store": {
+			input: modelAccounts.Update{AccountID: "id", StatementClosingDay: &closingDay},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelAccounts.Account{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to update account"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+
+			tt.prepare(&fields{
+				accounts: accountsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts: accountsMock,
+				},
+			})
+
+			res, err := a.Update(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -2,35 +2,57 @@ package accounts
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
+	"strings"
 
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
 	"github.com/jorgepiresg/ChallangePismo/store"
+	storeAccounts "github.com/jorgepiresg/ChallangePismo/store/accounts"
 	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	ErrAccountNotFound        = modelErrors.NotFound("ACCOUNT_NOT_FOUND", "account not found")
+	ErrAccountAlreadyExists   = modelErrors.Conflict("ACCOUNT_ALREADY_EXISTS", "account alredy exist")
+	ErrStatusChangeNotAllowed = modelErrors.Conflict("STATUS_CHANGE_NOT_ALLOWED", "status change not allowed")
+	ErrBalanceNotZero         = modelErrors.Unprocessable("ACCOUNT_BALANCE_NOT_ZERO", "account balance not zero")
+)
+
 //go:generate mockgen -source=$GOFILE -destination=../../mocks/app/accounts_mock.go -package=mocksApp
 type IAccounts interface {
 	Create(ctx context.Context, account modelAccounts.Create) (modelAccounts.Account, error)
 	GetByAccountID(ctx context.Context, AccountID string) (modelAccounts.Account, error)
+	List(ctx context.Context, filter modelAccounts.ListFilter) (modelAccounts.AccountsPage, error)
+	Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error)
+	ChangeStatus(ctx context.Context, data modelAccounts.ChangeStatus) (modelAccounts.Account, error)
+	ListStatusHistory(ctx context.Context, accountID string) (modelAccounts.StatusHistory, error)
 }
 
 type Options struct {
 	Store store.Store
 	Log   *logrus.Logger
+
+	// DefaultCreditLimit is the available credit limit of the accounts created without one.
+	DefaultCreditLimit modelMoney.Money
 }
 
 type account struct {
-	store store.Store
-	log   *logrus.Logger
+	store              store.Store
+	log                *logrus.Logger
+	defaultCreditLimit modelMoney.Money
 }
 
 func New(opts Options) IAccounts {
-	return account{
-		store: opts.Store,
-		log:   opts.Log,
-	}
+	return traced{next: account{
+		store:              opts.Store,
+		log:                opts.Log,
+		defaultCreditLimit: opts.DefaultCreditLimit,
+	}}
 }
 
 func (a account) Create(ctx context.Context, account modelAccounts.Create) (modelAccounts.Account, error) {
@@ -39,21 +61,219 @@ func (a account) Create(ctx context.Context, account modelAccounts.Create) (mode
 
 	account.DocumentNumber = utils.CleanDocument(account.DocumentNumber)
 
+	account.DocumentType = strings.ToUpper(strings.TrimSpace(account.DocumentType))
+	if account.DocumentType == "" {
+		account.DocumentType = modelAccounts.DocumentType(account.DocumentNumber)
+	}
+	if account.DocumentType == "" {
+		account.DocumentType = modelAccounts.DocumentTypeCPF
+	}
+
+	account.Currency = modelMoney.CleanCurrency(account.Currency)
+	if account.Currency == "" {
+		account.Currency = modelMoney.DefaultCurrency
+	}
+
+	if account.StatementClosingDay == 0 {
+		account.StatementClosingDay = modelStatements.DefaultClosingDay
+	}
+
+	if account.AvailableCreditLimit == nil {
+		limit := a.defaultCreditLimit
+		account.AvailableCreditLimit = &limit
+	}
+
 	if err := account.Valid(); err != nil {
 		return emptyAccount, err
 	}
 
-	if _, err := a.store.Accounts.GetByDocument(ctx, account.DocumentNumber); err == nil {
-		return emptyAccount, fmt.Errorf("account alredy exist")
+	res, err := a.store.Accounts.Create(ctx, account)
+	if errors.Is(err, storeAccounts.ErrDocumentNumberTaken) {
+		return emptyAccount, ErrAccountAlreadyExists
+	}
+	if err != nil {
+		return emptyAccount, modelErrors.Unavailable("fail to create account", err)
 	}
 
-	return a.store.Accounts.Create(ctx, account)
+	return res, nil
 }
 
 func (a account) GetByAccountID(ctx context.Context, AccountID string) (modelAccounts.Account, error) {
 	account, err := a.store.Accounts.GetByID(ctx, AccountID)
 	if err != nil {
-		return account, fmt.Errorf("account not found")
+		return account, getError(err, ErrAccountNotFound, "fail to get account")
 	}
 	return account, nil
 }
+
+// List returns a page of the accounts matching the filter, the newest first.
+func (a account) List(ctx context.Context, filter modelAccounts.ListFilter) (modelAccounts.AccountsPage, error) {
+
+	page := modelAccounts.AccountsPage{
+		Accounts: []modelAccounts.Account{},
+	}
+
+	filter.DocumentNumber = utils.CleanDocument(filter.DocumentNumber)
+
+	if err := filter.Valid(); err != nil {
+		return page, err
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	accounts, err := a.store.Accounts.List(ctx, filter)
+	if err != nil {
+		return page, modelErrors.Unavailable("fail to list accounts", err)
+	}
+
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+		last := accounts[limit-1]
+		page.NextCursor = modelAccounts.Cursor{CreatedAt: last.CreatedAt, AccountID: last.ID}.Encode()
+	}
+
+	if len(accounts) > 0 {
+		page.Accounts = accounts
+	}
+
+	return page, nil
+}
+
+// Update changes the document number, the statement closing day or the available credit limit of the account. The cache
+// is cleared under both the old and the new document number.
+func (a account) Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error) {
+
+	var res modelAccounts.Account
+
+	if update.DocumentNumber != nil {
+		document := utils.CleanDocument(*update.DocumentNumber)
+		update.DocumentNumber = &document
+	}
+
+	if err := update.Valid(); err != nil {
+		return res, err
+	}
+
+	account, err := a.store.Accounts.GetByID(ctx, update.AccountID)
+	if err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	if update.DocumentNumber != nil {
+		if err := modelAccounts.ValidDocument(account.DocumentType, *update.DocumentNumber); err != nil {
+			return res, err
+		}
+	}
+
+	res, err = a.store.Accounts.Update(ctx, update)
+	if errors.Is(err, storeAccounts.ErrDocumentNumberTaken) {
+		return res, ErrAccountAlreadyExists
+	}
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to update account", err)
+	}
+
+	a.store.Accounts.DeleteCache(ctx, account)
+	if res.DocumentNumber != account.DocumentNumber {
+		a.store.Accounts.DeleteCache(ctx, res)
+	}
+
+	return res, nil
+}
+
+// ChangeStatus moves the account to a new status, keeping the reason. An account is closed only when nothing is left open
+// in it, no debt nor credit in any currency, and is never reopened.
+func (a account) ChangeStatus(ctx context.Context, data modelAccounts.ChangeStatus) (modelAccounts.Account, error) {
+
+	var res modelAccounts.Account
+
+	if err := data.Valid(); err != nil {
+		return res, err
+	}
+
+	account, err := a.store.Accounts.GetByID(ctx, data.AccountID)
+	if err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	if !modelAccounts.CanChangeStatus(account.Status, data.Status) {
+		return res, ErrStatusChangeNotAllowed
+	}
+
+	err = a.store.WithTx(ctx, func(tx store.Store) error {
+
+		if err := tx.Accounts.Lock(ctx, account.ID); err != nil {
+			return err
+		}
+
+		if data.Status == modelAccounts.StatusClosed {
+
+			balances, err := tx.Transactions.GetBalanceByAccountID(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+
+			for _, balance := range balances {
+				if balance.OpenTransactions > 0 {
+					return ErrBalanceNotZero
+				}
+			}
+		}
+
+		res, err = tx.Accounts.UpdateStatus(ctx, modelAccounts.StatusChange{
+			AccountID:  account.ID,
+			FromStatus: account.Status,
+			ToStatus:   data.Status,
+			Reason:     data.Reason,
+		})
+
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storeAccounts.ErrStatusChanged) {
+			a.store.Accounts.DeleteCache(ctx, account)
+			return res, ErrStatusChangeNotAllowed
+		}
+		if errors.Is(err, ErrBalanceNotZero) {
+			return res, err
+		}
+		return res, modelErrors.Unavailable("fail to change status", err)
+	}
+
+	a.store.Accounts.DeleteCache(ctx, account)
+
+	return res, nil
+}
+
+// ListStatusHistory returns the status changes of the account, the oldest first.
+func (a account) ListStatusHistory(ctx context.Context, accountID string) (modelAccounts.StatusHistory, error) {
+
+	res := modelAccounts.StatusHistory{
+		AccountID: accountID,
+		Changes:   []modelAccounts.StatusChange{},
+	}
+
+	if _, err := a.store.Accounts.GetByID(ctx, accountID); err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	changes, err := a.store.Accounts.ListStatusHistory(ctx, accountID)
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to list status history", err)
+	}
+
+	if len(changes) > 0 {
+		res.Changes = changes
+	}
+
+	return res, nil
+}
+
+// getError tells a record that does not exist, returned as notFound, from a store that failed to get it.
+func getError(err error, notFound error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return modelErrors.Unavailable(message, err)
+}
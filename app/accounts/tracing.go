@@ -0,0 +1,49 @@
+package accounts
+
+import (
+	"context"
+
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
+)
+
+// traced starts a span for every call of the accounts, failed when the call returns an error.
+type traced struct {
+	next IAccounts
+}
+
+func (t traced) Create(ctx context.Context, account modelAccounts.Create) (res modelAccounts.Account, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.Create")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Create(ctx, account)
+}
+
+func (t traced) GetByAccountID(ctx context.Context, AccountID string) (res modelAccounts.Account, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.GetByAccountID")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetByAccountID(ctx, AccountID)
+}
+
+func (t traced) List(ctx context.Context, filter modelAccounts.ListFilter) (res modelAccounts.AccountsPage, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.List")
+	defer func() { tracing.End(span, err) }()
+	return t.next.List(ctx, filter)
+}
+
+func (t traced) Update(ctx context.Context, update modelAccounts.Update) (res modelAccounts.Account, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.Update")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Update(ctx, update)
+}
+
+func (t traced) ChangeStatus(ctx context.Context, data modelAccounts.ChangeStatus) (res modelAccounts.Account, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.ChangeStatus")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ChangeStatus(ctx, data)
+}
+
+func (t traced) ListStatusHistory(ctx context.Context, accountID string) (res modelAccounts.StatusHistory, err error) {
+	ctx, span := tracing.Start(ctx, "accounts.ListStatusHistory")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListStatusHistory(ctx, accountID)
+}
@@ -0,0 +1,148 @@
+package accruals
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jorgepiresg/ChallangePismo/metrics"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrDateRangeInvalid is a range ending before it starts or after today.
+var ErrDateRangeInvalid = modelErrors.Validation("DATE_RANGE_INVALID", "date range invalid")
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/accruals_mock.go -package=mocksApp
+type IAccruals interface {
+	Accrue(ctx context.Context, from, to time.Time) (modelAccruals.AccrueResult, error)
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+	Rates modelAccruals.Rates
+
+	// Now is the clock telling which dates can be accrued, time.Now when nil.
+	Now func() time.Time
+}
+
+type accruals struct {
+	store store.Store
+	log   *logrus.Logger
+	rates modelAccruals.Rates
+	now   func() time.Time
+}
+
+func New(opts Options) IAccruals {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	return accruals{
+		store: opts.Store,
+		log:   opts.Log,
+		rates: opts.Rates,
+		now:   opts.Now,
+	}
+}
+
+// Accrue charges interest and late fees on the debits overdue at each day from from to to, both included, up to today. A
+// day can be accrued again: the charges already made are kept as they are, and the ones left are made from the balances
+// at the start of that day. An account that fails is left for the next run and does not stop the others.
+func (a accruals) Accrue(ctx context.Context, from, to time.Time) (modelAccruals.AccrueResult, error) {
+
+	res := modelAccruals.AccrueResult{
+		From: modelAccruals.Day(from),
+		To:   modelAccruals.Day(to),
+	}
+
+	if res.To.Before(res.From) || res.To.After(modelAccruals.Day(a.now())) {
+		return res, ErrDateRangeInvalid
+	}
+
+	for date := res.From; !date.After(res.To); date = date.AddDate(0, 0, 1) {
+
+		accountIDs, err := a.store.Accruals.ListOverdueAccountIDs(ctx, date)
+		if err != nil {
+			return res, modelErrors.Unavailable("fail to list accounts", err)
+		}
+
+		res.Accounts += len(accountIDs)
+
+		for _, accountID := range accountIDs {
+
+			charged, err := a.accrue(ctx, accountID, date)
+			if err != nil {
+				a.log.WithField("account_id", accountID).WithField("accrual_date", date).Error(err)
+				res.Failed++
+				continue
+			}
+
+			res.Charges += charged
+		}
+	}
+
+	if res.Failed > 0 {
+		return res, fmt.Errorf("fail to accrue %d accounts", res.Failed)
+	}
+
+	return res, nil
+}
+
+// accrue makes the charges of the account at the date, returning how many were made. Charges stay off the credit limit.
+func (a accruals) accrue(ctx context.Context, accountID string, date time.Time) (int, error) {
+
+	var charged []int
+
+	err := a.store.WithTx(ctx, func(tx store.Store) error {
+
+		if err := tx.Accounts.Lock(ctx, accountID); err != nil {
+			return err
+		}
+
+		overdue, err := tx.Transactions.GetOverdueByAccountID(ctx, accountID, date)
+		if err != nil {
+			return err
+		}
+
+		for _, accrual := range modelAccruals.NewAccruals(accountID, date, overdue, a.rates) {
+
+			res, err := tx.Transactions.Create(ctx, modelTransactions.MakeTransaction{
+				AccountID:       accountID,
+				OperationTypeID: accrual.OperationTypeID,
+				Amount:          -accrual.Amount,
+				Currency:        accrual.Currency,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.Ledger.Post(ctx, modelLedger.NewTransactionEntry(res)); err != nil {
+				return err
+			}
+
+			accrual.TransactionID = res.TransactionID
+			if _, err := tx.Accruals.Create(ctx, accrual); err != nil {
+				return err
+			}
+
+			charged = append(charged, accrual.OperationTypeID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, operationTypeID := range charged {
+		metrics.TransactionCreated(operationTypeID)
+	}
+
+	return len(charged), nil
+}
@@ -0,0 +1,196 @@
+package accruals
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	storeAccruals "github.com/jorgepiresg/ChallangePismo/store/accruals"
+	"github.com/sirupsen/logrus"
+)
+
+func TestAccrue(t *testing.T) {
+
+	type fields struct {
+		accounts     *mocksStore.MockIAccounts
+		transactions *mocksStore.MockITransactions
+		ledger       *mocksStore.MockILedger
+		accruals     *mocksStore.MockIAccruals
+	}
+
+	type input struct {
+		from time.Time
+		to   time.Time
+	}
+
+	now := time.Date(2024, 2, 23, 15, 0, 0, 0, time.UTC)
+	first := time.Date(2024, 2, 21, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 2, 22, 0, 0, 0, 0, time.UTC)
+	rates := modelAccruals.Rates{Interest: big.NewRat(12, 100), LateFee: big.NewRat(2, 100)}
+
+	overdue := []modelAccruals.Overdue{{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-100"), StatementID: "statement_id"}}
+	charged := []modelAccruals.Overdue{{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-100"), StatementID: "statement_id", LateFeeCharged: true}}
+	interestCharged := []modelAccruals.Overdue{{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-100"), StatementID: "statement_id", InterestCharged: true}}
+
+	charge := func(f *fields, accrual modelAccruals.Accrual, transactionID string) {
+		transaction := modelTransactions.Transaction{TransactionID: transactionID, AccountID: accrual.AccountID, OperationTypeID: accrual.OperationTypeID, Currency: accrual.Currency, Amount: -accrual.Amount, Balance: -accrual.Amount}
+
+		f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+			AccountID:       accrual.AccountID,
+			OperationTypeID: accrual.OperationTypeID,
+			Amount:          -accrual.Amount,
+			Currency:        accrual.Currency,
+		}).Times(1).Return(transaction, nil)
+
+		f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(transaction)).Times(1).Return(modelLedger.Entry{}, nil)
+
+		accrual.TransactionID = transactionID
+		f.accruals.EXPECT().Create(gomock.Any(), accrual).Times(1).Return(accrual, nil)
+	}
+
+	tests := map[string]struct {
+		input    input
+		expected modelAccruals.AccrueResult
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to accrue a range of days": {
+			input: input{from: first, to: second.Add(10 * time.Hour)},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return([]string{"id"}, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(2).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", first).Times(1).Return(overdue, nil)
+
+				accruals := modelAccruals.NewAccruals("id", first, overdue, rates)
+				charge(f, accruals[0], "interest_id")
+				charge(f, accruals[1], "late_fee_id")
+
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), second).Times(1).Return([]string{"id"}, nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", second).Times(1).Return(charged, nil)
+
+				charge(f, modelAccruals.NewAccruals("id", second, charged, rates)[0], "second_interest_id")
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: second, Accounts: 2, Charges: 3},
+		},
+		"should be able to accrue a day again keeping the charges already made": {
+			input: input{from: first, to: first},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return([]string{"id"}, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", first).Times(1).Return(interestCharged, nil)
+
+				charge(f, modelAccruals.NewAccruals("id", first, interestCharged, rates)[0], "late_fee_id")
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: first, Accounts: 1, Charges: 1},
+		},
+		"should not be able to accrue an account with a charge already made": {
+			input: input{from: first, to: first},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return([]string{"id"}, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", first).Times(1).Return(charged, nil)
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{TransactionID: "interest_id"}, nil)
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+				f.accruals.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelAccruals.Accrual{}, storeAccruals.ErrAlreadyAccrued)
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: first, Accounts: 1, Failed: 1},
+			err:      fmt.Errorf("fail to accrue 1 accounts"),
+		},
+		"should be able to accrue nothing for accounts without overdue debits": {
+			input: input{from: first, to: first},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return([]string{"id"}, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", first).Times(1).Return(nil, nil)
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: first, Accounts: 1},
+		},
+		"should not be able to accrue an account with error at store": {
+			input: input{from: first, to: first},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return([]string{"id", "other_id"}, nil)
+
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "id", first).Times(1).Return(overdue, nil)
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, fmt.Errorf("any"))
+
+				f.accounts.EXPECT().Lock(gomock.Any(), "other_id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOverdueByAccountID(gomock.Any(), "other_id", first).Times(1).Return(nil, nil)
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: first, Accounts: 2, Failed: 1},
+			err:      fmt.Errorf("fail to accrue 1 accounts"),
+		},
+		"should not be able to accrue with error to list accounts": {
+			input: input{from: first, to: first},
+			prepare: func(f *fields) {
+				f.accruals.EXPECT().ListOverdueAccountIDs(gomock.Any(), first).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			expected: modelAccruals.AccrueResult{From: first, To: first},
+			err:      fmt.Errorf("fail to list accounts"),
+		},
+		"should not be able to accrue a range ending before it starts": {
+			input:    input{from: second, to: first},
+			prepare:  func(f *fields) {},
+			expected: modelAccruals.AccrueResult{From: second, To: first},
+			err:      fmt.Errorf("date range invalid"),
+		},
+		"should not be able to accrue days after today": {
+			input:    input{from: first, to: now.AddDate(0, 0, 1)},
+			prepare:  func(f *fields) {},
+			expected: modelAccruals.AccrueResult{From: first, To: time.Date(2024, 2, 24, 0, 0, 0, 0, time.UTC)},
+			err:      fmt.Errorf("date range invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+			ledgerMock := mocksStore.NewMockILedger(ctrl)
+			accrualsMock := mocksStore.NewMockIAccruals(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+				ledger:       ledgerMock,
+				accruals:     accrualsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+					Ledger:       ledgerMock,
+					Accruals:     accrualsMock,
+				},
+				Log:   logrus.New(),
+				Rates: rates,
+				Now:   func() time.Time { return now },
+			})
+
+			res, err := a.Accrue(context.Background(), tt.input.from, tt.input.to)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -4,25 +4,54 @@ import (
 	"log"
 
 	"github.com/jorgepiresg/ChallangePismo/app/accounts"
+	"github.com/jorgepiresg/ChallangePismo/app/accruals"
+	"github.com/jorgepiresg/ChallangePismo/app/health"
+	"github.com/jorgepiresg/ChallangePismo/app/idempotency"
+	operationsType "github.com/jorgepiresg/ChallangePismo/app/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/app/seed"
+	"github.com/jorgepiresg/ChallangePismo/app/statements"
 	"github.com/jorgepiresg/ChallangePismo/app/transactions"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	"github.com/jorgepiresg/ChallangePismo/store"
 	"github.com/sirupsen/logrus"
 )
 
 type App struct {
-	Accounts     accounts.IAccounts
-	Transactions transactions.ITransactions
+	Accounts       accounts.IAccounts
+	Transactions   transactions.ITransactions
+	OperationsType operationsType.IOperationsType
+	Statements     statements.IStatements
+	Accruals       accruals.IAccruals
+	Idempotency    idempotency.IIdempotency
+	Seed           seed.ISeed
+	Health         health.IHealth
 }
 
 type Options struct {
-	Store store.Store
-	Log   *logrus.Logger
+	Store                       store.Store
+	Log                         *logrus.Logger
+	ConvertPayments             bool
+	DischargeFutureInstallments bool
+	AccrualRates                modelAccruals.Rates
+	DefaultCreditLimit          modelMoney.Money
 }
 
 func New(opts Options) App {
 	app := App{
-		Accounts:     accounts.New(accounts.Options{Store: opts.Store, Log: opts.Log}),
-		Transactions: transactions.New(transactions.Options{Store: opts.Store, Log: opts.Log}),
+		Accounts: accounts.New(accounts.Options{Store: opts.Store, Log: opts.Log, DefaultCreditLimit: opts.DefaultCreditLimit}),
+		Transactions: transactions.New(transactions.Options{
+			Store:                       opts.Store,
+			Log:                         opts.Log,
+			ConvertPayments:             opts.ConvertPayments,
+			DischargeFutureInstallments: opts.DischargeFutureInstallments,
+		}),
+		OperationsType: operationsType.New(operationsType.Options{Store: opts.Store, Log: opts.Log}),
+		Statements:     statements.New(statements.Options{Store: opts.Store, Log: opts.Log}),
+		Accruals:       accruals.New(accruals.Options{Store: opts.Store, Log: opts.Log, Rates: opts.AccrualRates}),
+		Idempotency:    idempotency.New(idempotency.Options{Store: opts.Store, Log: opts.Log}),
+		Seed:           seed.New(seed.Options{Store: opts.Store, Log: opts.Log}),
+		Health:         health.New(health.Options{Store: opts.Store, Log: opts.Log}),
 	}
 
 	log.Println("APP Created")
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	modelHealth "github.com/jorgepiresg/ChallangePismo/model/health"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+// checkTimeout bounds each dependency check, so a dependency that hangs is reported down instead of hanging the probe.
+const checkTimeout = 2 * time.Second
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/health_mock.go -package=mocksApp
+type IHealth interface {
+	Live(ctx context.Context) modelHealth.Report
+	Ready(ctx context.Context) modelHealth.Report
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+}
+
+type health struct {
+	store store.Store
+	log   *logrus.Logger
+}
+
+func New(opts Options) IHealth {
+	return health{
+		store: opts.Store,
+		log:   opts.Log,
+	}
+}
+
+// Live reports the process up, without checking its dependencies: a restart does not fix a dependency down.
+func (h health) Live(ctx context.Context) modelHealth.Report {
+	return modelHealth.NewReport([]modelHealth.Check{})
+}
+
+// Ready checks the dependencies at the same time. The service is down without the database, and degraded without the
+// cache, which it can serve without.
+func (h health) Ready(ctx context.Context) modelHealth.Report {
+
+	pings := []struct {
+		name string
+		ping func(ctx context.Context) error
+	}{
+		{modelHealth.DependencyDatabase, h.store.Health.PingDatabase},
+		{modelHealth.DependencyCache, h.store.Health.PingCache},
+	}
+
+	checks := make([]modelHealth.Check, len(pings))
+
+	var wg sync.WaitGroup
+	for i, p := range pings {
+		wg.Add(1)
+		go func(i int, name string, ping func(ctx context.Context) error) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := ping(ctx)
+			checks[i] = modelHealth.NewCheck(name, time.Since(start), err)
+		}(i, p.name, p.ping)
+	}
+	wg.Wait()
+
+	return modelHealth.NewReport(checks, modelHealth.DependencyDatabase)
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelHealth "github.com/jorgepiresg/ChallangePismo/model/health"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+func TestLive(t *testing.T) {
+
+	t.Run("should be able to report live without checking dependencies", func(t *testing.T) {
+
+		ctrl := gomock.NewController(t)
+		healthMock := mocksStore.NewMockIHealth(ctrl)
+
+		h := New(Options{Store: store.Store{Health: healthMock}, Log: logrus.New()})
+
+		if res := h.Live(context.Background()); res.Status != modelHealth.StatusUp || len(res.Checks) != 0 {
+			t.Errorf("Expected status %s without checks got %v", modelHealth.StatusUp, res)
+		}
+	})
+}
+
+func TestReady(t *testing.T) {
+
+	type fields struct {
+		health *mocksStore.MockIHealth
+	}
+
+	tests := map[string]struct {
+		expected map[string]string
+		prepare  func(f *fields)
+	}{
+		"should be able to report ready": {
+			prepare: func(f *fields) {
+				f.health.EXPECT().PingDatabase(gomock.Any()).Times(1).Return(nil)
+				f.health.EXPECT().PingCache(gomock.Any()).Times(1).Return(nil)
+			},
+			expected: map[string]string{"": modelHealth.StatusUp, modelHealth.DependencyDatabase: modelHealth.StatusUp, modelHealth.DependencyCache: modelHealth.StatusUp},
+		},
+		"should be able to report degraded with cache down": {
+			prepare: func(f *fields) {
+				f.health.EXPECT().PingDatabase(gomock.Any()).Times(1).Return(nil)
+				f.health.EXPECT().PingCache(gomock.Any()).Times(1).Return(fmt.Errorf("connection refused"))
+			},
+			expected: map[string]string{"": modelHealth.StatusDegraded, modelHealth.DependencyDatabase: modelHealth.StatusUp, modelHealth.DependencyCache: modelHealth.StatusDown},
+		},
+		"should be able to report down with database down": {
+			prepare: func(f *fields) {
+				f.health.EXPECT().PingDatabase(gomock.Any()).Times(1).Return(fmt.Errorf("connection refused"))
+				f.health.EXPECT().PingCache(gomock.Any()).Times(1).Return(nil)
+			},
+			expected: map[string]string{"": modelHealth.StatusDown, modelHealth.DependencyDatabase: modelHealth.StatusDown, modelHealth.DependencyCache: modelHealth.StatusUp},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+			healthMock := mocksStore.NewMockIHealth(ctrl)
+
+			tt.prepare(&fields{health: healthMock})
+
+			h := New(Options{Store: store.Store{Health: healthMock}, Log: logrus.New()})
+
+			res := h.Ready(context.Background())
+
+			statuses := map[string]string{"": res.Status}
+			for _, check := range res.Checks {
+				statuses[check.Name] = check.Status
+			}
+
+			if fmt.Sprint(statuses) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected statuses %v got %v", tt.expected, statuses)
+			}
+		})
+	}
+}
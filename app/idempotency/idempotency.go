@@ -0,0 +1,99 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrKeyReused         = modelErrors.Unprocessable("IDEMPOTENCY_KEY_REUSED", "idempotency key already used with a different payload")
+	ErrRequestInProgress = modelErrors.Conflict("IDEMPOTENCY_REQUEST_IN_PROGRESS", "request with this idempotency key is in progress")
+	ErrRequestProcessed  = modelErrors.Conflict("IDEMPOTENCY_REQUEST_PROCESSED", "request with this idempotency key already made its transaction")
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/idempotency_mock.go -package=mocksApp
+type IIdempotency interface {
+	Start(ctx context.Context, key string, requestHash string) (modelIdempotency.Record, error)
+	Finish(ctx context.Context, record modelIdempotency.Record) error
+	Release(ctx context.Context, key string) error
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+}
+
+type idempotency struct {
+	store store.Store
+	log   *logrus.Logger
+}
+
+func New(opts Options) IIdempotency {
+	return idempotency{
+		store: opts.Store,
+		log:   opts.Log,
+	}
+}
+
+// Start reserves the key for the request. A completed record is returned when the request was already processed and its
+// response must be replayed; otherwise the caller owns the key and must Finish or Release it.
+func (i idempotency) Start(ctx context.Context, key string, requestHash string) (modelIdempotency.Record, error) {
+
+	record, err := i.store.Idempotency.GetByKey(ctx, key)
+	if err == nil {
+		return i.check(record, requestHash)
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return modelIdempotency.Record{}, modelErrors.Unavailable("fail to check idempotency key", err)
+	}
+
+	record = modelIdempotency.Record{Key: key, RequestHash: requestHash}
+
+	created, err := i.store.Idempotency.Create(ctx, record)
+	if err != nil {
+		return modelIdempotency.Record{}, modelErrors.Unavailable("fail to check idempotency key", err)
+	}
+
+	if created {
+		return record, nil
+	}
+
+	existing, err := i.store.Idempotency.GetByKey(ctx, key)
+	if err != nil {
+		return modelIdempotency.Record{}, modelErrors.Unavailable("fail to check idempotency key", err)
+	}
+
+	return i.check(existing, requestHash)
+}
+
+func (i idempotency) Finish(ctx context.Context, record modelIdempotency.Record) error {
+	return i.store.Idempotency.Complete(ctx, record)
+}
+
+func (i idempotency) Release(ctx context.Context, key string) error {
+	return i.store.Idempotency.Delete(ctx, key)
+}
+
+func (i idempotency) check(record modelIdempotency.Record, requestHash string) (modelIdempotency.Record, error) {
+
+	if record.RequestHash != requestHash {
+		return modelIdempotency.Record{}, ErrKeyReused
+	}
+
+	if !record.Completed() && record.Processed() {
+		return modelIdempotency.Record{}, ErrRequestProcessed
+	}
+
+	if !record.Completed() {
+		return modelIdempotency.Record{}, ErrRequestInProgress
+	}
+
+	return record, nil
+}
@@ -0,0 +1,130 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+func TestStart(t *testing.T) {
+
+	type fields struct {
+		idempotency *mocksStore.MockIIdempotency
+	}
+
+	transactionID := "transaction_id"
+
+	tests := map[string]struct {
+		key      string
+		hash     string
+		expected modelIdempotency.Record
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to start a new request": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{}, sql.ErrNoRows)
+				f.idempotency.EXPECT().Create(gomock.Any(), modelIdempotency.Record{Key: "key", RequestHash: "hash"}).Times(1).Return(true, nil)
+			},
+			expected: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+		},
+		"should be able to replay a completed request": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}, nil)
+			},
+			expected: modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201},
+		},
+		"should be able to replay a request completed concurrently": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{}, sql.ErrNoRows)
+				f.idempotency.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}, nil)
+			},
+			expected: modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201},
+		},
+		"should not be able to start with error key reused": {
+			key:  "key",
+			hash: "other",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}, nil)
+			},
+			err: ErrKeyReused,
+		},
+		"should not be able to start with error request in progress": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash"}, nil)
+			},
+			err: ErrRequestInProgress,
+		},
+		"should not be able to start with error request processed": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{Key: "key", RequestHash: "hash", TransactionID: &transactionID}, nil)
+			},
+			err: ErrRequestProcessed,
+		},
+		"should not be able to start with error in store": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to check idempotency key"),
+		},
+		"should not be able to start with error to create": {
+			key:  "key",
+			hash: "hash",
+			prepare: func(f *fields) {
+				f.idempotency.EXPECT().GetByKey(gomock.Any(), "key").Times(1).Return(modelIdempotency.Record{}, sql.ErrNoRows)
+				f.idempotency.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(false, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to check idempotency key"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			idempotencyMock := mocksStore.NewMockIIdempotency(ctrl)
+
+			tt.prepare(&fields{
+				idempotency: idempotencyMock,
+			})
+
+			i := New(Options{
+				Store: store.Store{
+					Idempotency: idempotencyMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := i.Start(context.Background(), tt.key, tt.hash)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
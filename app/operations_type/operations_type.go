@@ -0,0 +1,132 @@
+package operationsType
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrOperationTypeNotFound   = modelErrors.NotFound("OPERATION_TYPE_NOT_FOUND", "operation type id not found")
+	ErrOperationTypeNotAllowed = modelErrors.Unprocessable("OPERATION_TYPE_NOT_ALLOWED", "operation type not allowed")
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/operations_type_mock.go -package=mocksApp
+type IOperationsType interface {
+	Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error)
+	List(ctx context.Context) ([]modelOperaTionsType.OperationType, error)
+	Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error)
+	Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error)
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+}
+
+type operationsType struct {
+	store store.Store
+	log   *logrus.Logger
+}
+
+func New(opts Options) IOperationsType {
+	return operationsType{
+		store: opts.Store,
+		log:   opts.Log,
+	}
+}
+
+func (ot operationsType) Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error) {
+
+	if err := create.Valid(); err != nil {
+		return modelOperaTionsType.OperationType{}, err
+	}
+
+	operationType, err := ot.store.OperationsType.Create(ctx, create)
+	if err != nil {
+		return operationType, modelErrors.Unavailable("fail to create operation type", err)
+	}
+
+	return operationType, nil
+}
+
+func (ot operationsType) List(ctx context.Context) ([]modelOperaTionsType.OperationType, error) {
+
+	operationsType, err := ot.store.OperationsType.List(ctx)
+	if err != nil {
+		return nil, modelErrors.Unavailable("fail to list operations type", err)
+	}
+
+	if operationsType == nil {
+		operationsType = []modelOperaTionsType.OperationType{}
+	}
+
+	return operationsType, nil
+}
+
+// Update changes the description or the sign of the operation type. Transactions already made keep the sign they were made
+// with.
+func (ot operationsType) Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error) {
+
+	if err := update.Valid(); err != nil {
+		return modelOperaTionsType.OperationType{}, err
+	}
+
+	if err := ot.editable(ctx, update.OperationTypeID); err != nil {
+		return modelOperaTionsType.OperationType{}, err
+	}
+
+	operationType, err := ot.store.OperationsType.Update(ctx, update)
+	if err != nil {
+		return operationType, modelErrors.Unavailable("fail to update operation type", err)
+	}
+
+	ot.store.OperationsType.DeleteCache(ctx, update.OperationTypeID)
+
+	return operationType, nil
+}
+
+// Deactivate refuses new transactions with the operation type, keeping the ones already made.
+func (ot operationsType) Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error) {
+
+	if err := ot.editable(ctx, ID); err != nil {
+		return modelOperaTionsType.OperationType{}, err
+	}
+
+	operationType, err := ot.store.OperationsType.Deactivate(ctx, ID)
+	if err != nil {
+		return operationType, modelErrors.Unavailable("fail to deactivate operation type", err)
+	}
+
+	ot.store.OperationsType.DeleteCache(ctx, ID)
+
+	return operationType, nil
+}
+
+// editable refuses operation types that do not exist and the ones only used by the service itself.
+func (ot operationsType) editable(ctx context.Context, ID int) error {
+
+	operationType, err := ot.store.OperationsType.GetByID(ctx, ID)
+	if err != nil {
+		return getError(err, ErrOperationTypeNotFound, "fail to get operation type")
+	}
+
+	if operationType.System() {
+		return ErrOperationTypeNotAllowed
+	}
+
+	return nil
+}
+
+// getError tells a record that does not exist, returned as notFound, from a store that failed to get it.
+func getError(err error, notFound error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return modelErrors.Unavailable(message, err)
+}
@@ -0,0 +1,336 @@
+package operationsType
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/sirupsen/logrus"
+)
+
+func TestCreate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksStore.MockIOperationsType
+	}
+
+	tests := map[string]struct {
+		input    modelOperaTionsType.Create
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to create operation type": {
+			input: modelOperaTionsType.Create{Description: " tarifa ", Operation: -1},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Create(gomock.Any(), modelOperaTionsType.Create{Description: "TARIFA", Operation: -1}).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1000,
+					Description:     "TARIFA",
+					Operation:       -1,
+				}, nil)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA",
+				Operation:       -1,
+			},
+		},
+		"should not be able to create operation type with description invalid": {
+			input:   modelOperaTionsType.Create{Description: " ", Operation: -1},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("description invalid"),
+		},
+		"should not be able to create operation type with operation invalid": {
+			input:   modelOperaTionsType.Create{Description: "TARIFA"},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("operation invalid"),
+		},
+		"should not be able to create operation type with error at store": {
+			input: modelOperaTionsType.Create{Description: "TARIFA", Operation: 1},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelOperaTionsType.OperationType{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to create operation type"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			ot := New(Options{
+				Store: store.Store{
+					OperationsType: operationsTypeMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := ot.Create(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksStore.MockIOperationsType
+	}
+
+	tests := map[string]struct {
+		expected []modelOperaTionsType.OperationType
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list operations type": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().List(gomock.Any()).Times(1).Return([]modelOperaTionsType.OperationType{
+					{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+				}, nil)
+			},
+			expected: []modelOperaTionsType.OperationType{
+				{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+			},
+		},
+		"should be able to list operations type without operations type": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().List(gomock.Any()).Times(1).Return(nil, nil)
+			},
+			expected: []modelOperaTionsType.OperationType{},
+		},
+		"should not be able to list operations type with error at store": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().List(gomock.Any()).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to list operations type"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			ot := New(Options{
+				Store: store.Store{
+					OperationsType: operationsTypeMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := ot.List(context.Background())
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksStore.MockIOperationsType
+	}
+
+	description := "tarifa anual"
+	cleanDescription := "TARIFA ANUAL"
+	operation := 1
+
+	tests := map[string]struct {
+		input    modelOperaTionsType.Update
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to update operation type and delete it from cache": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1000, Description: &description},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000, Description: "TARIFA", Operation: -1}, nil)
+				f.operationsType.EXPECT().Update(gomock.Any(), modelOperaTionsType.Update{OperationTypeID: 1000, Description: &cleanDescription}).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1000,
+					Description:     "TARIFA ANUAL",
+					Operation:       -1,
+				}, nil)
+				f.operationsType.EXPECT().DeleteCache(gomock.Any(), 1000).Times(1)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA ANUAL",
+				Operation:       -1,
+			},
+		},
+		"should not be able to update operation type with payload invalid": {
+			input:   modelOperaTionsType.Update{OperationTypeID: 1000},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("payload invalid"),
+		},
+		"should not be able to update operation type not found": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1001, Operation: &operation},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1001).Times(1).Return(modelOperaTionsType.OperationType{}, sql.ErrNoRows)
+			},
+			err: ErrOperationTypeNotFound,
+		},
+		"should not be able to update operation type of reversals": {
+			input: modelOperaTionsType.Update{OperationTypeID: 5, Operation: &operation},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 5).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 5, Description: "ESTORNO"}, nil)
+			},
+			err: fmt.Errorf("operation type not allowed"),
+		},
+		"should not be able to update operation type with error at store": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1000, Operation: &operation},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000, Description: "TARIFA", Operation: -1}, nil)
+				f.operationsType.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(modelOperaTionsType.OperationType{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to update operation type"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			ot := New(Options{
+				Store: store.Store{
+					OperationsType: operationsTypeMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := ot.Update(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestDeactivate(t *testing.T) {
+
+	type fields struct {
+		operationsType *mocksStore.MockIOperationsType
+	}
+
+	deactivatedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    int
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to deactivate operation type and delete it from cache": {
+			input: 1000,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000, Description: "TARIFA", Operation: -1}, nil)
+				f.operationsType.EXPECT().Deactivate(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1000,
+					Description:     "TARIFA",
+					Operation:       -1,
+					DeactivatedAt:   &deactivatedAt,
+				}, nil)
+				f.operationsType.EXPECT().DeleteCache(gomock.Any(), 1000).Times(1)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA",
+				Operation:       -1,
+				DeactivatedAt:   &deactivatedAt,
+			},
+		},
+		"should not be able to deactivate operation type not found": {
+			input: 1001,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1001).Times(1).Return(modelOperaTionsType.OperationType{}, sql.ErrNoRows)
+			},
+			err: ErrOperationTypeNotFound,
+		},
+		"should not be able to deactivate operation type of refunds": {
+			input: 6,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 6).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 6, Description: "REEMBOLSO"}, nil)
+			},
+			err: fmt.Errorf("operation type not allowed"),
+		},
+		"should not be able to deactivate operation type with error at store": {
+			input: 1000,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000, Description: "TARIFA", Operation: -1}, nil)
+				f.operationsType.EXPECT().Deactivate(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to deactivate operation type"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				operationsType: operationsTypeMock,
+			})
+
+			ot := New(Options{
+				Store: store.Store{
+					OperationsType: operationsTypeMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := ot.Deactivate(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package seed
+
+import (
+	"context"
+	"errors"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	modelSeed "github.com/jorgepiresg/ChallangePismo/model/seed"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	storeAccounts "github.com/jorgepiresg/ChallangePismo/store/accounts"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/seed_mock.go -package=mocksApp
+type ISeed interface {
+	Run(ctx context.Context) (modelSeed.Result, error)
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+}
+
+type seed struct {
+	store store.Store
+	log   *logrus.Logger
+}
+
+func New(opts Options) ISeed {
+	return seed{
+		store: opts.Store,
+		log:   opts.Log,
+	}
+}
+
+// Run creates the default operation types and the demo accounts missing. It can be run again: what is already there, even
+// if changed since, is kept as it is.
+func (s seed) Run(ctx context.Context) (modelSeed.Result, error) {
+
+	var res modelSeed.Result
+
+	for _, operationType := range modelOperaTionsType.Defaults {
+
+		created, err := s.store.OperationsType.Seed(ctx, operationType)
+		if err != nil {
+			return res, modelErrors.Unavailable("fail to seed operation types", err)
+		}
+
+		if created {
+			res.OperationTypes++
+		}
+	}
+
+	for _, account := range modelSeed.Accounts {
+
+		_, err := s.store.Accounts.Create(ctx, account)
+		if errors.Is(err, storeAccounts.ErrDocumentNumberTaken) {
+			continue
+		}
+		if err != nil {
+			return res, modelErrors.Unavailable("fail to seed accounts", err)
+		}
+
+		res.Accounts++
+	}
+
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	modelSeed "github.com/jorgepiresg/ChallangePismo/model/seed"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	storeAccounts "github.com/jorgepiresg/ChallangePismo/store/accounts"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRun(t *testing.T) {
+
+	type fields struct {
+		accounts       *mocksStore.MockIAccounts
+		operationsType *mocksStore.MockIOperationsType
+	}
+
+	tests := map[string]struct {
+		expected modelSeed.Result
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to seed": {
+			prepare: func(f *fields) {
+				for _, operationType := range modelOperaTionsType.Defaults {
+					f.operationsType.EXPECT().Seed(gomock.Any(), operationType).Times(1).Return(true, nil)
+				}
+				for _, account := range modelSeed.Accounts {
+					f.accounts.EXPECT().Create(gomock.Any(), account).Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				}
+			},
+			expected: modelSeed.Result{OperationTypes: len(modelOperaTionsType.Defaults), Accounts: len(modelSeed.Accounts)},
+		},
+		"should be able to seed again keeping what is already there": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Seed(gomock.Any(), gomock.Any()).Times(len(modelOperaTionsType.Defaults)).Return(false, nil)
+				f.accounts.EXPECT().Create(gomock.Any(), gomock.Any()).Times(len(modelSeed.Accounts)).Return(modelAccounts.Account{}, storeAccounts.ErrDocumentNumberTaken)
+			},
+		},
+		"should not be able to seed with error at operation types": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Seed(gomock.Any(), modelOperaTionsType.Defaults[0]).Times(1).Return(false, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to seed operation types"),
+		},
+		"should not be able to seed with error at accounts": {
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().Seed(gomock.Any(), gomock.Any()).Times(len(modelOperaTionsType.Defaults)).Return(true, nil)
+				f.accounts.EXPECT().Create(gomock.Any(), modelSeed.Accounts[0]).Times(1).Return(modelAccounts.Account{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to seed accounts"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+
+			tt.prepare(&fields{
+				accounts:       accountsMock,
+				operationsType: operationsTypeMock,
+			})
+
+			s := New(Options{
+				Store: store.Store{
+					Accounts:       accountsMock,
+					OperationsType: operationsTypeMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := s.Run(context.Background())
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	storeStatements "github.com/jorgepiresg/ChallangePismo/store/statements"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrAccountNotFound   = modelErrors.NotFound("ACCOUNT_NOT_FOUND", "account id not found")
+	ErrStatementNotFound = modelErrors.NotFound("STATEMENT_NOT_FOUND", "statement not found")
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/app/statements_mock.go -package=mocksApp
+type IStatements interface {
+	ListByAccountID(ctx context.Context, accountID string) (modelStatements.AccountStatements, error)
+	GetByID(ctx context.Context, accountID, statementID string) (modelStatements.Statement, error)
+	CloseCycles(ctx context.Context, date time.Time) (modelStatements.CloseResult, error)
+}
+
+type Options struct {
+	Store store.Store
+	Log   *logrus.Logger
+}
+
+type statements struct {
+	store store.Store
+	log   *logrus.Logger
+}
+
+func New(opts Options) IStatements {
+	return statements{
+		store: opts.Store,
+		log:   opts.Log,
+	}
+}
+
+func (s statements) ListByAccountID(ctx context.Context, accountID string) (modelStatements.AccountStatements, error) {
+
+	res := modelStatements.AccountStatements{
+		AccountID:  accountID,
+		Statements: []modelStatements.Statement{},
+	}
+
+	if _, err := s.store.Accounts.GetByID(ctx, accountID); err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	statements, err := s.store.Statements.ListByAccountID(ctx, accountID)
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to list statements", err)
+	}
+
+	if len(statements) > 0 {
+		res.Statements = statements
+	}
+
+	return res, nil
+}
+
+func (s statements) GetByID(ctx context.Context, accountID, statementID string) (modelStatements.Statement, error) {
+
+	statement, err := s.store.Statements.GetByID(ctx, statementID)
+	if err != nil {
+		return modelStatements.Statement{}, getError(err, ErrStatementNotFound, "fail to get statement")
+	}
+
+	if statement.AccountID != accountID {
+		return modelStatements.Statement{}, ErrStatementNotFound
+	}
+
+	return statement, nil
+}
+
+// CloseCycles closes the cycles of the accounts whose statements close on the day of date, at the start of that day. It
+// can be run again for the same date: the cycles already closed are kept as they are. An account that fails is left for the
+// next run and does not stop the others.
+func (s statements) CloseCycles(ctx context.Context, date time.Time) (modelStatements.CloseResult, error) {
+
+	res := modelStatements.CloseResult{
+		ClosingDate: modelStatements.ClosingDate(date),
+	}
+
+	accounts, err := s.store.Accounts.ListByClosingDay(ctx, res.ClosingDate.Day())
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to list accounts", err)
+	}
+
+	res.Accounts = len(accounts)
+
+	for _, account := range accounts {
+
+		closed, err := s.close(ctx, account, res.ClosingDate)
+		if err != nil {
+			s.log.WithField("account_id", account.ID).WithField("closing_date", res.ClosingDate).Error(err)
+			res.Failed++
+			continue
+		}
+
+		res.Statements += closed
+	}
+
+	if res.Failed > 0 {
+		return res, fmt.Errorf("fail to close %d cycles", res.Failed)
+	}
+
+	return res, nil
+}
+
+// close creates the statements of the account cycle ending at the closing date, returning how many were created.
+func (s statements) close(ctx context.Context, account modelAccounts.Account, closingDate time.Time) (int, error) {
+
+	var closed int
+
+	err := s.store.WithTx(ctx, func(tx store.Store) error {
+
+		previous, err := tx.Statements.GetLastByAccountID(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		var start *time.Time
+		if len(previous) > 0 {
+			if !previous[0].ClosingDate.Before(closingDate) {
+				return nil
+			}
+			start = &previous[0].ClosingDate
+		}
+
+		movements, err := tx.Statements.GetMovements(ctx, account.ID, start, closingDate)
+		if err != nil {
+			return err
+		}
+
+		for _, statement := range modelStatements.NewCycle(account.ID, closingDate, previous, movements) {
+			if _, err := tx.Statements.Create(ctx, statement); err != nil {
+				return err
+			}
+			closed++
+		}
+
+		return nil
+	})
+	if errors.Is(err, storeStatements.ErrStatementClosed) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return closed, nil
+}
+
+// getError tells a record that does not exist, returned as notFound, from a store that failed to get it.
+func getError(err error, notFound error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return modelErrors.Unavailable(message, err)
+}
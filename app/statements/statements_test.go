@@ -0,0 +1,283 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	storeStatements "github.com/jorgepiresg/ChallangePismo/store/statements"
+	"github.com/sirupsen/logrus"
+)
+
+func TestListByAccountID(t *testing.T) {
+
+	type fields struct {
+		accounts   *mocksStore.MockIAccounts
+		statements *mocksStore.MockIStatements
+	}
+
+	statement := modelStatements.Statement{StatementID: "statement_id", AccountID: "id", Currency: "BRL", ClosingBalance: modelMoney.MustParse("100")}
+
+	tests := map[string]struct {
+		input    string
+		expected modelStatements.AccountStatements
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list statements": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.statements.EXPECT().ListByAccountID(gomock.Any(), "id").Times(1).Return([]modelStatements.Statement{statement}, nil)
+			},
+			expected: modelStatements.AccountStatements{AccountID: "id", Statements: []modelStatements.Statement{statement}},
+		},
+		"should be able to list no statements": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.statements.EXPECT().ListByAccountID(gomock.Any(), "id").Times(1).Return(nil, nil)
+			},
+			expected: modelStatements.AccountStatements{AccountID: "id", Statements: []modelStatements.Statement{}},
+		},
+		"should not be able to list statements with error account id not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to list statements with error at store": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.statements.EXPECT().ListByAccountID(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to list statements"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			statementsMock := mocksStore.NewMockIStatements(ctrl)
+
+			tt.prepare(&fields{
+				accounts:   accountsMock,
+				statements: statementsMock,
+			})
+
+			s := New(Options{
+				Store: store.Store{
+					Accounts:   accountsMock,
+					Statements: statementsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := s.ListByAccountID(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+
+	type fields struct {
+		statements *mocksStore.MockIStatements
+	}
+
+	type input struct {
+		accountID   string
+		statementID string
+	}
+
+	statement := modelStatements.Statement{StatementID: "statement_id", AccountID: "id", Currency: "BRL", ClosingBalance: modelMoney.MustParse("100")}
+
+	tests := map[string]struct {
+		input    input
+		expected modelStatements.Statement
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get statement by id": {
+			input: input{accountID: "id", statementID: "statement_id"},
+			prepare: func(f *fields) {
+				f.statements.EXPECT().GetByID(gomock.Any(), "statement_id").Times(1).Return(statement, nil)
+			},
+			expected: statement,
+		},
+		"should not be able to get statement of another account": {
+			input: input{accountID: "other_id", statementID: "statement_id"},
+			prepare: func(f *fields) {
+				f.statements.EXPECT().GetByID(gomock.Any(), "statement_id").Times(1).Return(statement, nil)
+			},
+			err: ErrStatementNotFound,
+		},
+		"should not be able to get statement not found": {
+			input: input{accountID: "id", statementID: "statement_id"},
+			prepare: func(f *fields) {
+				f.statements.EXPECT().GetByID(gomock.Any(), "statement_id").Times(1).Return(modelStatements.Statement{}, sql.ErrNoRows)
+			},
+			err: ErrStatementNotFound,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			statementsMock := mocksStore.NewMockIStatements(ctrl)
+
+			tt.prepare(&fields{
+				statements: statementsMock,
+			})
+
+			s := New(Options{
+				Store: store.Store{
+					Statements: statementsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := s.GetByID(context.Background(), tt.input.accountID, tt.input.statementID)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestCloseCycles(t *testing.T) {
+
+	type fields struct {
+		accounts   *mocksStore.MockIAccounts
+		statements *mocksStore.MockIStatements
+	}
+
+	previousClosing := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	closing := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	account := modelAccounts.Account{ID: "id", Currency: "BRL", StatementClosingDay: 10}
+	other := modelAccounts.Account{ID: "other_id", Currency: "BRL", StatementClosingDay: 10}
+
+	previous := []modelStatements.Statement{{StatementID: "previous_id", AccountID: "id", Currency: "BRL", ClosingDate: previousClosing, ClosingBalance: modelMoney.MustParse("70")}}
+	movements := []modelStatements.Movement{{Currency: "BRL", Purchases: modelMoney.MustParse("100"), Payments: modelMoney.MustParse("70")}}
+
+	tests := map[string]struct {
+		input    time.Time
+		expected modelStatements.CloseResult
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to close the cycles of the day": {
+			input: time.Date(2024, 2, 10, 3, 0, 0, 0, time.UTC),
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListByClosingDay(gomock.Any(), 10).Times(1).Return([]modelAccounts.Account{account, other}, nil)
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "id").Times(1).Return(previous, nil)
+				f.statements.EXPECT().GetMovements(gomock.Any(), "id", &previousClosing, closing).Times(1).Return(movements, nil)
+				f.statements.EXPECT().Create(gomock.Any(), modelStatements.NewCycle("id", closing, previous, movements)[0]).Times(1).Return(modelStatements.Statement{StatementID: "statement_id"}, nil)
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "other_id").Times(1).Return(nil, nil)
+				f.statements.EXPECT().GetMovements(gomock.Any(), "other_id", nil, closing).Times(1).Return(nil, nil)
+			},
+			expected: modelStatements.CloseResult{ClosingDate: closing, Accounts: 2, Statements: 1},
+		},
+		"should be able to close the cycles of the day again keeping the ones already closed": {
+			input: closing,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListByClosingDay(gomock.Any(), 10).Times(1).Return([]modelAccounts.Account{account, other}, nil)
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "id").Times(1).Return([]modelStatements.Statement{{AccountID: "id", Currency: "BRL", ClosingDate: closing}}, nil)
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "other_id").Times(1).Return(nil, nil)
+				f.statements.EXPECT().GetMovements(gomock.Any(), "other_id", nil, closing).Times(1).Return(movements, nil)
+				f.statements.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelStatements.Statement{}, storeStatements.ErrStatementClosed)
+			},
+			expected: modelStatements.CloseResult{ClosingDate: closing, Accounts: 2},
+		},
+		"should not be able to close the cycle of an account with error at store": {
+			input: closing,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListByClosingDay(gomock.Any(), 10).Times(1).Return([]modelAccounts.Account{account, other}, nil)
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any"))
+
+				f.statements.EXPECT().GetLastByAccountID(gomock.Any(), "other_id").Times(1).Return(nil, nil)
+				f.statements.EXPECT().GetMovements(gomock.Any(), "other_id", nil, closing).Times(1).Return(movements, nil)
+				f.statements.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelStatements.Statement{StatementID: "statement_id"}, nil)
+			},
+			expected: modelStatements.CloseResult{ClosingDate: closing, Accounts: 2, Statements: 1, Failed: 1},
+			err:      fmt.Errorf("fail to close 1 cycles"),
+		},
+		"should not be able to close the cycles with error to list accounts": {
+			input: closing,
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().ListByClosingDay(gomock.Any(), 10).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			expected: modelStatements.CloseResult{ClosingDate: closing},
+			err:      fmt.Errorf("fail to list accounts"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			statementsMock := mocksStore.NewMockIStatements(ctrl)
+
+			tt.prepare(&fields{
+				accounts:   accountsMock,
+				statements: statementsMock,
+			})
+
+			s := New(Options{
+				Store: store.Store{
+					Accounts:   accountsMock,
+					Statements: statementsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := s.CloseCycles(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package transactions
+
+import (
+	"context"
+
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
+)
+
+// traced starts a span for every call of the transactions, failed when the call returns an error.
+type traced struct {
+	next ITransactions
+}
+
+func (t traced) Make(ctx context.Context, data modelTransactions.MakeTransaction) (err error) {
+	ctx, span := tracing.Start(ctx, "transactions.Make")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Make(ctx, data)
+}
+
+func (t traced) Reverse(ctx context.Context, transactionID string) (res modelTransactions.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.Reverse")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Reverse(ctx, transactionID)
+}
+
+func (t traced) Refund(ctx context.Context, data modelTransactions.Refund) (res modelTransactions.Transaction, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.Refund")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Refund(ctx, data)
+}
+
+func (t traced) ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) (res modelTransactions.TransactionsPage, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.ListByAccountID")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListByAccountID(ctx, filter)
+}
+
+func (t traced) GetBalance(ctx context.Context, accountID string) (res modelTransactions.BalanceSummary, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.GetBalance")
+	defer func() { tracing.End(span, err) }()
+	return t.next.GetBalance(ctx, accountID)
+}
+
+func (t traced) ListFutureInstallments(ctx context.Context, accountID string) (res modelTransactions.FutureInstallments, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.ListFutureInstallments")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListFutureInstallments(ctx, accountID)
+}
+
+func (t traced) ListAllocations(ctx context.Context, transactionID string) (res modelAllocations.TransactionAllocations, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.ListAllocations")
+	defer func() { tracing.End(span, err) }()
+	return t.next.ListAllocations(ctx, transactionID)
+}
+
+func (t traced) Reconcile(ctx context.Context, accountID string) (res modelTransactions.ReconcileResult, err error) {
+	ctx, span := tracing.Start(ctx, "transactions.Reconcile")
+	defer func() { tracing.End(span, err) }()
+	return t.next.Reconcile(ctx, accountID)
+}
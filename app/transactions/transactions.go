@@ -2,33 +2,76 @@ package transactions
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"errors"
+	"time"
 
+	"github.com/jorgepiresg/ChallangePismo/metrics"
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/jorgepiresg/ChallangePismo/store"
+	storeTransactions "github.com/jorgepiresg/ChallangePismo/store/transactions"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	ErrAccountNotFound          = modelErrors.NotFound("ACCOUNT_NOT_FOUND", "account id not found")
+	ErrTransactionNotFound      = modelErrors.NotFound("TRANSACTION_NOT_FOUND", "transaction id not found")
+	ErrOperationTypeNotFound    = modelErrors.NotFound("OPERATION_TYPE_NOT_FOUND", "operation type id not found")
+	ErrOperationTypeNotAllowed  = modelErrors.Unprocessable("OPERATION_TYPE_NOT_ALLOWED", "operation type not allowed")
+	ErrCreditLimitExceeded      = modelErrors.LimitExceeded("CREDIT_LIMIT_EXCEEDED", "credit limit exceeded")
+	ErrExchangeRateNotAvailable = modelErrors.Unprocessable("EXCHANGE_RATE_NOT_AVAILABLE", "exchange rate not available")
+	ErrTransactionReversed      = modelErrors.Conflict("TRANSACTION_REVERSED", "transaction already reversed")
+	ErrTransactionNotReversible = modelErrors.Unprocessable("TRANSACTION_NOT_REVERSIBLE", "transaction cannot be reversed")
+	ErrRefundAmountExceeded     = modelErrors.LimitExceeded("REFUND_AMOUNT_EXCEEDED", "amount exceeds refundable amount")
+	ErrAccountBlocked           = modelErrors.Unprocessable("ACCOUNT_BLOCKED", "account blocked")
+	ErrAccountClosed            = modelErrors.Unprocessable("ACCOUNT_CLOSED", "account closed")
+	ErrAccountNotActive         = modelErrors.Unprocessable("ACCOUNT_NOT_ACTIVE", "account not active")
+)
+
 //go:generate mockgen -source=$GOFILE -destination=../../mocks/app/transactions_mock.go -package=mocksApp
 type ITransactions interface {
 	Make(ctx context.Context, data modelTransactions.MakeTransaction) error
+	Reverse(ctx context.Context, transactionID string) (modelTransactions.Transaction, error)
+	Refund(ctx context.Context, data modelTransactions.Refund) (modelTransactions.Transaction, error)
+	ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) (modelTransactions.TransactionsPage, error)
+	GetBalance(ctx context.Context, accountID string) (modelTransactions.BalanceSummary, error)
+	ListFutureInstallments(ctx context.Context, accountID string) (modelTransactions.FutureInstallments, error)
+	ListAllocations(ctx context.Context, transactionID string) (modelAllocations.TransactionAllocations, error)
+	Reconcile(ctx context.Context, accountID string) (modelTransactions.ReconcileResult, error)
 }
 
 type Options struct {
 	Store store.Store
 	Log   *logrus.Logger
+
+	// ConvertPayments converts payments made in a foreign currency into the account currency, so they settle its debits.
+	ConvertPayments bool
+
+	// DischargeFutureInstallments lets payments settle installments before they are due.
+	DischargeFutureInstallments bool
 }
 
 type transactions struct {
-	store store.Store
-	log   *logrus.Logger
+	store                       store.Store
+	log                         *logrus.Logger
+	convertPayments             bool
+	dischargeFutureInstallments bool
 }
 
 func New(opts Options) ITransactions {
-	return transactions{
-		store: opts.Store,
-		log:   opts.Log,
-	}
+	return traced{next: transactions{
+		store:                       opts.Store,
+		log:                         opts.Log,
+		convertPayments:             opts.ConvertPayments,
+		dischargeFutureInstallments: opts.DischargeFutureInstallments,
+	}}
 }
 
 func (t transactions) Make(ctx context.Context, data modelTransactions.MakeTransaction) error {
@@ -37,71 +80,642 @@ func (t transactions) Make(ctx context.Context, data modelTransactions.MakeTrans
 		return err
 	}
 
+	if err := data.ValidateInstallments(); err != nil {
+		return err
+	}
+
+	data.Currency = modelMoney.CleanCurrency(data.Currency)
+	if data.Currency != "" && !modelMoney.ValidCurrency(data.Currency) {
+		return modelMoney.ErrCurrencyInvalid
+	}
+
 	operationType, err := t.store.OperationsType.GetByID(ctx, data.OperationTypeID)
 	if err != nil {
-		return fmt.Errorf("operation type id not found")
+		return getError(err, ErrOperationTypeNotFound, "fail to get operation type")
+	}
+
+	if operationType.System() || !operationType.Active() {
+		return ErrOperationTypeNotAllowed
+	}
+
+	if data.Installments <= 1 {
+		data.Installments = 0
+	}
+
+	if data.Installments > 1 && (operationType.OperationTypeID != modelOperaTionsType.InstallmentPurchaseID || operationType.Operation > 0) {
+		return modelTransactions.ErrInstallmentsInvalid
 	}
 
-	if _, err := t.store.Accounts.GetByID(ctx, data.AccountID); err != nil {
-		return fmt.Errorf("account id not found")
+	account, err := t.store.Accounts.GetByID(ctx, data.AccountID)
+	if err != nil {
+		return getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	if account.Status == modelAccounts.StatusClosed {
+		return ErrAccountClosed
+	}
+
+	if data.Currency == "" {
+		data.Currency = account.Currency
 	}
 
 	data.SetOperationInAmount(operationType.Operation)
 
-	res, err := t.store.Transactions.Create(ctx, data)
+	if data.Amount < 0 && account.Status == modelAccounts.StatusBlocked {
+		return ErrAccountBlocked
+	}
+
+	if data.Amount > 0 && data.Currency != account.Currency && t.convertPayments {
+		if err := t.convertToAccountCurrency(ctx, &data, account.Currency); err != nil {
+			return err
+		}
+	}
+
+	if data.Amount < 0 {
+		if data.LimitAmount, err = t.exchange(ctx, data.Amount, data.Currency, account.Currency); err != nil {
+			return err
+		}
+	}
+
+	// A purchase in installments takes its limit through its installments, each its share, so they give it back as they
+	// are settled.
+	limit := data.LimitAmount
+	if data.Installments > 1 {
+		data.LimitAmount = 0
+	}
+
+	var settled modelMoney.Money
+
+	err = t.store.WithTx(ctx, func(tx store.Store) error {
+
+		res, err := tx.Transactions.Create(ctx, data)
+		if err != nil {
+			return err
+		}
+
+		if err := setIdempotencyTransaction(ctx, tx, res.TransactionID); err != nil {
+			return err
+		}
+
+		entry := modelLedger.NewTransactionEntry(res)
+
+		if data.Installments > 1 {
+
+			installments := make([]modelTransactions.Transaction, data.Installments)
+
+			for i, installment := range modelTransactions.NewInstallments(res, data.Installments, limit) {
+				if installments[i], err = tx.Transactions.Create(ctx, installment); err != nil {
+					return err
+				}
+			}
+
+			entry = modelLedger.NewInstallmentsEntry(res, installments)
+		}
+
+		if _, err := tx.Ledger.Post(ctx, entry); err != nil {
+			return err
+		}
+
+		settled, err = t.discharge(ctx, tx, res, account.Currency)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("fail to make transaction")
+		if errors.Is(err, storeTransactions.ErrInsufficientCreditLimit) {
+			return ErrCreditLimitExceeded
+		}
+		if errors.Is(err, storeTransactions.ErrAccountNotActive) {
+			return ErrAccountNotActive
+		}
+		return modelErrors.Wrap(err, "fail to make transaction")
 	}
 
-	go t.discharge(context.Background(), res)
+	metrics.TransactionCreated(data.OperationTypeID)
+	metrics.Settled(data.Currency, settled)
+
+	t.store.Accounts.DeleteCache(ctx, account)
 
 	return nil
 }
 
-func (t transactions) discharge(ctx context.Context, data modelTransactions.Transaction) {
+// Reverse gives back the whole amount of the transaction not yet refunded.
+func (t transactions) Reverse(ctx context.Context, transactionID string) (modelTransactions.Transaction, error) {
+	return t.compensate(ctx, transactionID, 0, modelOperaTionsType.ReversalID, modelLedger.KindReversal)
+}
+
+func (t transactions) Refund(ctx context.Context, data modelTransactions.Refund) (modelTransactions.Transaction, error) {
+
+	if err := data.Valid(); err != nil {
+		return modelTransactions.Transaction{}, err
+	}
+
+	return t.compensate(ctx, data.TransactionID, data.Amount, modelOperaTionsType.RefundID, modelLedger.KindRefund)
+}
+
+func (t transactions) ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) (modelTransactions.TransactionsPage, error) {
+
+	page := modelTransactions.TransactionsPage{
+		Transactions: []modelTransactions.Transaction{},
+	}
+
+	if err := filter.Valid(); err != nil {
+		return page, err
+	}
+
+	if _, err := t.store.Accounts.GetByID(ctx, filter.AccountID); err != nil {
+		return page, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	transactions, err := t.store.Transactions.ListByAccountID(ctx, filter)
+	if err != nil {
+		return page, modelErrors.Unavailable("fail to list transactions", err)
+	}
+
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		last := transactions[limit-1]
+		page.NextCursor = modelTransactions.Cursor{EventDate: last.EventDate, TransactionID: last.TransactionID}.Encode()
+	}
+
+	if len(transactions) > 0 {
+		page.Transactions = transactions
+	}
+
+	return page, nil
+}
+
+func (t transactions) GetBalance(ctx context.Context, accountID string) (modelTransactions.BalanceSummary, error) {
+
+	account, err := t.store.Accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return modelTransactions.BalanceSummary{}, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	balances, err := t.store.Transactions.GetBalanceByAccountID(ctx, accountID)
+	if err != nil {
+		return modelTransactions.BalanceSummary{}, modelErrors.Unavailable("fail to get balance", err)
+	}
+
+	return modelTransactions.NewBalanceSummary(accountID, account.Currency, balances), nil
+}
+
+// ListFutureInstallments returns the installments of the account not due yet.
+func (t transactions) ListFutureInstallments(ctx context.Context, accountID string) (modelTransactions.FutureInstallments, error) {
+
+	res := modelTransactions.FutureInstallments{
+		AccountID:    accountID,
+		Installments: []modelTransactions.Transaction{},
+	}
+
+	if _, err := t.store.Accounts.GetByID(ctx, accountID); err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	installments, err := t.store.Transactions.ListFutureInstallmentsByAccountID(ctx, accountID, time.Now())
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to list installments", err)
+	}
+
+	if len(installments) > 0 {
+		res.Installments = installments
+	}
+
+	return res, nil
+}
+
+// ListAllocations returns how the transaction was applied: the debits a payment paid, or the payments that paid a debit.
+func (t transactions) ListAllocations(ctx context.Context, transactionID string) (modelAllocations.TransactionAllocations, error) {
+
+	res := modelAllocations.TransactionAllocations{
+		TransactionID: transactionID,
+		Allocations:   []modelAllocations.Allocation{},
+	}
+
+	if _, err := t.store.Transactions.GetByID(ctx, transactionID); err != nil {
+		return res, getError(err, ErrTransactionNotFound, "fail to get transaction")
+	}
+
+	allocations, err := t.store.Allocations.ListByTransactionID(ctx, transactionID)
+	if err != nil {
+		return res, modelErrors.Unavailable("fail to list allocations", err)
+	}
+
+	if len(allocations) > 0 {
+		res.Allocations = allocations
+	}
+
+	return res, nil
+}
+
+// Reconcile applies again the credits of the account still owed to the cardholder to its open debits, oldest credit first,
+// as if each one were paid now. It settles debits left open by a discharge that did not cover them at the time, such as
+// installments due after the payment, and does nothing when there is nothing to settle.
+func (t transactions) Reconcile(ctx context.Context, accountID string) (modelTransactions.ReconcileResult, error) {
+
+	res := modelTransactions.ReconcileResult{AccountID: accountID}
+	settled := map[string]modelMoney.Money{}
+
+	account, err := t.store.Accounts.GetByID(ctx, accountID)
+	if err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	err = t.store.WithTx(ctx, func(tx store.Store) error {
+
+		if err := tx.Accounts.Lock(ctx, accountID); err != nil {
+			return err
+		}
+
+		credits, err := tx.Transactions.GetOpenCreditsByAccountID(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		for _, credit := range credits {
+
+			credit.Amount = credit.Balance
+
+			amount, err := t.discharge(ctx, tx, credit, account.Currency)
+			if err != nil {
+				return err
+			}
+
+			settled[credit.Currency] += amount
+		}
+
+		res.Credits = len(credits)
+
+		return nil
+	})
+	if err != nil {
+		return res, modelErrors.Wrap(err, "fail to reconcile balances")
+	}
+
+	for currency, amount := range settled {
+		metrics.Settled(currency, amount)
+	}
+
+	t.store.Accounts.DeleteCache(ctx, account)
+
+	return res, nil
+}
+
+// discharge settles the open debits of the account in the payment currency, oldest due first, posting the settlements to the
+// ledger, and gives back to the available credit limit the share of the limit each debit took that its settlement covers.
+// It must run in the same database transaction that created the payment, and returns
+// the amount settled, in the payment currency.
+func (t transactions) discharge(ctx context.Context, tx store.Store, data modelTransactions.Transaction, accountCurrency string) (modelMoney.Money, error) {
 
 	if data.Amount <= 0 {
-		return
+		return 0, nil
+	}
+
+	defer func(start time.Time) {
+		metrics.DischargeDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	var dueBy *time.Time
+	if !t.dischargeFutureInstallments {
+		now := time.Now()
+		dueBy = &now
 	}
 
-	transactions, err := t.store.Transactions.GetToDischargeByAccountID(ctx, data.AccountID)
+	transactions, err := tx.Transactions.GetToDischargeByAccountID(ctx, data.AccountID, data.Currency, dueBy)
 	if err != nil {
-		t.log.Error(err)
-		return
+		return 0, err
 	}
 
 	if len(transactions) == 0 {
-		return
+		return 0, nil
 	}
 
-	currentBalance := data.Amount
+	entry := modelLedger.NewDischargeEntry(data)
+	available := data.Amount
+	var restored modelMoney.Money
 
 	for _, transaction := range transactions {
 
-		if currentBalance == 0 {
+		if available == 0 {
 			break
 		}
 
-		currentBalance += transaction.Balance
+		settled := transaction.Balance.Abs()
+		if settled > available {
+			settled = available
+		}
+
+		share, err := t.limitShare(ctx, transaction, settled, accountCurrency)
+		if err != nil {
+			return 0, err
+		}
+
+		entry.Settle(data, transaction, settled)
+		available -= settled
+		restored += share
+	}
+
+	entry, err = tx.Ledger.Post(ctx, entry)
+	if err != nil {
+		return 0, err
+	}
+
+	if allocations := modelAllocations.FromEntry(entry); len(allocations) > 0 {
+		if err := tx.Allocations.Create(ctx, allocations); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Accounts.UpdateAvailableCreditLimit(ctx, data.AccountID, restored); err != nil {
+		return 0, err
+	}
+
+	return data.Amount - available, nil
+}
+
+// compensate makes the transaction giving back amount of the original one, all that is left of it when amount is 0. The
+// open balance of the original is used first, then the settlements it took part in are reopened, most recent first, so a
+// reversed debit hands its payments back as credit and a reversed payment leaves the debits it paid open again. A purchase
+// in installments is given back through its installments, the last one first; installments cannot be reversed on their
+// own. The available credit limit follows the debt the account is left with: a reversed debit gives back the share of its
+// limit still open, a reversed payment takes back the shares of the debits it leaves open.
+func (t transactions) compensate(ctx context.Context, transactionID string, amount modelMoney.Money, operationTypeID int, kind string) (modelTransactions.Transaction, error) {
+
+	var res modelTransactions.Transaction
+
+	original, err := t.store.Transactions.GetByID(ctx, transactionID)
+	if err != nil {
+		return res, getError(err, ErrTransactionNotFound, "fail to get transaction")
+	}
+
+	if original.ReversedTransactionID != nil || original.ParentTransactionID != nil {
+		return res, ErrTransactionNotReversible
+	}
+
+	account, err := t.store.Accounts.GetByID(ctx, original.AccountID)
+	if err != nil {
+		return res, getError(err, ErrAccountNotFound, "fail to get account")
+	}
+
+	if account.Status == modelAccounts.StatusClosed {
+		return res, ErrAccountClosed
+	}
+
+	err = t.store.WithTx(ctx, func(tx store.Store) error {
+
+		if err := tx.Accounts.Lock(ctx, original.AccountID); err != nil {
+			return err
+		}
+
+		original, err := tx.Transactions.GetByID(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		reversed, err := tx.Transactions.GetReversedAmount(ctx, original.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		left := original.Amount.Abs() - reversed
+		if left <= 0 {
+			return ErrTransactionReversed
+		}
+
+		if amount == 0 {
+			amount = left
+		}
+
+		if amount > left {
+			return ErrRefundAmountExceeded
+		}
+
+		targets := []modelTransactions.Transaction{original}
+
+		if original.Installments != nil {
+			if targets, err = tx.Transactions.ListInstallmentsByParentID(ctx, original.TransactionID); err != nil {
+				return err
+			}
+		}
+
+		unsettled := amount
+		given := make([]modelMoney.Money, len(targets))
+		var limit modelMoney.Money
+
+		for i, target := range targets {
+
+			given[i] = target.Balance.Abs()
+			if given[i] > unsettled {
+				given[i] = unsettled
+			}
+
+			unsettled -= given[i]
+
+			if original.Amount < 0 {
+				share, err := t.limitShare(ctx, target, given[i], account.Currency)
+				if err != nil {
+					return err
+				}
+				limit += share
+			}
+		}
+
+		reopened := make([][]modelLedger.Settlement, len(targets))
+
+		for i, target := range targets {
+
+			if unsettled == 0 {
+				break
+			}
+
+			settlements, err := tx.Ledger.GetSettlements(ctx, target.TransactionID)
+			if err != nil {
+				return err
+			}
+
+			for _, settlement := range settlements {
+
+				if unsettled == 0 {
+					break
+				}
+
+				if settlement.Amount > unsettled {
+					settlement.Amount = unsettled
+				}
+
+				reopened[i] = append(reopened[i], settlement)
+				given[i] += settlement.Amount
+				unsettled -= settlement.Amount
+
+				if original.Amount > 0 {
+					debit, err := tx.Transactions.GetByID(ctx, settlement.TransactionID)
+					if err != nil {
+						return err
+					}
+
+					share, err := t.limitShare(ctx, debit, -settlement.Amount, account.Currency)
+					if err != nil {
+						return err
+					}
+					limit += share
+				}
+			}
+		}
+
+		compensating := modelTransactions.MakeTransaction{
+			AccountID:             original.AccountID,
+			OperationTypeID:       operationTypeID,
+			Amount:                -amount,
+			Currency:              original.Currency,
+			ReversedTransactionID: &original.TransactionID,
+		}
+
+		if original.Amount < 0 {
+			compensating.Amount = amount
+		}
+
+		if original.Amount > 0 {
+			compensating.LimitAmount = limit
+		}
+
+		res, err = tx.Transactions.Create(ctx, compensating)
+		if err != nil {
+			return err
+		}
+
+		if err := setIdempotencyTransaction(ctx, tx, res.TransactionID); err != nil {
+			return err
+		}
+
+		entry := modelLedger.NewReversalEntry(kind, res)
+
+		for i, target := range targets {
+			for _, settlement := range reopened[i] {
+
+				counterpart := modelTransactions.Transaction{TransactionID: settlement.TransactionID, Currency: target.Currency}
+
+				if target.Amount < 0 {
+					entry.Reopen(counterpart, target, settlement.Amount)
+				} else {
+					entry.Reopen(target, counterpart, settlement.Amount)
+				}
+			}
+		}
+
+		for i, target := range targets {
+			if given[i] > 0 {
+				entry.Reverse(target, res, given[i], 0)
+			}
+		}
 
-		if currentBalance >= 0 {
-			transaction.Balance = 0
+		if unsettled > 0 {
+			entry.Reverse(original, res, unsettled, unsettled)
 		}
 
-		if currentBalance < 0 {
-			transaction.Balance = currentBalance
-			currentBalance = 0
+		res.Balance = unsettled
+		if original.Amount > 0 {
+			res.Balance = -unsettled
 		}
 
-		err := t.store.Transactions.UpdateBalance(ctx, transaction)
+		entry, err = tx.Ledger.Post(ctx, entry)
 		if err != nil {
-			t.log.Error(err)
+			return err
+		}
+
+		if allocations := modelAllocations.FromEntry(entry); len(allocations) > 0 {
+			if err := tx.Allocations.Create(ctx, allocations); err != nil {
+				return err
+			}
+		}
+
+		if original.Amount > 0 || limit == 0 {
+			return nil
+		}
+
+		return tx.Accounts.UpdateAvailableCreditLimit(ctx, original.AccountID, limit)
+	})
+	if err != nil {
+		if errors.Is(err, storeTransactions.ErrInsufficientCreditLimit) {
+			return res, ErrCreditLimitExceeded
 		}
+		if errors.Is(err, storeTransactions.ErrAccountNotActive) {
+			return res, ErrAccountNotActive
+		}
+		return res, modelErrors.Wrap(err, "fail to reverse transaction")
+	}
+
+	metrics.TransactionCreated(operationTypeID)
+
+	t.store.Accounts.DeleteCache(ctx, account)
+
+	return res, nil
+}
+
+// convertToAccountCurrency converts the amount into the account currency, keeping the original amount and currency.
+func (t transactions) convertToAccountCurrency(ctx context.Context, data *modelTransactions.MakeTransaction, accountCurrency string) error {
+
+	converted, err := t.exchange(ctx, data.Amount, data.Currency, accountCurrency)
+	if err != nil {
+		return err
+	}
+
+	originalAmount, originalCurrency := data.Amount, data.Currency
+
+	data.OriginalAmount = &originalAmount
+	data.OriginalCurrency = &originalCurrency
+	data.Amount = converted
+	data.Currency = accountCurrency
+
+	return nil
+}
+
+// limitShare returns, in the account currency, the limit that goes back to the account when amount more of the debit is
+// settled, or that is taken again when amount is negative. Debits made before the limit they took was kept give back the
+// amount at the current exchange rate, and charges nothing, as they never took from it.
+func (t transactions) limitShare(ctx context.Context, debit modelTransactions.Transaction, amount modelMoney.Money, accountCurrency string) (modelMoney.Money, error) {
+
+	if debit.LimitAmount != nil {
+		return debit.LimitShare(amount), nil
 	}
 
-	data.Balance = currentBalance
+	if amount == 0 || modelOperaTionsType.Charge(debit.OperationTypeID) {
+		return 0, nil
+	}
+
+	return t.exchange(ctx, amount, debit.Currency, accountCurrency)
+}
+
+func (t transactions) exchange(ctx context.Context, amount modelMoney.Money, from, to string) (modelMoney.Money, error) {
+
+	if from == to {
+		return amount, nil
+	}
+
+	rate, err := t.store.FX.Rate(ctx, from, to)
+	if err != nil {
+		return 0, ErrExchangeRateNotAvailable
+	}
+
+	return amount.Convert(rate), nil
+}
+
+// setIdempotencyTransaction records the transaction with the idempotency key of the request, when it was sent with one, in
+// the database transaction that made it, so a retry never makes it again even if the response was not stored.
+func setIdempotencyTransaction(ctx context.Context, tx store.Store, transactionID string) error {
+
+	key, ok := modelIdempotency.KeyFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	return tx.Idempotency.SetTransaction(ctx, key, transactionID)
+}
 
-	if err := t.store.Transactions.UpdateBalance(ctx, data); err != nil {
-		t.log.Error(err)
+// getError tells a record that does not exist, returned as notFound, from a store that failed to get it.
+func getError(err error, notFound error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
 	}
+	return modelErrors.Unavailable(message, err)
 }
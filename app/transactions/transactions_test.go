@@ -2,16 +2,24 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"sync"
+	"math/big"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	mocksStore "github.com/jorgepiresg/ChallangePismo/mocks/store"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/jorgepiresg/ChallangePismo/store"
+	storeTransactions "github.com/jorgepiresg/ChallangePismo/store/transactions"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,19 +29,81 @@ func TestMake(t *testing.T) {
 		transactions   *mocksStore.MockITransactions
 		accounts       *mocksStore.MockIAccounts
 		operationsType *mocksStore.MockIOperationsType
-		wg             *sync.WaitGroup
+		fx             *mocksStore.MockIRates
+		ledger         *mocksStore.MockILedger
+		allocations    *mocksStore.MockIAllocations
+		idempotency    *mocksStore.MockIIdempotency
 	}
 
+	originalAmount, originalCurrency := modelMoney.MustParse("10"), "USD"
+
 	tests := map[string]struct {
-		input   modelTransactions.MakeTransaction
-		err     error
-		prepare func(f *fields)
+		input           modelTransactions.MakeTransaction
+		convertPayments bool
+		idempotencyKey  string
+		err             error
+		prepare         func(f *fields)
 	}{
+		"should be able to make a new transaction recording it with the idempotency key": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("10"),
+			},
+			idempotencyKey: "key",
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{
+					TransactionID: "transaction_id",
+					AccountID:     "id",
+					Currency:      "BRL",
+					Amount:        modelMoney.MustParse("10"),
+					Balance:       modelMoney.MustParse("10"),
+				}, nil)
+
+				f.idempotency.EXPECT().SetTransaction(gomock.Any(), "key", "transaction_id").Times(1).Return(nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should not be able to make a new transaction with error to record it with the idempotency key": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("10"),
+			},
+			idempotencyKey: "key",
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{TransactionID: "transaction_id"}, nil)
+
+				f.idempotency.EXPECT().SetTransaction(gomock.Any(), "key", "transaction_id").Times(1).Return(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to make transaction"),
+		},
 		"should be able to make a new transaction": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 1,
-				Amount:          10.50,
+				Amount:          modelMoney.MustParse("10.50"),
 			},
 			prepare: func(f *fields) {
 				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
@@ -42,43 +112,222 @@ func TestMake(t *testing.T) {
 					Operation:       -1,
 				}, nil)
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
 
 				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
 					AccountID:       "id",
-					Amount:          -10.50,
+					Amount:          modelMoney.MustParse("-10.50"),
+					OperationTypeID: 1,
+					Currency:        "BRL",
+					LimitAmount:     modelMoney.MustParse("-10.50"),
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("-10.50"),
+					OperationTypeID: 1,
+					Balance:         modelMoney.MustParse("-10.50"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should not be able to make a new transaction with error credit limit exceeded": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10.50"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1,
+					Description:     "COMPRA A VISTA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, storeTransactions.ErrInsufficientCreditLimit)
+			},
+			err: ErrCreditLimitExceeded,
+		},
+		"should not be able to make a new transaction with error account blocked meanwhile": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10.50"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
 					OperationTypeID: 1,
-				}).Times(1).Return(modelTransactions.Transaction{}, nil)
+					Description:     "COMPRA A VISTA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL", Status: modelAccounts.StatusActive}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, storeTransactions.ErrAccountNotActive)
 			},
+			err: ErrAccountNotActive,
 		},
 		"should not be able to make a new transaction with error amount negative invalid": {
 			input: modelTransactions.MakeTransaction{
-				Amount: -10,
+				Amount: modelMoney.MustParse("-10"),
 			},
 			prepare: func(f *fields) {},
 			err:     fmt.Errorf("amount invalid"),
 		},
 		"should not be able to make a new transaction with error amount 0 is invalid": {
 			input: modelTransactions.MakeTransaction{
-				Amount: 0,
+				Amount: modelMoney.MustParse("0"),
 			},
 			prepare: func(f *fields) {},
 			err:     fmt.Errorf("amount invalid"),
 		},
 		"should not be able to make a new transaction with error operation type id not found": {
 			input: modelTransactions.MakeTransaction{
-				Amount: 10,
+				Amount: modelMoney.MustParse("10"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 0).Times(1).Return(modelOperaTionsType.OperationType{}, sql.ErrNoRows)
+			},
+			err: ErrOperationTypeNotFound,
+		},
+		"should be able to make a new purchase in installments": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 2,
+				Amount:          modelMoney.MustParse("100"),
+				Installments:    3,
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 2).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 2,
+					Description:     "COMPRA PARCELADA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				purchase := modelTransactions.Transaction{
+					TransactionID:   "parent_id",
+					AccountID:       "id",
+					OperationTypeID: 2,
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("-100"),
+					Balance:         modelMoney.MustParse("-100"),
+					EventDate:       time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC),
+				}
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					OperationTypeID: 2,
+					Amount:          modelMoney.MustParse("-100"),
+					Currency:        "BRL",
+					Installments:    3,
+				}).Times(1).Return(purchase, nil)
+
+				installments := []modelTransactions.Transaction{}
+
+				for i, installment := range modelTransactions.NewInstallments(purchase, 3, modelMoney.MustParse("-100")) {
+
+					created := modelTransactions.Transaction{
+						TransactionID:   fmt.Sprintf("installment_%d", i+1),
+						AccountID:       "id",
+						OperationTypeID: 2,
+						Currency:        "BRL",
+						Amount:          installment.Amount,
+					}
+					installments = append(installments, created)
+
+					f.transactions.EXPECT().Create(gomock.Any(), installment).Times(1).Return(created, nil)
+				}
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewInstallmentsEntry(purchase, installments)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should not be able to make a new transaction in installments with an operation type other than COMPRA PARCELADA": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("100"),
+				Installments:    3,
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1}, nil)
+			},
+			err: fmt.Errorf("installments invalid"),
+		},
+		"should not be able to make a new transaction with more installments than allowed": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 2,
+				Amount:          modelMoney.MustParse("100"),
+				Installments:    modelTransactions.MaxInstallments + 1,
+			},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("installments invalid"),
+		},
+		"should not be able to make a new transaction with an operation type of reversals": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 5,
+				Amount:          modelMoney.MustParse("10"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 5).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 5, Description: "ESTORNO"}, nil)
+			},
+			err: fmt.Errorf("operation type not allowed"),
+		},
+		"should not be able to make a new transaction with an operation type of charges": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 7,
+				Amount:          modelMoney.MustParse("10"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 7).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 7, Description: "JUROS", Operation: -1}, nil)
+			},
+			err: fmt.Errorf("operation type not allowed"),
+		},
+		"should not be able to make a new transaction with a deactivated operation type": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1000,
+				Amount:          modelMoney.MustParse("10"),
 			},
 			prepare: func(f *fields) {
-				f.operationsType.EXPECT().GetByID(gomock.Any(), 0).Times(1).Return(modelOperaTionsType.OperationType{}, fmt.Errorf("any"))
+				deactivatedAt := time.Now()
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1000).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1000, Description: "TARIFA", Operation: -1, DeactivatedAt: &deactivatedAt}, nil)
 			},
-			err: fmt.Errorf("operation type id not found"),
+			err: fmt.Errorf("operation type not allowed"),
 		},
 		"should not be able to make a new transaction with error account id not found": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "invalid_id",
 				OperationTypeID: 1,
-				Amount:          10.00,
+				Amount:          modelMoney.MustParse("10.00"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1,
+					Description:     "COMPRA A VISTA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "invalid_id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to make a new transaction with error to post in ledger": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10.00"),
 			},
 			prepare: func(f *fields) {
 				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
@@ -87,15 +336,25 @@ func TestMake(t *testing.T) {
 					Operation:       -1,
 				}, nil)
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "invalid_id").Times(1).Return(modelAccounts.Account{}, fmt.Errorf("any"))
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("-10.00"),
+					OperationTypeID: 1,
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, fmt.Errorf("any"))
 			},
-			err: fmt.Errorf("account id not found"),
+			err: fmt.Errorf("fail to make transaction"),
 		},
 		"should not be able to make a new transaction with error fail to make transaction": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 1,
-				Amount:          10.00,
+				Amount:          modelMoney.MustParse("10.00"),
 			},
 			prepare: func(f *fields) {
 				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
@@ -104,12 +363,14 @@ func TestMake(t *testing.T) {
 					Operation:       -1,
 				}, nil)
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
 
 				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
 					AccountID:       "id",
-					Amount:          -10.00,
+					Amount:          modelMoney.MustParse("-10.00"),
 					OperationTypeID: 1,
+					Currency:        "BRL",
+					LimitAmount:     modelMoney.MustParse("-10.00"),
 				}).Times(1).Return(modelTransactions.Transaction{}, fmt.Errorf("any"))
 			},
 			err: fmt.Errorf("fail to make transaction"),
@@ -118,163 +379,111 @@ func TestMake(t *testing.T) {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 4,
-				Amount:          60.00,
+				Amount:          modelMoney.MustParse("60.00"),
 			},
 			prepare: func(f *fields) {
 
-				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
-					OperationTypeID: 4,
-					Description:     "PAGAMENTO",
-					Operation:       1,
-				}, nil)
-
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
-
-				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-				}).Times(1).Return(modelTransactions.Transaction{
+				payment := modelTransactions.Transaction{
 					TransactionID:   "transaction_id",
 					AccountID:       "id",
-					Amount:          60.00,
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
 					OperationTypeID: 4,
-					Balance:         60,
-				}, nil)
-
-				f.wg.Add(4)
+					Balance:         modelMoney.MustParse("60"),
+				}
 
-				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.Transaction{
+				debits := []modelTransactions.Transaction{
 					{
 						TransactionID:   "1",
 						AccountID:       "id",
+						Currency:        "BRL",
 						OperationTypeID: 1,
-						Amount:          -50,
-						Balance:         -50,
+						Amount:          modelMoney.MustParse("-50"),
+						Balance:         modelMoney.MustParse("-50"),
 					},
 					{
 						TransactionID:   "2",
 						AccountID:       "id",
+						Currency:        "BRL",
 						OperationTypeID: 1,
-						Amount:          -23.50,
-						Balance:         -23.50,
+						Amount:          modelMoney.MustParse("-23.50"),
+						Balance:         modelMoney.MustParse("-23.50"),
 					},
-				}, nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				}
 
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "1",
-					AccountID:       "id",
-					OperationTypeID: 1,
-					Amount:          -50,
-					Balance:         0,
-				}).Times(1).Return(nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
 
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "2",
-					AccountID:       "id",
-					OperationTypeID: 1,
-					Amount:          -23.50,
-					Balance:         -13.50,
-				}).Times(1).Return(nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
 
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
 					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
 					OperationTypeID: 4,
-					Amount:          60,
-					Balance:         0,
-				}).Times(1).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+					Currency:        "BRL",
+				}).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
+
+				entry := dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("50")}, settlement{debits[1], modelMoney.MustParse("10")})
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
 
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("60")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
 			},
 		},
 
-		"should be able to make a new transaction with dischard where current balance is zero": {
+		"should be able to make a new transaction with dischard of charges not restoring credit limit": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 4,
-				Amount:          60.00,
+				Amount:          modelMoney.MustParse("60.00"),
 			},
 			prepare: func(f *fields) {
 
-				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
-					OperationTypeID: 4,
-					Description:     "PAGAMENTO",
-					Operation:       1,
-				}, nil)
-
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
-
-				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-				}).Times(1).Return(modelTransactions.Transaction{
+				payment := modelTransactions.Transaction{
 					TransactionID:   "transaction_id",
 					AccountID:       "id",
-					Amount:          60.00,
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
 					OperationTypeID: 4,
-					Balance:         60,
-				}, nil)
+					Balance:         modelMoney.MustParse("60"),
+				}
 
-				f.wg.Add(3)
-
-				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.Transaction{
+				debits := []modelTransactions.Transaction{
 					{
 						TransactionID:   "1",
 						AccountID:       "id",
+						Currency:        "BRL",
 						OperationTypeID: 1,
-						Amount:          -60,
-						Balance:         -60,
+						Amount:          modelMoney.MustParse("-50"),
+						Balance:         modelMoney.MustParse("-50"),
 					},
 					{
 						TransactionID:   "2",
 						AccountID:       "id",
-						OperationTypeID: 1,
-						Amount:          -23.50,
-						Balance:         -23.50,
+						Currency:        "BRL",
+						OperationTypeID: modelOperaTionsType.InterestID,
+						Amount:          modelMoney.MustParse("-2.50"),
+						Balance:         modelMoney.MustParse("-2.50"),
 					},
-				}, nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
-
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "1",
-					AccountID:       "id",
-					OperationTypeID: 1,
-					Amount:          -60,
-					Balance:         0,
-				}).Times(1).Return(nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
-
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
-					AccountID:       "id",
-					OperationTypeID: 4,
-					Amount:          60,
-					Balance:         0,
-				}).Times(1).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
-
-			},
-		},
-
-		"should be able to make a new transaction with error in dischard": {
-			input: modelTransactions.MakeTransaction{
-				AccountID:       "id",
-				OperationTypeID: 4,
-				Amount:          60.00,
-			},
-			prepare: func(f *fields) {
+					{
+						TransactionID:   "3",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: modelOperaTionsType.LateFeeID,
+						Amount:          modelMoney.MustParse("-1"),
+						Balance:         modelMoney.MustParse("-1"),
+					},
+				}
 
 				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
 					OperationTypeID: 4,
@@ -282,128 +491,1221 @@ func TestMake(t *testing.T) {
 					Operation:       1,
 				}, nil)
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
 
 				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
 					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-				}).Times(1).Return(modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
-					AccountID:       "id",
-					Amount:          60.00,
+					Amount:          modelMoney.MustParse("60.00"),
 					OperationTypeID: 4,
-					Balance:         60,
-				}, nil)
+					Currency:        "BRL",
+				}).Times(1).Return(payment, nil)
 
-				f.wg.Add(3)
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
 
-				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.Transaction{
-					{
-						TransactionID:   "1",
-						AccountID:       "id",
-						OperationTypeID: 1,
-						Amount:          -60,
-						Balance:         -60,
-					},
-				}, nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
 
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "1",
-					AccountID:       "id",
-					OperationTypeID: 1,
-					Amount:          -60,
-					Balance:         0,
-				}).Times(1).Return(fmt.Errorf("any")).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				entry := dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("50")}, settlement{debits[1], modelMoney.MustParse("2.50")}, settlement{debits[2], modelMoney.MustParse("1")})
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
 
-				f.transactions.EXPECT().UpdateBalance(gomock.Any(), modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
-					AccountID:       "id",
-					OperationTypeID: 4,
-					Amount:          60,
-					Balance:         0,
-				}).Times(1).Return(fmt.Errorf("any")).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("50")).Times(1).Return(nil)
 
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
 			},
 		},
 
-		"should be able to make a new transaction with error to get in transactions": {
+		"should not be able to make a new transaction with error to create the allocations of the discharge": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 4,
-				Amount:          60.00,
+				Amount:          modelMoney.MustParse("60.00"),
 			},
 			prepare: func(f *fields) {
 
+				payment := modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}
+
+				debits := []modelTransactions.Transaction{
+					{
+						TransactionID:   "1",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-50"),
+						Balance:         modelMoney.MustParse("-50"),
+					},
+				}
+
 				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
 					OperationTypeID: 4,
 					Description:     "PAGAMENTO",
 					Operation:       1,
 				}, nil)
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
 
-				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-				}).Times(1).Return(modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-					Balance:         60,
-				}, nil)
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
 
-				f.wg.Add(1)
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
 
-				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any")).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				entry := dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("50")})
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
 
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(fmt.Errorf("any"))
 			},
+			err: fmt.Errorf("fail to make transaction"),
 		},
 
-		"should be able to make a new transaction with dischard with empty transactions": {
+		"should be able to make a new transaction with dischard where current balance is zero": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "id",
 				OperationTypeID: 4,
-				Amount:          60.00,
+				Amount:          modelMoney.MustParse("60.00"),
 			},
 			prepare: func(f *fields) {
 
-				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+				payment := modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
 					OperationTypeID: 4,
-					Description:     "PAGAMENTO",
-					Operation:       1,
-				}, nil)
+					Balance:         modelMoney.MustParse("60"),
+				}
 
-				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				debits := []modelTransactions.Transaction{
+					{
+						TransactionID:   "1",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-60"),
+						Balance:         modelMoney.MustParse("-60"),
+					},
+					{
+						TransactionID:   "2",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-23.50"),
+						Balance:         modelMoney.MustParse("-23.50"),
+					},
+				}
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("60")})).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("60")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+
+		"should be able to make a new transaction with partial dischard": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				payment := modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}
+
+				debits := []modelTransactions.Transaction{
+					{
+						TransactionID:   "1",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-20"),
+						Balance:         modelMoney.MustParse("-20"),
+					},
+				}
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("20")})).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("20")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+
+		"should be able to make a new transaction with dischard settling cents exactly": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("0.30"),
+			},
+			prepare: func(f *fields) {
+
+				payment := modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("0.30"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("0.30"),
+				}
+
+				debits := []modelTransactions.Transaction{
+					{TransactionID: "1", AccountID: "id", Currency: "BRL", OperationTypeID: 1, Amount: modelMoney.MustParse("-0.1"), Balance: modelMoney.MustParse("-0.1")},
+					{TransactionID: "2", AccountID: "id", Currency: "BRL", OperationTypeID: 1, Amount: modelMoney.MustParse("-0.2"), Balance: modelMoney.MustParse("-0.2")},
+				}
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(debits, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("0.1")}, settlement{debits[1], modelMoney.MustParse("0.2")})).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("0.30")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+
+		"should be able to make a new transaction with dischard giving back the limit the debits took": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("10"),
+				Currency:        "USD",
+			},
+			prepare: func(f *fields) {
+
+				payment := modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "USD",
+					Amount:          modelMoney.MustParse("10"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("10"),
+				}
+
+				limit := modelMoney.MustParse("-50")
+				debits := []modelTransactions.Transaction{
+					{TransactionID: "1", AccountID: "id", Currency: "USD", OperationTypeID: 1, Amount: modelMoney.MustParse("-20"), Balance: modelMoney.MustParse("-15"), LimitAmount: &limit},
+				}
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(payment, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), modelLedger.NewTransactionEntry(payment)).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "USD", gomock.Any()).Times(1).Return(debits, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), dischargeEntry(payment, settlement{debits[0], modelMoney.MustParse("10")})).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("25")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+
+		"should not be able to make a new transaction with error in dischard": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{
+					{
+						TransactionID:   "1",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-60"),
+						Balance:         modelMoney.MustParse("-60"),
+					},
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to make transaction"),
+		},
+
+		"should not be able to make a new transaction with error to restore credit limit": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{
+					{
+						TransactionID:   "1",
+						AccountID:       "id",
+						Currency:        "BRL",
+						OperationTypeID: 1,
+						Amount:          modelMoney.MustParse("-60"),
+						Balance:         modelMoney.MustParse("-60"),
+					},
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("60")).Times(1).Return(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to make transaction"),
+		},
+
+		"should not be able to make a new transaction with error to get in transactions": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to make transaction"),
+		},
+
+		"should be able to make a new transaction with dischard with empty transactions": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should be able to make a new payment on a blocked account": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+
+				account := modelAccounts.Account{ID: "id", Currency: "BRL", Status: modelAccounts.StatusBlocked}
+
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Currency:        "BRL",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("60.00"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("60"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should not be able to make a new debit on a blocked account": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10.50"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL", Status: modelAccounts.StatusBlocked}, nil)
+			},
+			err: ErrAccountBlocked,
+		},
+		"should not be able to make a new payment on a closed account": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("60.00"),
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{OperationTypeID: 4, Description: "PAGAMENTO", Operation: 1}, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL", Status: modelAccounts.StatusClosed}, nil)
+			},
+			err: ErrAccountClosed,
+		},
+		"should not be able to make a new transaction with error currency invalid": {
+			input: modelTransactions.MakeTransaction{
+				Amount:   modelMoney.MustParse("10"),
+				Currency: "ABC",
+			},
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("currency invalid"),
+		},
+		"should be able to make a new transaction in a foreign currency": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10"),
+				Currency:        "usd",
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1,
+					Description:     "COMPRA A VISTA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.fx.EXPECT().Rate(gomock.Any(), "USD", "BRL").Times(1).Return(big.NewRat(495, 100), nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("-10"),
+					OperationTypeID: 1,
+					Currency:        "USD",
+					LimitAmount:     modelMoney.MustParse("-49.50"),
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "USD",
+					Amount:          modelMoney.MustParse("-10"),
+					OperationTypeID: 1,
+					Balance:         modelMoney.MustParse("-10"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should not be able to make a new transaction in a foreign currency with error exchange rate not available": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("10"),
+				Currency:        "USD",
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 1).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 1,
+					Description:     "COMPRA A VISTA",
+					Operation:       -1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.fx.EXPECT().Rate(gomock.Any(), "USD", "BRL").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: ErrExchangeRateNotAvailable,
+		},
+		"should be able to make a new payment in a foreign currency settling debits of the same currency": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("10"),
+				Currency:        "USD",
+			},
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:       "id",
+					Amount:          modelMoney.MustParse("10"),
+					OperationTypeID: 4,
+					Currency:        "USD",
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "USD",
+					Amount:          modelMoney.MustParse("10"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("10"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "USD", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{
+					{TransactionID: "1", AccountID: "id", Currency: "USD", OperationTypeID: 1, Amount: modelMoney.MustParse("-10"), Balance: modelMoney.MustParse("-10")},
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.fx.EXPECT().Rate(gomock.Any(), "USD", "BRL").Times(1).Return(big.NewRat(5, 1), nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("50")).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+		"should be able to make a new payment in a foreign currency converted into the account currency": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "id",
+				OperationTypeID: 4,
+				Amount:          modelMoney.MustParse("10"),
+				Currency:        "USD",
+			},
+			convertPayments: true,
+			prepare: func(f *fields) {
+				f.operationsType.EXPECT().GetByID(gomock.Any(), 4).Times(1).Return(modelOperaTionsType.OperationType{
+					OperationTypeID: 4,
+					Description:     "PAGAMENTO",
+					Operation:       1,
+				}, nil)
+
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+
+				f.fx.EXPECT().Rate(gomock.Any(), "USD", "BRL").Times(1).Return(big.NewRat(5, 1), nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:        "id",
+					Amount:           modelMoney.MustParse("50"),
+					OperationTypeID:  4,
+					Currency:         "BRL",
+					OriginalAmount:   &originalAmount,
+					OriginalCurrency: &originalCurrency,
+				}).Times(1).Return(modelTransactions.Transaction{
+					TransactionID:   "transaction_id",
+					AccountID:       "id",
+					Currency:        "BRL",
+					Amount:          modelMoney.MustParse("50"),
+					OperationTypeID: 4,
+					Balance:         modelMoney.MustParse("50"),
+				}, nil)
+
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, nil)
+
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{}, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), modelAccounts.Account{ID: "id", Currency: "BRL"}).Times(1)
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
+			fxMock := mocksStore.NewMockIRates(ctrl)
+			ledgerMock := mocksStore.NewMockILedger(ctrl)
+			allocationsMock := mocksStore.NewMockIAllocations(ctrl)
+			idempotencyMock := mocksStore.NewMockIIdempotency(ctrl)
+
+			tt.prepare(&fields{
+				accounts:       accountsMock,
+				transactions:   transactionsMock,
+				operationsType: operationsTypeMock,
+				fx:             fxMock,
+				ledger:         ledgerMock,
+				allocations:    allocationsMock,
+				idempotency:    idempotencyMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:       accountsMock,
+					Transactions:   transactionsMock,
+					OperationsType: operationsTypeMock,
+					FX:             fxMock,
+					Ledger:         ledgerMock,
+					Allocations:    allocationsMock,
+					Idempotency:    idempotencyMock,
+				},
+				Log:             logrus.New(),
+				ConvertPayments: tt.convertPayments,
+			})
+
+			ctx := context.Background()
+			if tt.idempotencyKey != "" {
+				ctx = modelIdempotency.WithKey(ctx, tt.idempotencyKey)
+			}
+
+			err := a.Make(ctx, tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
+
+func TestListByAccountID(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+	}
+
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    modelTransactions.ListFilter
+		expected modelTransactions.TransactionsPage
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list transactions with next cursor": {
+			input: modelTransactions.ListFilter{
+				AccountID: "id",
+				Limit:     1,
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+
+				f.transactions.EXPECT().ListByAccountID(gomock.Any(), modelTransactions.ListFilter{
+					AccountID: "id",
+					Limit:     2,
+				}).Times(1).Return([]modelTransactions.Transaction{
+					{TransactionID: "2", AccountID: "id", EventDate: eventDate},
+					{TransactionID: "1", AccountID: "id", EventDate: eventDate},
+				}, nil)
+			},
+			expected: modelTransactions.TransactionsPage{
+				Transactions: []modelTransactions.Transaction{
+					{TransactionID: "2", AccountID: "id", EventDate: eventDate},
+				},
+				NextCursor: modelTransactions.Cursor{EventDate: eventDate, TransactionID: "2"}.Encode(),
+			},
+		},
+		"should be able to list transactions on the last page": {
+			input: modelTransactions.ListFilter{
+				AccountID: "id",
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+
+				f.transactions.EXPECT().ListByAccountID(gomock.Any(), modelTransactions.ListFilter{
+					AccountID: "id",
+					Limit:     modelTransactions.DefaultListLimit + 1,
+				}).Times(1).Return(nil, nil)
+			},
+			expected: modelTransactions.TransactionsPage{
+				Transactions: []modelTransactions.Transaction{},
+			},
+		},
+		"should not be able to list transactions with error filter invalid": {
+			input: modelTransactions.ListFilter{
+				AccountID: "id",
+				Limit:     1000,
+			},
+			prepare: func(f *fields) {},
+			expected: modelTransactions.TransactionsPage{
+				Transactions: []modelTransactions.Transaction{},
+			},
+			err: fmt.Errorf("limit invalid"),
+		},
+		"should not be able to list transactions with error account id not found": {
+			input: modelTransactions.ListFilter{
+				AccountID: "id",
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			expected: modelTransactions.TransactionsPage{
+				Transactions: []modelTransactions.Transaction{},
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to list transactions with error in store": {
+			input: modelTransactions.ListFilter{
+				AccountID: "id",
+			},
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.transactions.EXPECT().ListByAccountID(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			expected: modelTransactions.TransactionsPage{
+				Transactions: []modelTransactions.Transaction{},
+			},
+			err: fmt.Errorf("fail to list transactions"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := a.ListByAccountID(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected modelTransactions.BalanceSummary
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get balance": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL"}, nil)
+				f.transactions.EXPECT().GetBalanceByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.OperationTypeBalance{
+					{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "BRL", OutstandingDebt: modelMoney.MustParse("13.50"), OpenTransactions: 1},
+					{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "USD", OutstandingDebt: modelMoney.MustParse("5"), OpenTransactions: 1},
+					{OperationTypeID: 4, Description: "PAGAMENTO", Currency: "BRL", UnappliedCredit: modelMoney.MustParse("10"), OpenTransactions: 1},
+				}, nil)
+			},
+			expected: modelTransactions.BalanceSummary{
+				AccountID:       "id",
+				Currency:        "BRL",
+				OutstandingDebt: modelMoney.MustParse("13.50"),
+				UnappliedCredit: modelMoney.MustParse("10"),
+				Balance:         modelMoney.MustParse("-3.50"),
+				OtherCurrencies: []modelTransactions.CurrencyBalance{
+					{Currency: "USD", OutstandingDebt: modelMoney.MustParse("5"), Balance: modelMoney.MustParse("-5")},
+				},
+				OperationsType: []modelTransactions.OperationTypeBalance{
+					{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "BRL", OutstandingDebt: modelMoney.MustParse("13.50"), OpenTransactions: 1},
+					{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "USD", OutstandingDebt: modelMoney.MustParse("5"), OpenTransactions: 1},
+					{OperationTypeID: 4, Description: "PAGAMENTO", Currency: "BRL", UnappliedCredit: modelMoney.MustParse("10"), OpenTransactions: 1},
+				},
+			},
+		},
+		"should not be able to get balance with error account id not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to get balance with error in store": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.transactions.EXPECT().GetBalanceByAccountID(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to get balance"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := a.GetBalance(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+type settlement struct {
+	debit  modelTransactions.Transaction
+	amount modelMoney.Money
+}
+
+func dischargeEntry(payment modelTransactions.Transaction, settlements ...settlement) modelLedger.Entry {
+
+	entry := modelLedger.NewDischargeEntry(payment)
+
+	for _, s := range settlements {
+		entry.Settle(payment, s.debit, s.amount)
+	}
+
+	return entry
+}
+
+func TestRefund(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+		fx           *mocksStore.MockIRates
+		ledger       *mocksStore.MockILedger
+		allocations  *mocksStore.MockIAllocations
+	}
+
+	account := modelAccounts.Account{ID: "id", Currency: "BRL"}
+
+	debitID, paymentID, interestID := "debit_id", "payment_id", "interest_id"
+	debit := modelTransactions.Transaction{TransactionID: debitID, AccountID: "id", OperationTypeID: 1, Currency: "BRL", Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-70")}
+	payment := modelTransactions.Transaction{TransactionID: paymentID, AccountID: "id", OperationTypeID: 4, Currency: "BRL", Amount: modelMoney.MustParse("100"), Balance: modelMoney.MustParse("40")}
+	other := modelTransactions.Transaction{TransactionID: "other_id", Currency: "BRL"}
+	otherLimit, installmentLimit := modelMoney.MustParse("-60"), modelMoney.MustParse("-25")
+	otherDebit := modelTransactions.Transaction{TransactionID: "other_id", AccountID: "id", OperationTypeID: 1, Currency: "BRL", Amount: modelMoney.MustParse("-60"), LimitAmount: &otherLimit}
+
+	parentID, installments := "parent_id", 2
+	purchase := modelTransactions.Transaction{TransactionID: parentID, AccountID: "id", OperationTypeID: 2, Currency: "BRL", Amount: modelMoney.MustParse("-100"), Installments: &installments}
+	first := modelTransactions.Transaction{TransactionID: "installment_id", AccountID: "id", OperationTypeID: 2, Currency: "BRL", Amount: modelMoney.MustParse("-50"), Balance: modelMoney.MustParse("-50"), ParentTransactionID: &parentID, LimitAmount: &installmentLimit}
+	second := modelTransactions.Transaction{TransactionID: "second_id", AccountID: "id", OperationTypeID: 2, Currency: "BRL", Amount: modelMoney.MustParse("-50"), Balance: modelMoney.MustParse("-50"), ParentTransactionID: &parentID, LimitAmount: &installmentLimit}
+
+	tests := map[string]struct {
+		input    modelTransactions.Refund
+		expected modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to refund a debit reopening the payment that settled it": {
+			input:    modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("80")},
+			expected: modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("80"), ReversedTransactionID: &debitID},
+			prepare: func(f *fields) {
+				refund := modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("80"), Balance: modelMoney.MustParse("80"), ReversedTransactionID: &debitID}
+
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.Money(0), nil)
+				f.ledger.EXPECT().GetSettlements(gomock.Any(), debitID).Times(1).Return([]modelLedger.Settlement{{TransactionID: "other_id", Amount: modelMoney.MustParse("30")}}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       6,
+					Amount:                modelMoney.MustParse("80"),
+					Currency:              "BRL",
+					ReversedTransactionID: &debitID,
+				}).Times(1).Return(refund, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reopen(other, debit, modelMoney.MustParse("10"))
+				entry.Reverse(debit, refund, modelMoney.MustParse("80"), 0)
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("70")).Times(1).Return(nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should be able to refund a payment reopening the debits it settled": {
+			input:    modelTransactions.Refund{TransactionID: paymentID, Amount: modelMoney.MustParse("100")},
+			expected: modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("-100"), ReversedTransactionID: &paymentID},
+			prepare: func(f *fields) {
+				refund := modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-100"), ReversedTransactionID: &paymentID}
+
+				f.transactions.EXPECT().GetByID(gomock.Any(), paymentID).Times(2).Return(payment, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), paymentID).Times(1).Return(modelMoney.Money(0), nil)
+				f.ledger.EXPECT().GetSettlements(gomock.Any(), paymentID).Times(1).Return([]modelLedger.Settlement{{TransactionID: "other_id", Amount: modelMoney.MustParse("60")}}, nil)
+				f.transactions.EXPECT().GetByID(gomock.Any(), "other_id").Times(1).Return(otherDebit, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       6,
+					Amount:                modelMoney.MustParse("-100"),
+					Currency:              "BRL",
+					LimitAmount:           modelMoney.MustParse("-60"),
+					ReversedTransactionID: &paymentID,
+				}).Times(1).Return(refund, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reopen(payment, other, modelMoney.MustParse("60"))
+				entry.Reverse(payment, refund, modelMoney.MustParse("100"), 0)
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should be able to refund a payment reopening charges without taking from the credit limit": {
+			input:    modelTransactions.Refund{TransactionID: paymentID, Amount: modelMoney.MustParse("100")},
+			expected: modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("-100"), ReversedTransactionID: &paymentID},
+			prepare: func(f *fields) {
+				refund := modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-100"), ReversedTransactionID: &paymentID}
+				interest := modelTransactions.Transaction{TransactionID: "interest_id", Currency: "BRL"}
 
-				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-				}).Times(1).Return(modelTransactions.Transaction{
-					TransactionID:   "transaction_id",
-					AccountID:       "id",
-					Amount:          60.00,
-					OperationTypeID: 4,
-					Balance:         60,
+				f.transactions.EXPECT().GetByID(gomock.Any(), paymentID).Times(2).Return(payment, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), paymentID).Times(1).Return(modelMoney.Money(0), nil)
+				f.ledger.EXPECT().GetSettlements(gomock.Any(), paymentID).Times(1).Return([]modelLedger.Settlement{
+					{TransactionID: "interest_id", OperationTypeID: modelOperaTionsType.InterestID, Amount: modelMoney.MustParse("5")},
+					{TransactionID: "other_id", OperationTypeID: 1, Amount: modelMoney.MustParse("55")},
 				}, nil)
+				f.transactions.EXPECT().GetByID(gomock.Any(), "interest_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "interest_id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "BRL", Amount: modelMoney.MustParse("-5")}, nil)
+				f.transactions.EXPECT().GetByID(gomock.Any(), "other_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "other_id", OperationTypeID: 1, Currency: "BRL", Amount: modelMoney.MustParse("-55"), LimitAmount: &otherLimit}, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       6,
+					Amount:                modelMoney.MustParse("-100"),
+					Currency:              "BRL",
+					LimitAmount:           modelMoney.MustParse("-60"),
+					ReversedTransactionID: &paymentID,
+				}).Times(1).Return(refund, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reopen(payment, interest, modelMoney.MustParse("5"))
+				entry.Reopen(payment, other, modelMoney.MustParse("55"))
+				entry.Reverse(payment, refund, modelMoney.MustParse("100"), 0)
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should be able to refund a charge without giving back to the credit limit": {
+			input:    modelTransactions.Refund{TransactionID: "interest_id", Amount: modelMoney.MustParse("5")},
+			expected: modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("5"), ReversedTransactionID: &interestID},
+			prepare: func(f *fields) {
+				interest := modelTransactions.Transaction{TransactionID: interestID, AccountID: "id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "BRL", Amount: modelMoney.MustParse("-5"), Balance: modelMoney.MustParse("-5")}
+				refund := modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("5"), Balance: modelMoney.MustParse("5"), ReversedTransactionID: &interestID}
+
+				f.transactions.EXPECT().GetByID(gomock.Any(), interestID).Times(2).Return(interest, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), interestID).Times(1).Return(modelMoney.Money(0), nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       6,
+					Amount:                modelMoney.MustParse("5"),
+					Currency:              "BRL",
+					ReversedTransactionID: &interestID,
+				}).Times(1).Return(refund, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reverse(interest, refund, modelMoney.MustParse("5"), 0)
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should not be able to refund a transaction of a closed account": {
+			input: modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(1).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id", Currency: "BRL", Status: modelAccounts.StatusClosed}, nil)
+			},
+			err: ErrAccountClosed,
+		},
+		"should not be able to refund a payment with error credit limit exceeded": {
+			input: modelTransactions.Refund{TransactionID: paymentID, Amount: modelMoney.MustParse("100")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), paymentID).Times(2).Return(payment, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), paymentID).Times(1).Return(modelMoney.Money(0), nil)
+				f.ledger.EXPECT().GetSettlements(gomock.Any(), paymentID).Times(1).Return([]modelLedger.Settlement{{TransactionID: "other_id", Amount: modelMoney.MustParse("60")}}, nil)
+				f.transactions.EXPECT().GetByID(gomock.Any(), "other_id").Times(1).Return(otherDebit, nil)
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{}, storeTransactions.ErrInsufficientCreditLimit)
+			},
+			err: ErrCreditLimitExceeded,
+		},
+		"should be able to refund a purchase in installments from the last installment": {
+			input:    modelTransactions.Refund{TransactionID: "parent_id", Amount: modelMoney.MustParse("60")},
+			expected: modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("60"), ReversedTransactionID: &parentID},
+			prepare: func(f *fields) {
+				refund := modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", OperationTypeID: 6, Currency: "BRL", Amount: modelMoney.MustParse("60"), Balance: modelMoney.MustParse("60"), ReversedTransactionID: &parentID}
 
-				f.wg.Add(1)
+				f.transactions.EXPECT().GetByID(gomock.Any(), parentID).Times(2).Return(purchase, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), parentID).Times(1).Return(modelMoney.Money(0), nil)
+				f.transactions.EXPECT().ListInstallmentsByParentID(gomock.Any(), parentID).Times(1).Return([]modelTransactions.Transaction{second, first}, nil)
 
-				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.Transaction{}, nil).Do(func(arg0, arg1 interface{}) {
-					f.wg.Done()
-				})
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       6,
+					Amount:                modelMoney.MustParse("60"),
+					Currency:              "BRL",
+					ReversedTransactionID: &parentID,
+				}).Times(1).Return(refund, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reverse(second, refund, modelMoney.MustParse("50"), 0)
+				entry.Reverse(first, refund, modelMoney.MustParse("10"), 0)
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("30")).Times(1).Return(nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should not be able to refund an installment on its own": {
+			input: modelTransactions.Refund{TransactionID: "installment_id", Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "installment_id").Times(1).Return(first, nil)
+			},
+			err: ErrTransactionNotReversible,
+		},
+		"should not be able to refund with amount invalid": {
+			input: modelTransactions.Refund{TransactionID: debitID},
+			prepare: func(f *fields) {
+			},
+			err: fmt.Errorf("amount invalid"),
+		},
+		"should not be able to refund with transaction id not found": {
+			input: modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(1).Return(modelTransactions.Transaction{}, sql.ErrNoRows)
+			},
+			err: ErrTransactionNotFound,
+		},
+		"should not be able to refund a compensating transaction": {
+			input: modelTransactions.Refund{TransactionID: "refund_id", Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "refund_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "refund_id", ReversedTransactionID: &debitID}, nil)
+			},
+			err: ErrTransactionNotReversible,
+		},
+		"should not be able to refund a transaction already reversed": {
+			input: modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.MustParse("100"), nil)
+			},
+			err: ErrTransactionReversed,
+		},
+		"should not be able to refund more than is left of the transaction": {
+			input: modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("60")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.MustParse("50"), nil)
+			},
+			err: ErrRefundAmountExceeded,
+		},
+		"should not be able to refund with error to post in ledger": {
+			input: modelTransactions.Refund{TransactionID: debitID, Amount: modelMoney.MustParse("10")},
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.Money(0), nil)
+				f.transactions.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(modelTransactions.Transaction{TransactionID: "refund_id", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("10")}, nil)
+				f.ledger.EXPECT().Post(gomock.Any(), gomock.Any()).Times(1).Return(modelLedger.Entry{}, fmt.Errorf("any"))
 			},
+			err: fmt.Errorf("fail to reverse transaction"),
 		},
 	}
 
@@ -414,32 +1716,435 @@ func TestMake(t *testing.T) {
 
 			accountsMock := mocksStore.NewMockIAccounts(ctrl)
 			transactionsMock := mocksStore.NewMockITransactions(ctrl)
-			operationsTypeMock := mocksStore.NewMockIOperationsType(ctrl)
-			var wg sync.WaitGroup
+			fxMock := mocksStore.NewMockIRates(ctrl)
+			ledgerMock := mocksStore.NewMockILedger(ctrl)
+			allocationsMock := mocksStore.NewMockIAllocations(ctrl)
 
 			tt.prepare(&fields{
-				accounts:       accountsMock,
-				transactions:   transactionsMock,
-				operationsType: operationsTypeMock,
-				wg:             &wg,
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+				fx:           fxMock,
+				ledger:       ledgerMock,
+				allocations:  allocationsMock,
 			})
 
 			a := New(Options{
 				Store: store.Store{
-					Accounts:       accountsMock,
-					Transactions:   transactionsMock,
-					OperationsType: operationsTypeMock,
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+					FX:           fxMock,
+					Ledger:       ledgerMock,
+					Allocations:  allocationsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := a.Refund(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+		ledger       *mocksStore.MockILedger
+		allocations  *mocksStore.MockIAllocations
+	}
+
+	account := modelAccounts.Account{ID: "id", Currency: "BRL"}
+
+	debitID := "debit_id"
+	debit := modelTransactions.Transaction{TransactionID: debitID, AccountID: "id", OperationTypeID: 1, Currency: "BRL", Amount: modelMoney.MustParse("-100")}
+
+	tests := map[string]struct {
+		input    string
+		expected modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to reverse a debit settled before its counterparts were recorded": {
+			input:    debitID,
+			expected: modelTransactions.Transaction{TransactionID: "reversal_id", AccountID: "id", OperationTypeID: 5, Currency: "BRL", Amount: modelMoney.MustParse("100"), Balance: modelMoney.MustParse("100"), ReversedTransactionID: &debitID},
+			prepare: func(f *fields) {
+				reversal := modelTransactions.Transaction{TransactionID: "reversal_id", AccountID: "id", OperationTypeID: 5, Currency: "BRL", Amount: modelMoney.MustParse("100"), Balance: modelMoney.MustParse("100"), ReversedTransactionID: &debitID}
+
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.Money(0), nil)
+				f.ledger.EXPECT().GetSettlements(gomock.Any(), debitID).Times(1).Return(nil, nil)
+
+				f.transactions.EXPECT().Create(gomock.Any(), modelTransactions.MakeTransaction{
+					AccountID:             "id",
+					OperationTypeID:       5,
+					Amount:                modelMoney.MustParse("100"),
+					Currency:              "BRL",
+					ReversedTransactionID: &debitID,
+				}).Times(1).Return(reversal, nil)
+
+				entry := modelLedger.NewReversalEntry(modelLedger.KindReversal, reversal)
+				entry.Reverse(debit, reversal, modelMoney.MustParse("100"), modelMoney.MustParse("100"))
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+		},
+		"should not be able to reverse a transaction twice": {
+			input: debitID,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(2).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetReversedAmount(gomock.Any(), debitID).Times(1).Return(modelMoney.MustParse("100"), nil)
+			},
+			err: ErrTransactionReversed,
+		},
+		"should not be able to reverse with error to lock the account": {
+			input: debitID,
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), debitID).Times(1).Return(debit, nil)
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to reverse transaction"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+			ledgerMock := mocksStore.NewMockILedger(ctrl)
+			allocationsMock := mocksStore.NewMockIAllocations(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+				ledger:       ledgerMock,
+				allocations:  allocationsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+					Ledger:       ledgerMock,
+					Allocations:  allocationsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := a.Reverse(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListFutureInstallments(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+	}
+
+	parentID := "parent_id"
+	installment := modelTransactions.Transaction{TransactionID: "2", AccountID: "id", OperationTypeID: 2, Amount: modelMoney.MustParse("-50"), Balance: modelMoney.MustParse("-50"), ParentTransactionID: &parentID}
+
+	tests := map[string]struct {
+		input    string
+		expected modelTransactions.FutureInstallments
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list future installments": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.transactions.EXPECT().ListFutureInstallmentsByAccountID(gomock.Any(), "id", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{installment}, nil)
+			},
+			expected: modelTransactions.FutureInstallments{AccountID: "id", Installments: []modelTransactions.Transaction{installment}},
+		},
+		"should be able to list no future installments": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.transactions.EXPECT().ListFutureInstallmentsByAccountID(gomock.Any(), "id", gomock.Any()).Times(1).Return(nil, nil)
+			},
+			expected: modelTransactions.FutureInstallments{AccountID: "id", Installments: []modelTransactions.Transaction{}},
+		},
+		"should not be able to list future installments with error account id not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to list future installments with error at store": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{ID: "id"}, nil)
+				f.transactions.EXPECT().ListFutureInstallmentsByAccountID(gomock.Any(), "id", gomock.Any()).Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to list installments"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+
+			tt.prepare(&fields{
+				accounts:     accountsMock,
+				transactions: transactionsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Accounts:     accountsMock,
+					Transactions: transactionsMock,
+				},
+				Log: logrus.New(),
+			})
+
+			res, err := a.ListFutureInstallments(context.Background(), tt.input)
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListAllocations(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		allocations  *mocksStore.MockIAllocations
+	}
+
+	allocation := modelAllocations.Allocation{AllocationID: 1, EntryID: "entry_id", PaymentID: "payment_id", DebitID: "debit_id", Amount: modelMoney.MustParse("50"), Currency: "BRL"}
+
+	tests := map[string]struct {
+		input    string
+		expected modelAllocations.TransactionAllocations
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list allocations": {
+			input: "payment_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "payment_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "payment_id"}, nil)
+				f.allocations.EXPECT().ListByTransactionID(gomock.Any(), "payment_id").Times(1).Return([]modelAllocations.Allocation{allocation}, nil)
+			},
+			expected: modelAllocations.TransactionAllocations{TransactionID: "payment_id", Allocations: []modelAllocations.Allocation{allocation}},
+		},
+		"should be able to list no allocations": {
+			input: "payment_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "payment_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "payment_id"}, nil)
+				f.allocations.EXPECT().ListByTransactionID(gomock.Any(), "payment_id").Times(1).Return(nil, nil)
+			},
+			expected: modelAllocations.TransactionAllocations{TransactionID: "payment_id", Allocations: []modelAllocations.Allocation{}},
+		},
+		"should not be able to list allocations with error transaction id not found": {
+			input: "payment_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "payment_id").Times(1).Return(modelTransactions.Transaction{}, sql.ErrNoRows)
+			},
+			err: ErrTransactionNotFound,
+		},
+		"should not be able to list allocations with error at store": {
+			input: "payment_id",
+			prepare: func(f *fields) {
+				f.transactions.EXPECT().GetByID(gomock.Any(), "payment_id").Times(1).Return(modelTransactions.Transaction{TransactionID: "payment_id"}, nil)
+				f.allocations.EXPECT().ListByTransactionID(gomock.Any(), "payment_id").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to list allocations"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+			allocationsMock := mocksStore.NewMockIAllocations(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+				allocations:  allocationsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Transactions: transactionsMock,
+					Allocations:  allocationsMock,
 				},
 				Log: logrus.New(),
 			})
 
-			err := a.Make(context.Background(), tt.input)
+			res, err := a.ListAllocations(context.Background(), tt.input)
 			if err != nil && err.Error() != tt.err.Error() {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
 			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+
+	type fields struct {
+		transactions *mocksStore.MockITransactions
+		accounts     *mocksStore.MockIAccounts
+		ledger       *mocksStore.MockILedger
+		allocations  *mocksStore.MockIAllocations
+	}
+
+	account := modelAccounts.Account{ID: "id", Currency: "BRL"}
+
+	credit := modelTransactions.Transaction{
+		TransactionID:   "payment_id",
+		AccountID:       "id",
+		Currency:        "BRL",
+		OperationTypeID: 4,
+		Amount:          modelMoney.MustParse("100"),
+		Balance:         modelMoney.MustParse("40"),
+	}
+
+	debit := modelTransactions.Transaction{
+		TransactionID:   "debit_id",
+		AccountID:       "id",
+		Currency:        "BRL",
+		OperationTypeID: 2,
+		Amount:          modelMoney.MustParse("-25"),
+		Balance:         modelMoney.MustParse("-25"),
+	}
+
+	replayed := credit
+	replayed.Amount = credit.Balance
+
+	tests := map[string]struct {
+		input    string
+		expected modelTransactions.ReconcileResult
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to reconcile balances": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOpenCreditsByAccountID(gomock.Any(), "id").Times(1).Return([]modelTransactions.Transaction{credit}, nil)
+				f.transactions.EXPECT().GetToDischargeByAccountID(gomock.Any(), "id", "BRL", gomock.Any()).Times(1).Return([]modelTransactions.Transaction{debit}, nil)
 
-			wg.Wait()
+				entry := dischargeEntry(replayed, settlement{debit, modelMoney.MustParse("25")})
+				f.ledger.EXPECT().Post(gomock.Any(), entry).Times(1).Return(entry, nil)
+				f.allocations.EXPECT().Create(gomock.Any(), modelAllocations.FromEntry(entry)).Times(1).Return(nil)
+				f.accounts.EXPECT().UpdateAvailableCreditLimit(gomock.Any(), "id", modelMoney.MustParse("25")).Times(1).Return(nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+			expected: modelTransactions.ReconcileResult{AccountID: "id", Credits: 1},
+		},
+		"should be able to reconcile balances without open credits": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOpenCreditsByAccountID(gomock.Any(), "id").Times(1).Return(nil, nil)
+				f.accounts.EXPECT().DeleteCache(gomock.Any(), account).Times(1)
+			},
+			expected: modelTransactions.ReconcileResult{AccountID: "id"},
+		},
+		"should not be able to reconcile balances with error account not found": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(modelAccounts.Account{}, sql.ErrNoRows)
+			},
+			err: ErrAccountNotFound,
+		},
+		"should not be able to reconcile balances with error at store": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.accounts.EXPECT().GetByID(gomock.Any(), "id").Times(1).Return(account, nil)
+				f.accounts.EXPECT().Lock(gomock.Any(), "id").Times(1).Return(nil)
+				f.transactions.EXPECT().GetOpenCreditsByAccountID(gomock.Any(), "id").Times(1).Return(nil, fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("fail to reconcile balances"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			ctrl := gomock.NewController(t)
+
+			transactionsMock := mocksStore.NewMockITransactions(ctrl)
+			accountsMock := mocksStore.NewMockIAccounts(ctrl)
+			ledgerMock := mocksStore.NewMockILedger(ctrl)
+			allocationsMock := mocksStore.NewMockIAllocations(ctrl)
+
+			tt.prepare(&fields{
+				transactions: transactionsMock,
+				accounts:     accountsMock,
+				ledger:       ledgerMock,
+				allocations:  allocationsMock,
+			})
+
+			a := New(Options{
+				Store: store.Store{
+					Transactions: transactionsMock,
+					Accounts:     accountsMock,
+					Ledger:       ledgerMock,
+					Allocations:  allocationsMock,
+				},
+				Log: logrus.New(),
+			})
 
+			res, err := a.Reconcile(context.Background(), tt.input)
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
 		})
 	}
 }
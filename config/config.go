@@ -1,16 +1,23 @@
 package config
 
 import (
+	"math/big"
 	"os"
 	"strconv"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 )
 
 func New() Config {
 
 	dbPort, _ := strconv.Atoi(os.Getenv("DB_PORT"))
+	fxConvertPayments, _ := strconv.ParseBool(os.Getenv("FX_CONVERT_PAYMENTS"))
+	installmentsDischargeFuture, _ := strconv.ParseBool(os.Getenv("INSTALLMENTS_DISCHARGE_FUTURE"))
 
 	cfg := Config{
-		ServerPort: os.Getenv("PORT"),
+		ServerPort:      os.Getenv("PORT"),
+		ShutdownTimeout: parseDuration(os.Getenv("SHUTDOWN_TIMEOUT"), 30*time.Second),
 		DB: DB{
 			MigrationFile: os.Getenv("DB_MIGRATION_FILE"),
 			DriverName:    os.Getenv("DB_DRIVER_NAME"),
@@ -23,15 +30,45 @@ func New() Config {
 		Cache: Cache{
 			Addr: os.Getenv("REDIS_ADDR"),
 		},
+		FX: FX{
+			RatesFile:       os.Getenv("FX_RATES_FILE"),
+			ConvertPayments: fxConvertPayments,
+		},
+		Installments: Installments{
+			DischargeFuture: installmentsDischargeFuture,
+		},
+		Accrual: Accrual{
+			InterestRate: parseRate(os.Getenv("ACCRUAL_INTEREST_RATE")),
+			LateFeeRate:  parseRate(os.Getenv("ACCRUAL_LATE_FEE_RATE")),
+		},
+		Accounts: Accounts{
+			DefaultCreditLimit: parseMoney(os.Getenv("ACCOUNT_DEFAULT_CREDIT_LIMIT")),
+		},
+		Tracing: Tracing{
+			Exporter:    os.Getenv("TRACING_EXPORTER"),
+			ServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+		},
+	}
+
+	if cfg.Tracing.ServiceName == "" {
+		cfg.Tracing.ServiceName = "pismo"
 	}
 
 	return cfg
 }
 
+// Config of the server. ShutdownTimeout bounds how long a shutdown waits for the requests being served and the work they
+// left running.
 type Config struct {
-	ServerPort string `json:"port"`
-	DB         DB     `json:"db"`
-	Cache      Cache  `json:"cache"`
+	ServerPort      string        `json:"port"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+	DB              DB            `json:"db"`
+	Cache           Cache         `json:"cache"`
+	FX              FX            `json:"fx"`
+	Installments    Installments  `json:"installments"`
+	Accrual         Accrual       `json:"accrual"`
+	Accounts        Accounts      `json:"accounts"`
+	Tracing         Tracing       `json:"tracing"`
 }
 
 type DB struct {
@@ -47,3 +84,57 @@ type DB struct {
 type Cache struct {
 	Addr string `json:"addr"`
 }
+
+type FX struct {
+	RatesFile       string `json:"rates_file"`
+	ConvertPayments bool   `json:"convert_payments"`
+}
+
+type Installments struct {
+	DischargeFuture bool `json:"discharge_future"`
+}
+
+// Accrual holds the charges on overdue debits: the monthly interest rate and the late fee rate, such as "0.12" for 12%.
+type Accrual struct {
+	InterestRate *big.Rat `json:"interest_rate"`
+	LateFeeRate  *big.Rat `json:"late_fee_rate"`
+}
+
+// Accounts holds the available credit limit of the accounts created without one, also given to the accounts left without
+// a limit when it was added.
+type Accounts struct {
+	DefaultCreditLimit modelMoney.Money `json:"default_credit_limit"`
+}
+
+// Tracing holds where the spans go: "otlp", to the collector of OTEL_EXPORTER_OTLP_ENDPOINT, "stdout", or nowhere when empty.
+type Tracing struct {
+	Exporter    string `json:"exporter"`
+	ServiceName string `json:"service_name"`
+}
+
+// parseRate reads a non negative decimal rate, nil when it is empty or invalid.
+func parseRate(value string) *big.Rat {
+	rate, ok := new(big.Rat).SetString(value)
+	if !ok || rate.Sign() < 0 {
+		return nil
+	}
+	return rate
+}
+
+// parseMoney reads a non negative amount such as "1500.00", zero when it is empty or invalid.
+func parseMoney(value string) modelMoney.Money {
+	amount, err := modelMoney.Parse(value)
+	if err != nil || amount < 0 {
+		return 0
+	}
+	return amount
+}
+
+// parseDuration reads a positive duration such as "30s", def when it is empty or invalid.
+func parseDuration(value string, def time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return def
+	}
+	return duration
+}
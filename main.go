@@ -1,12 +1,167 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
 	"github.com/jorgepiresg/ChallangePismo/config"
 	"github.com/jorgepiresg/ChallangePismo/server"
 )
 
+// usage lists the commands of the binary; serve is the default.
+const usage = `usage: main <command> [flags]
+
+commands:
+  serve [-migrate]                       serve the API, applying the pending migrations first with -migrate
+  migrate up|down [-steps N]|status      apply, roll back or list the migrations
+  seed                                   create the default operation types and the demo accounts
+  reconcile-balances -account ID         apply the open credits of the account to its open debits
+  close-cycles [-date YYYY-MM-DD]        close the billing cycles of the date
+  accrue [-from YYYY-MM-DD] [-to YYYY-MM-DD]
+                                         charge interest and late fees on the overdue debits`
+
 func main() {
 	cfg := config.New()
 	server := server.New(cfg)
-	server.Start()
+
+	command, args := "serve", []string{}
+	if len(os.Args) > 1 {
+		command, args = os.Args[1], os.Args[2:]
+	}
+
+	switch command {
+	case "serve":
+		serve(server, args)
+	case "migrate":
+		migrate(server, args)
+	case "seed":
+		seed(server)
+	case "reconcile-balances":
+		reconcileBalances(server, args)
+	case "close-cycles":
+		closeCycles(server, args)
+	case "accrue":
+		accrue(server, args)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// serve runs "serve [-migrate]", serving the API. Migrations are applied first only with -migrate, so replicas starting
+// together do not all migrate; run "migrate up" before a release instead.
+func serve(server server.Server, args []string) {
+
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	migrate := flags.Bool("migrate", false, "apply the pending migrations before serving")
+	flags.Parse(args)
+
+	server.Start(*migrate)
+}
+
+// migrate runs "migrate up", "migrate down [-steps N]" or "migrate status": applying the pending migrations, rolling back
+// the last N applied ones, 1 by default, or listing them all.
+func migrate(server server.Server, args []string) {
+
+	if len(args) == 0 {
+		log.Fatal("migrate: up, down or status expected")
+	}
+
+	var err error
+
+	switch args[0] {
+	case "up":
+		err = server.MigrateUp()
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of applied migrations to roll back")
+		flags.Parse(args[1:])
+
+		if *steps < 1 {
+			log.Fatal("migrate: steps invalid: ", *steps)
+		}
+
+		err = server.MigrateDown(*steps)
+	case "status":
+		err = server.MigrationStatus()
+	default:
+		log.Fatal("migrate: up, down or status expected, got ", args[0])
+	}
+
+	if err != nil {
+		log.Fatal("migrate: ", err.Error())
+	}
+}
+
+// seed runs "seed", creating the default operation types and the demo accounts missing.
+func seed(server server.Server) {
+	if err := server.Seed(); err != nil {
+		log.Fatal("seed: ", err.Error())
+	}
+}
+
+// reconcileBalances runs "reconcile-balances -account ID", applying the open credits of the account to its open debits.
+func reconcileBalances(server server.Server, args []string) {
+
+	flags := flag.NewFlagSet("reconcile-balances", flag.ExitOnError)
+	accountID := flags.String("account", "", "account id")
+	flags.Parse(args)
+
+	if *accountID == "" {
+		log.Fatal("reconcile-balances: account expected")
+	}
+
+	if err := server.ReconcileBalances(*accountID); err != nil {
+		log.Fatal("reconcile-balances: ", err.Error())
+	}
+}
+
+// closeCycles runs "close-cycles [-date YYYY-MM-DD]", closing the billing cycles of the date, today in UTC by default.
+func closeCycles(server server.Server, args []string) {
+
+	flags := flag.NewFlagSet("close-cycles", flag.ExitOnError)
+	date := flags.String("date", time.Now().UTC().Format(time.DateOnly), "closing date (YYYY-MM-DD)")
+	flags.Parse(args)
+
+	closingDate, err := time.Parse(time.DateOnly, *date)
+	if err != nil {
+		log.Fatal("close-cycles: date invalid: ", *date)
+	}
+
+	if err := server.CloseCycles(closingDate); err != nil {
+		log.Fatal("close-cycles: ", err.Error())
+	}
+}
+
+// accrue runs "accrue [-from YYYY-MM-DD] [-to YYYY-MM-DD]", charging interest and late fees on the debits overdue at each
+// day of the range, today in UTC by default.
+func accrue(server server.Server, args []string) {
+
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	flags := flag.NewFlagSet("accrue", flag.ExitOnError)
+	from := flags.String("from", today, "first accrual date (YYYY-MM-DD)")
+	to := flags.String("to", "", "last accrual date (YYYY-MM-DD), from by default")
+	flags.Parse(args)
+
+	if *to == "" {
+		*to = *from
+	}
+
+	fromDate, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		log.Fatal("accrue: date invalid: ", *from)
+	}
+
+	toDate, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		log.Fatal("accrue: date invalid: ", *to)
+	}
+
+	if err := server.Accrue(fromDate, toDate); err != nil {
+		log.Fatal("accrue: ", err.Error())
+	}
 }
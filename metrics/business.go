@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"strconv"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+// TransactionCreated counts a transaction of the operation type, once its database transaction is committed.
+func TransactionCreated(operationTypeID int) {
+	TransactionsCreated.WithLabelValues(strconv.Itoa(operationTypeID)).Inc()
+}
+
+// Settled adds amount to what credits settled in the currency, once its database transaction is committed.
+func Settled(currency string, amount modelMoney.Money) {
+	if amount > 0 {
+		SettledAmount.WithLabelValues(currency).Add(float64(amount.Cents()) / 100)
+	}
+}
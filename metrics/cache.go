@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// CacheResult is the result of a cache read that failed with err: a miss when the key is not there, an error otherwise.
+func CacheResult(err error) string {
+	if errors.Is(err, redis.Nil) {
+		return CacheMiss
+	}
+	return CacheError
+}
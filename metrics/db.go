@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// storePackage prefixes the functions of the store packages, telling the store method that made a query.
+const storePackage = "github.com/jorgepiresg/ChallangePismo/store/"
+
+type db struct {
+	sqlx.ExtContext
+	store string
+}
+
+// DB times every query made through ext, labeled with store and with the store method that made it.
+func DB(ext sqlx.ExtContext, store string) sqlx.ExtContext {
+	return db{ExtContext: ext, store: store}
+}
+
+func (d db) QueryContext(ctx context.Context, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	defer func(start time.Time) { d.observe(start, err) }(time.Now())
+	return d.ExtContext.QueryContext(ctx, query, args...)
+}
+
+func (d db) QueryxContext(ctx context.Context, query string, args ...interface{}) (rows *sqlx.Rows, err error) {
+	defer func(start time.Time) { d.observe(start, err) }(time.Now())
+	return d.ExtContext.QueryxContext(ctx, query, args...)
+}
+
+func (d db) QueryRowxContext(ctx context.Context, query string, args ...interface{}) (row *sqlx.Row) {
+	defer func(start time.Time) { d.observe(start, row.Err()) }(time.Now())
+	return d.ExtContext.QueryRowxContext(ctx, query, args...)
+}
+
+func (d db) ExecContext(ctx context.Context, query string, args ...interface{}) (res sql.Result, err error) {
+	defer func(start time.Time) { d.observe(start, err) }(time.Now())
+	return d.ExtContext.ExecContext(ctx, query, args...)
+}
+
+func (d db) observe(start time.Time, err error) {
+
+	result := "ok"
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		result = "error"
+	}
+
+	QueryDuration.WithLabelValues(d.store, caller(), result).Observe(time.Since(start).Seconds())
+}
+
+// caller is the name of the store method up the stack, "unknown" when the query was not made by a store.
+func caller() string {
+
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	for {
+		frame, more := frames.Next()
+
+		if strings.HasPrefix(frame.Function, storePackage) {
+			name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+			if !strings.HasPrefix(name, "func") {
+				return name
+			}
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// RegisterPool exposes the statistics of the connection pool of the database: connections open, in use and idle, and
+// the waits for one.
+func RegisterPool(pool *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(pool, "postgres"))
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.Handler())
+}
+
+// Middleware counts and times the requests by the route they matched, not by their path, so ids do not become labels.
+// Requests matching no route are labeled "unmatched". Errors are rendered here, to label the status sent, and still returned
+// so the middlewares before it, such as the tracing, see them.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" || c.Response().Status == 404 && route == "/*" {
+				route = "unmatched"
+			}
+
+			method := c.Request().Method
+			status := strconv.Itoa(c.Response().Status)
+
+			HTTPRequests.WithLabelValues(method, route, status).Inc()
+			HTTPDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Package metrics holds the Prometheus metrics of the service, registered on the default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pismo"
+
+// Results of a cache read.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+var (
+	// HTTPRequests counts the requests served, by method, route and status.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Requests served, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration observes how long the requests took, by method, route and status.
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time to serve a request, by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// QueryDuration observes how long the database queries took, by store, store method and result.
+	QueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "query_duration_seconds",
+		Help:      "Time of a database query, by store, store method and result.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"store", "method", "result"})
+
+	// CacheReads counts the cache reads, by cache and result, hit, miss or error.
+	CacheReads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "reads_total",
+		Help:      "Cache reads, by cache and result: hit, miss or error.",
+	}, []string{"cache", "result"})
+
+	// TransactionsCreated counts the transactions made, by operation type.
+	TransactionsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transactions",
+		Name:      "created_total",
+		Help:      "Transactions made, by operation type.",
+	}, []string{"operation_type_id"})
+
+	// DischargeDuration observes how long settling the open debits with a credit took.
+	DischargeDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "discharge",
+		Name:      "duration_seconds",
+		Help:      "Time to settle the open debits with a credit.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	})
+
+	// SettledAmount sums the amounts of debits settled by credits, in units of the currency.
+	SettledAmount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "discharge",
+		Name:      "settled_amount_total",
+		Help:      "Amount of debits settled by credits, in units of the currency.",
+	}, []string{"currency"})
+)
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestMiddleware(t *testing.T) {
+
+	var returned error
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			returned = next(c)
+			return returned
+		}
+	})
+	e.Use(Middleware())
+	e.GET("/accounts/:account_id", func(c echo.Context) error {
+		if c.Param("account_id") == "missing" {
+			return echo.ErrNotFound
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := map[string]struct {
+		path   string
+		route  string
+		status string
+		err    error
+	}{
+		"should be able to count a request by its route": {
+			path:   "/accounts/1",
+			route:  "/accounts/:account_id",
+			status: "200",
+		},
+		"should be able to count a request failed with the status sent": {
+			path:   "/accounts/missing",
+			route:  "/accounts/:account_id",
+			status: "404",
+			err:    echo.ErrNotFound,
+		},
+		"should be able to count a request matching no route": {
+			path:   "/unknown/1",
+			route:  "unmatched",
+			status: "404",
+			err:    echo.ErrNotFound,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			counter := HTTPRequests.WithLabelValues(http.MethodGet, tt.route, tt.status)
+			before := testutil.ToFloat64(counter)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if res := fmt.Sprint(rec.Code); res != tt.status {
+				t.Errorf("Expected status %s got %s", tt.status, res)
+			}
+			if res := testutil.ToFloat64(counter) - before; res != 1 {
+				t.Errorf("Expected 1 request counted got %v", res)
+			}
+			if fmt.Sprint(returned) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, returned)
+			}
+		})
+	}
+}
+
+func TestDB(t *testing.T) {
+
+	t.Run("should be able to time queries", func(t *testing.T) {
+
+		db, mock, err := sqlxmock.Newx()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		mock.ExpectExec("SELECT 1").WillReturnResult(sqlxmock.NewResult(0, 1))
+		mock.ExpectExec("SELECT 1").WillReturnError(fmt.Errorf("any"))
+
+		ext := DB(db, "test")
+		ext.ExecContext(context.Background(), "SELECT 1")
+		ext.ExecContext(context.Background(), "SELECT 1")
+
+		if res := testutil.CollectAndCount(QueryDuration, "pismo_store_query_duration_seconds"); res < 2 {
+			t.Errorf("Expected 2 series at least got %d", res)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestCacheResult(t *testing.T) {
+
+	tests := map[string]struct {
+		input    error
+		expected string
+	}{
+		"should be a miss without the key": {input: redis.Nil, expected: CacheMiss},
+		"should be an error otherwise":     {input: fmt.Errorf("connection refused"), expected: CacheError},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			if res := CacheResult(tt.input); res != tt.expected {
+				t.Errorf("Expected result %s got %s", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestSettled(t *testing.T) {
+
+	counter := SettledAmount.WithLabelValues("XTS")
+	before := testutil.ToFloat64(counter)
+
+	Settled("XTS", modelMoney.MustParse("10.25"))
+	Settled("XTS", 0)
+
+	if res := testutil.ToFloat64(counter) - before; res != 10.25 {
+		t.Errorf("Expected 10.25 settled got %v", res)
+	}
+}
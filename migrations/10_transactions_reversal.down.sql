@@ -0,0 +1,7 @@
+DELETE FROM operations_type WHERE operation_type_id IN (5, 6) AND NOT EXISTS (SELECT 1 FROM transactions WHERE operation_type_id IN (5, 6));
+
+DROP INDEX IF EXISTS ledger_postings_counterpart_idx;
+ALTER TABLE ledger_postings DROP COLUMN IF EXISTS counterpart_transaction_id;
+
+DROP INDEX IF EXISTS transactions_reversed_transaction_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reversed_transaction_id;
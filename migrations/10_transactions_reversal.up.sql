@@ -0,0 +1,14 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversed_transaction_id uuid REFERENCES transactions (transaction_id);
+
+CREATE INDEX IF NOT EXISTS transactions_reversed_transaction_idx ON transactions (reversed_transaction_id) WHERE reversed_transaction_id IS NOT NULL;
+
+ALTER TABLE ledger_postings ADD COLUMN IF NOT EXISTS counterpart_transaction_id uuid;
+
+CREATE INDEX IF NOT EXISTS ledger_postings_counterpart_idx ON ledger_postings (transaction_id, counterpart_transaction_id) WHERE counterpart_transaction_id IS NOT NULL;
+
+INSERT INTO operations_type
+    (operation_type_id, description, operation)
+VALUES
+    (5, 'ESTORNO', 0),
+    (6, 'REEMBOLSO', 0)
+ON CONFLICT (operation_type_id) DO NOTHING;
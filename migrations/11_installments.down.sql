@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS transactions_account_due_date_idx;
+DROP INDEX IF EXISTS transactions_parent_transaction_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS due_date;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_number;
+ALTER TABLE transactions DROP COLUMN IF EXISTS parent_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installments;
@@ -0,0 +1,8 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installments SMALLINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS parent_transaction_id uuid REFERENCES transactions (transaction_id);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_number SMALLINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS due_date TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS transactions_parent_transaction_idx ON transactions (parent_transaction_id, installment_number) WHERE parent_transaction_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS transactions_account_due_date_idx ON transactions (account_id, due_date) WHERE due_date IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE operations_type ALTER COLUMN operation_type_id DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS operations_type_id_seq;
+
+ALTER TABLE operations_type DROP COLUMN IF EXISTS deactivated_at;
@@ -0,0 +1,9 @@
+ALTER TABLE operations_type ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
+
+-- operation types created through the API start at 1000, lower ids are kept for the ones seeded by migrations
+CREATE SEQUENCE IF NOT EXISTS operations_type_id_seq MINVALUE 1000 START WITH 1000;
+
+SELECT setval('operations_type_id_seq', GREATEST((SELECT MAX(operation_type_id) FROM operations_type WHERE operation_type_id >= 1000), 1000),
+    EXISTS (SELECT 1 FROM operations_type WHERE operation_type_id >= 1000));
+
+ALTER TABLE operations_type ALTER COLUMN operation_type_id SET DEFAULT nextval('operations_type_id_seq');
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS allocations (
+    allocation_id BIGSERIAL,
+    entry_id uuid NOT NULL REFERENCES ledger_entries (entry_id),
+    payment_id uuid NOT NULL REFERENCES transactions (transaction_id),
+    debit_id uuid NOT NULL REFERENCES transactions (transaction_id),
+    amount NUMERIC(15,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    reversal_id uuid REFERENCES transactions (transaction_id),
+    applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (allocation_id),
+    CONSTRAINT allocations_amount_check CHECK (amount <> 0)
+);
+
+CREATE INDEX IF NOT EXISTS allocations_payment_idx ON allocations (payment_id);
+CREATE INDEX IF NOT EXISTS allocations_debit_idx ON allocations (debit_id);
+CREATE INDEX IF NOT EXISTS allocations_entry_idx ON allocations (entry_id);
+
+DROP TRIGGER IF EXISTS allocations_append_only ON allocations;
+CREATE TRIGGER allocations_append_only BEFORE UPDATE OR DELETE ON allocations FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS allocations_no_truncate ON allocations;
+CREATE TRIGGER allocations_no_truncate BEFORE TRUNCATE ON allocations FOR EACH STATEMENT EXECUTE FUNCTION ledger_append_only();
+
+-- Allocations of the settlements posted before the table existed, read from the receivable postings paired with a payment.
+INSERT INTO allocations (entry_id, payment_id, debit_id, amount, currency, reversal_id, applied_at)
+SELECT p.entry_id, p.counterpart_transaction_id, p.transaction_id, p.credit - p.debit, p.currency,
+    CASE WHEN e.kind IN ('REVERSAL', 'REFUND') THEN e.transaction_id END, e.created_at
+FROM ledger_postings p
+JOIN ledger_entries e ON e.entry_id = p.entry_id
+WHERE p.ledger = 'RECEIVABLE' AND p.counterpart_transaction_id IS NOT NULL
+AND NOT EXISTS (SELECT 1 FROM allocations a WHERE a.entry_id = p.entry_id)
+ORDER BY p.posting_id;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS transactions_account_due_idx;
+
+DROP TABLE IF EXISTS statements;
+
+DROP INDEX IF EXISTS accounts_statement_closing_day_idx;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_statement_closing_day_check;
+ALTER TABLE accounts DROP COLUMN IF EXISTS statement_closing_day;
@@ -0,0 +1,27 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS statement_closing_day SMALLINT DEFAULT 1 NOT NULL;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_statement_closing_day_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_statement_closing_day_check CHECK (statement_closing_day BETWEEN 1 AND 28);
+
+CREATE INDEX IF NOT EXISTS accounts_statement_closing_day_idx ON accounts (statement_closing_day);
+
+CREATE TABLE IF NOT EXISTS statements (
+    statement_id uuid DEFAULT uuid_generate_v4 (),
+    account_id uuid NOT NULL REFERENCES accounts (account_id),
+    currency CHAR(3) NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE,
+    closing_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    opening_balance NUMERIC(15,2) NOT NULL,
+    purchases NUMERIC(15,2) NOT NULL,
+    payments NUMERIC(15,2) NOT NULL,
+    closing_balance NUMERIC(15,2) NOT NULL,
+    minimum_payment NUMERIC(15,2) NOT NULL,
+    due_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (statement_id),
+    CONSTRAINT statements_cycle_key UNIQUE (account_id, currency, closing_date)
+);
+
+CREATE INDEX IF NOT EXISTS statements_account_closing_idx ON statements (account_id, closing_date DESC);
+
+CREATE INDEX IF NOT EXISTS transactions_account_due_idx ON transactions (account_id, (COALESCE(due_date, event_date))) WHERE installments IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS statements_due_idx;
+
+DROP TABLE IF EXISTS accruals;
+
+DELETE FROM operations_type WHERE operation_type_id IN (7, 8) AND NOT EXISTS (SELECT 1 FROM transactions WHERE operation_type_id IN (7, 8));
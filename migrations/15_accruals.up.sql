@@ -0,0 +1,26 @@
+INSERT INTO operations_type
+    (operation_type_id, description, operation)
+VALUES
+    (7, 'JUROS', -1),
+    (8, 'MULTA', -1)
+ON CONFLICT (operation_type_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS accruals (
+    accrual_id BIGSERIAL,
+    account_id uuid NOT NULL REFERENCES accounts (account_id),
+    transaction_id uuid NOT NULL REFERENCES transactions (transaction_id),
+    operation_type_id INT NOT NULL REFERENCES operations_type (operation_type_id),
+    currency CHAR(3) NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    accrual_date DATE NOT NULL,
+    statement_id uuid REFERENCES statements (statement_id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (accrual_id),
+    CONSTRAINT accruals_amount_check CHECK (amount > 0)
+);
+
+-- interest is accrued once per account, currency and day, and the late fee once per statement
+CREATE UNIQUE INDEX IF NOT EXISTS accruals_interest_key ON accruals (account_id, currency, accrual_date) WHERE statement_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS accruals_late_fee_key ON accruals (statement_id) WHERE statement_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS statements_due_idx ON statements (due_date) WHERE closing_balance > 0;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS accounts_status_history;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_status_check;
+ALTER TABLE accounts DROP COLUMN IF EXISTS status;
@@ -0,0 +1,22 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS status VARCHAR(10) DEFAULT 'ACTIVE' NOT NULL;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_status_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_status_check CHECK (status IN ('ACTIVE', 'BLOCKED', 'CLOSED'));
+
+CREATE TABLE IF NOT EXISTS accounts_status_history (
+    change_id BIGSERIAL,
+    account_id uuid NOT NULL REFERENCES accounts (account_id),
+    from_status VARCHAR(10) NOT NULL,
+    to_status VARCHAR(10) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (change_id)
+);
+
+CREATE INDEX IF NOT EXISTS accounts_status_history_account_idx ON accounts_status_history (account_id, changed_at);
+
+DROP TRIGGER IF EXISTS accounts_status_history_append_only ON accounts_status_history;
+CREATE TRIGGER accounts_status_history_append_only BEFORE UPDATE OR DELETE ON accounts_status_history FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS accounts_status_history_no_truncate ON accounts_status_history;
+CREATE TRIGGER accounts_status_history_no_truncate BEFORE TRUNCATE ON accounts_status_history FOR EACH STATEMENT EXECUTE FUNCTION ledger_append_only();
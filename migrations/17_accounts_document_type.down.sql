@@ -0,0 +1,4 @@
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_document_type_check;
+ALTER TABLE accounts DROP COLUMN IF EXISTS document_type;
+
+ALTER TABLE accounts ALTER COLUMN document_number TYPE VARCHAR(11);
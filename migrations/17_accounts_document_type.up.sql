@@ -0,0 +1,6 @@
+ALTER TABLE accounts ALTER COLUMN document_number TYPE VARCHAR(14);
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS document_type VARCHAR(4) DEFAULT 'CPF' NOT NULL;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_document_type_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_document_type_check CHECK (document_type IN ('CPF', 'CNPJ'));
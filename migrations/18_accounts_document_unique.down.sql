@@ -0,0 +1 @@
+DROP INDEX IF EXISTS accounts_document_number_key;
@@ -0,0 +1,30 @@
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_indexes WHERE tablename = 'accounts' AND indexname = 'accounts_document_number_key') THEN
+        RETURN;
+    END IF;
+
+    -- Duplicates left by concurrent creations keep the oldest account. The newer ones are dropped when nothing was made
+    -- with them yet, the others need to be sorted out by hand before the index can be created.
+    DELETE FROM accounts a
+    WHERE EXISTS (
+        SELECT 1 FROM accounts o
+        WHERE o.document_number = a.document_number AND (o.created_at, o.account_id) < (a.created_at, a.account_id)
+    )
+    AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.account_id = a.account_id::TEXT)
+    AND NOT EXISTS (SELECT 1 FROM statements s WHERE s.account_id = a.account_id)
+    AND NOT EXISTS (SELECT 1 FROM accruals ac WHERE ac.account_id = a.account_id)
+    AND NOT EXISTS (SELECT 1 FROM accounts_status_history h WHERE h.account_id = a.account_id);
+
+    SELECT string_agg(document_number, ', ') INTO duplicates FROM (
+        SELECT document_number FROM accounts GROUP BY document_number HAVING COUNT(*) > 1
+    ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'accounts with the same document number and transactions: %', duplicates;
+    END IF;
+
+    CREATE UNIQUE INDEX accounts_document_number_key ON accounts (document_number);
+END $$;
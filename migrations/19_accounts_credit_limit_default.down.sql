@@ -0,0 +1 @@
+-- The credit limits given by the up migration are kept, since they were taken and given back by transactions since.
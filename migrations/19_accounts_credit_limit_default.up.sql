@@ -0,0 +1,9 @@
+ALTER TABLE accounts ALTER COLUMN available_credit_limit TYPE NUMERIC(15,2) USING ROUND(available_credit_limit::NUMERIC, 2);
+
+-- Accounts made before the credit limit existed were left without one, so they refused every debit. The ones that never
+-- took a debit get the default credit limit of the configuration, pismo.default_credit_limit, when there is one; the
+-- others have their limit set by updating the account.
+UPDATE accounts a SET available_credit_limit = d.credit_limit
+FROM (SELECT CAST(NULLIF(current_setting('pismo.default_credit_limit', true), '') AS NUMERIC(15,2)) AS credit_limit) d
+WHERE d.credit_limit > 0 AND a.available_credit_limit = 0
+AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.account_id = a.account_id::TEXT AND t.amount < 0);
@@ -0,0 +1,4 @@
+-- The limit each transaction takes from the available credit limit, in the account currency, so settling, reversing and
+-- refunding it give back what it took, whatever the exchange rate is by then. It is left NULL on the transactions made
+-- before it was kept.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS limit_amount NUMERIC(15,2);
@@ -0,0 +1,3 @@
+-- The transaction made by the request of each key, recorded in the database transaction that made it, so a key whose
+-- response was never stored is not taken over by a retry that would make the transaction again.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS transaction_id uuid;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS transactions_account_currency_due_idx;
+
+CREATE INDEX IF NOT EXISTS transactions_account_open_balance_idx ON transactions (account_id, event_date) WHERE balance <> 0;
+CREATE INDEX IF NOT EXISTS transactions_account_currency_open_idx ON transactions (account_id, currency, event_date) WHERE balance < 0;
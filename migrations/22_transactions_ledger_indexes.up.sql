@@ -0,0 +1,7 @@
+-- Open balances are derived from the ledger since it was created, and the balance column is no longer written, so the
+-- partial indexes on it match rows the queries no longer look for.
+DROP INDEX IF EXISTS transactions_account_open_balance_idx;
+DROP INDEX IF EXISTS transactions_account_currency_open_idx;
+
+-- Discharge looks up the debits of an account in a currency, oldest due first.
+CREATE INDEX IF NOT EXISTS transactions_account_currency_due_idx ON transactions (account_id, currency, (COALESCE(due_date, event_date)), event_date);
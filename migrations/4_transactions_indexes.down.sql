@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS transactions_account_open_balance_idx;
+
+DROP INDEX IF EXISTS transactions_account_event_date_idx;
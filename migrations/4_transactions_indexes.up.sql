@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS transactions_account_event_date_idx ON transactions (account_id, event_date DESC, transaction_id DESC);
+
+CREATE INDEX IF NOT EXISTS transactions_account_open_balance_idx ON transactions (account_id, event_date) WHERE balance <> 0;
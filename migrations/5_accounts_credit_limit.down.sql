@@ -0,0 +1,3 @@
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_available_credit_limit_check;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS available_credit_limit;
@@ -0,0 +1,4 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS available_credit_limit FLOAT DEFAULT 0 NOT NULL;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_available_credit_limit_check;
+ALTER TABLE accounts ADD CONSTRAINT accounts_available_credit_limit_check CHECK (available_credit_limit >= 0);
@@ -0,0 +1,5 @@
+ALTER TABLE accounts ALTER COLUMN available_credit_limit TYPE FLOAT USING available_credit_limit::FLOAT;
+
+ALTER TABLE transactions ALTER COLUMN balance TYPE FLOAT USING balance::FLOAT;
+
+ALTER TABLE transactions ALTER COLUMN amount TYPE FLOAT USING amount::FLOAT;
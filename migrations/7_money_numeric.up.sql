@@ -0,0 +1,14 @@
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns WHERE table_name = 'transactions' AND column_name = 'amount') = 'double precision' THEN
+        ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(15,2) USING ROUND(amount::NUMERIC, 2);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns WHERE table_name = 'transactions' AND column_name = 'balance') = 'double precision' THEN
+        ALTER TABLE transactions ALTER COLUMN balance TYPE NUMERIC(15,2) USING ROUND(balance::NUMERIC, 2);
+    END IF;
+
+    IF (SELECT data_type FROM information_schema.columns WHERE table_name = 'accounts' AND column_name = 'available_credit_limit') = 'double precision' THEN
+        ALTER TABLE accounts ALTER COLUMN available_credit_limit TYPE NUMERIC(15,2) USING ROUND(available_credit_limit::NUMERIC, 2);
+    END IF;
+END $$;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS transactions_account_currency_open_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,7 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS currency CHAR(3) DEFAULT 'BRL' NOT NULL;
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency CHAR(3) DEFAULT 'BRL' NOT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_amount NUMERIC(15,2);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_currency CHAR(3);
+
+CREATE INDEX IF NOT EXISTS transactions_account_currency_open_idx ON transactions (account_id, currency, event_date) WHERE balance < 0;
@@ -0,0 +1,11 @@
+UPDATE transactions t SET balance = COALESCE((
+    SELECT SUM(p.credit - p.debit) FROM ledger_postings p WHERE p.transaction_id = t.transaction_id AND p.ledger IN ('RECEIVABLE', 'ACCOUNT')
+), 0);
+
+COMMENT ON COLUMN transactions.balance IS NULL;
+
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_entries;
+
+DROP FUNCTION IF EXISTS ledger_entry_balanced();
+DROP FUNCTION IF EXISTS ledger_append_only();
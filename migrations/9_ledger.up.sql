@@ -0,0 +1,97 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    entry_id uuid DEFAULT uuid_generate_v4 (),
+    account_id VARCHAR NOT NULL,
+    transaction_id uuid NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    posting_id BIGSERIAL,
+    entry_id uuid NOT NULL REFERENCES ledger_entries (entry_id),
+    account_id VARCHAR NOT NULL,
+    ledger VARCHAR(20) NOT NULL,
+    transaction_id uuid NOT NULL,
+    currency CHAR(3) NOT NULL,
+    debit NUMERIC(15,2) DEFAULT 0 NOT NULL,
+    credit NUMERIC(15,2) DEFAULT 0 NOT NULL,
+    PRIMARY KEY (posting_id),
+    CONSTRAINT ledger_postings_one_side_check CHECK (debit >= 0 AND credit >= 0 AND (debit = 0) <> (credit = 0))
+);
+
+CREATE INDEX IF NOT EXISTS ledger_entries_transaction_idx ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS ledger_postings_transaction_idx ON ledger_postings (transaction_id, ledger);
+CREATE INDEX IF NOT EXISTS ledger_postings_account_idx ON ledger_postings (account_id, ledger, currency);
+
+CREATE OR REPLACE FUNCTION ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+CREATE TRIGGER ledger_entries_append_only BEFORE UPDATE OR DELETE ON ledger_entries FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_entries_no_truncate ON ledger_entries;
+CREATE TRIGGER ledger_entries_no_truncate BEFORE TRUNCATE ON ledger_entries FOR EACH STATEMENT EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_postings_append_only ON ledger_postings;
+CREATE TRIGGER ledger_postings_append_only BEFORE UPDATE OR DELETE ON ledger_postings FOR EACH ROW EXECUTE FUNCTION ledger_append_only();
+
+DROP TRIGGER IF EXISTS ledger_postings_no_truncate ON ledger_postings;
+CREATE TRIGGER ledger_postings_no_truncate BEFORE TRUNCATE ON ledger_postings FOR EACH STATEMENT EXECUTE FUNCTION ledger_append_only();
+
+CREATE OR REPLACE FUNCTION ledger_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM ledger_postings WHERE entry_id = NEW.entry_id GROUP BY currency HAVING SUM(debit) <> SUM(credit)) THEN
+        RAISE EXCEPTION 'ledger entry % is unbalanced', NEW.entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS ledger_postings_balanced ON ledger_postings;
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced AFTER INSERT ON ledger_postings DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION ledger_entry_balanced();
+
+-- Opening entries for the transactions recorded before the journal existed. They bring each transaction to the open
+-- balance it had, settling the discharged part against cash since which payment settled which debit was never stored.
+DO $$
+DECLARE
+    t RECORD;
+    entry uuid;
+BEGIN
+    FOR t IN SELECT tr.transaction_id, tr.account_id, tr.amount, tr.balance, tr.currency, tr.event_date FROM transactions tr
+        WHERE NOT EXISTS (SELECT 1 FROM ledger_entries e WHERE e.transaction_id = tr.transaction_id)
+        ORDER BY tr.event_date
+    LOOP
+        INSERT INTO ledger_entries (account_id, transaction_id, kind, created_at)
+        VALUES (t.account_id, t.transaction_id, 'OPENING', t.event_date) RETURNING entry_id INTO entry;
+
+        IF t.amount < 0 THEN
+            INSERT INTO ledger_postings (entry_id, account_id, ledger, transaction_id, currency, debit, credit) VALUES
+                (entry, t.account_id, 'RECEIVABLE', t.transaction_id, t.currency, -t.amount, 0),
+                (entry, t.account_id, 'CASH', t.transaction_id, t.currency, 0, -t.amount);
+
+            IF t.balance > t.amount THEN
+                INSERT INTO ledger_postings (entry_id, account_id, ledger, transaction_id, currency, debit, credit) VALUES
+                    (entry, t.account_id, 'CASH', t.transaction_id, t.currency, t.balance - t.amount, 0),
+                    (entry, t.account_id, 'RECEIVABLE', t.transaction_id, t.currency, 0, t.balance - t.amount);
+            END IF;
+        ELSIF t.amount > 0 THEN
+            INSERT INTO ledger_postings (entry_id, account_id, ledger, transaction_id, currency, debit, credit) VALUES
+                (entry, t.account_id, 'CASH', t.transaction_id, t.currency, t.amount, 0),
+                (entry, t.account_id, 'ACCOUNT', t.transaction_id, t.currency, 0, t.amount);
+
+            IF t.balance < t.amount THEN
+                INSERT INTO ledger_postings (entry_id, account_id, ledger, transaction_id, currency, debit, credit) VALUES
+                    (entry, t.account_id, 'ACCOUNT', t.transaction_id, t.currency, t.amount - t.balance, 0),
+                    (entry, t.account_id, 'CASH', t.transaction_id, t.currency, 0, t.amount - t.balance);
+            END IF;
+        END IF;
+    END LOOP;
+END $$;
+
+COMMENT ON COLUMN transactions.balance IS 'Deprecated: open balances are derived from ledger_postings.';
@@ -0,0 +1,8 @@
+// Package migrations holds the migrations of the database schema, embedded in the binary. Each one is a pair of files,
+// <version>_<name>.up.sql and <version>_<name>.down.sql, applied in the order of their versions.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -35,6 +35,21 @@ func (m *MockIAccounts) EXPECT() *MockIAccountsMockRecorder {
 	return m.recorder
 }
 
+// ChangeStatus mocks base method.
+func (m *MockIAccounts) ChangeStatus(ctx context.Context, data modelAccounts.ChangeStatus) (modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, data)
+	ret0, _ := ret[0].(modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockIAccountsMockRecorder) ChangeStatus(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockIAccounts)(nil).ChangeStatus), ctx, data)
+}
+
 // Create mocks base method.
 func (m *MockIAccounts) Create(ctx context.Context, account modelAccounts.Create) (modelAccounts.Account, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +79,48 @@ func (mr *MockIAccountsMockRecorder) GetByAccountID(ctx, AccountID interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccountID", reflect.TypeOf((*MockIAccounts)(nil).GetByAccountID), ctx, AccountID)
 }
+
+// List mocks base method.
+func (m *MockIAccounts) List(ctx context.Context, filter modelAccounts.ListFilter) (modelAccounts.AccountsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(modelAccounts.AccountsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIAccountsMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIAccounts)(nil).List), ctx, filter)
+}
+
+// ListStatusHistory mocks base method.
+func (m *MockIAccounts) ListStatusHistory(ctx context.Context, accountID string) (modelAccounts.StatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusHistory", ctx, accountID)
+	ret0, _ := ret[0].(modelAccounts.StatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusHistory indicates an expected call of ListStatusHistory.
+func (mr *MockIAccountsMockRecorder) ListStatusHistory(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusHistory", reflect.TypeOf((*MockIAccounts)(nil).ListStatusHistory), ctx, accountID)
+}
+
+// Update mocks base method.
+func (m *MockIAccounts) Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIAccountsMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIAccounts)(nil).Update), ctx, update)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accruals.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+)
+
+// MockIAccruals is a mock of IAccruals interface.
+type MockIAccruals struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccrualsMockRecorder
+}
+
+// MockIAccrualsMockRecorder is the mock recorder for MockIAccruals.
+type MockIAccrualsMockRecorder struct {
+	mock *MockIAccruals
+}
+
+// NewMockIAccruals creates a new mock instance.
+func NewMockIAccruals(ctrl *gomock.Controller) *MockIAccruals {
+	mock := &MockIAccruals{ctrl: ctrl}
+	mock.recorder = &MockIAccrualsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccruals) EXPECT() *MockIAccrualsMockRecorder {
+	return m.recorder
+}
+
+// Accrue mocks base method.
+func (m *MockIAccruals) Accrue(ctx context.Context, from, to time.Time) (modelAccruals.AccrueResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", ctx, from, to)
+	ret0, _ := ret[0].(modelAccruals.AccrueResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockIAccrualsMockRecorder) Accrue(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockIAccruals)(nil).Accrue), ctx, from, to)
+}
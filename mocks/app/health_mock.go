@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelHealth "github.com/jorgepiresg/ChallangePismo/model/health"
+)
+
+// MockIHealth is a mock of IHealth interface.
+type MockIHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthMockRecorder
+}
+
+// MockIHealthMockRecorder is the mock recorder for MockIHealth.
+type MockIHealthMockRecorder struct {
+	mock *MockIHealth
+}
+
+// NewMockIHealth creates a new mock instance.
+func NewMockIHealth(ctrl *gomock.Controller) *MockIHealth {
+	mock := &MockIHealth{ctrl: ctrl}
+	mock.recorder = &MockIHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealth) EXPECT() *MockIHealthMockRecorder {
+	return m.recorder
+}
+
+// Live mocks base method.
+func (m *MockIHealth) Live(ctx context.Context) modelHealth.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", ctx)
+	ret0, _ := ret[0].(modelHealth.Report)
+	return ret0
+}
+
+// Live indicates an expected call of Live.
+func (mr *MockIHealthMockRecorder) Live(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*MockIHealth)(nil).Live), ctx)
+}
+
+// Ready mocks base method.
+func (m *MockIHealth) Ready(ctx context.Context) modelHealth.Report {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(modelHealth.Report)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockIHealthMockRecorder) Ready(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockIHealth)(nil).Ready), ctx)
+}
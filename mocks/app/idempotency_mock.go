@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+)
+
+// MockIIdempotency is a mock of IIdempotency interface.
+type MockIIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyMockRecorder
+}
+
+// MockIIdempotencyMockRecorder is the mock recorder for MockIIdempotency.
+type MockIIdempotencyMockRecorder struct {
+	mock *MockIIdempotency
+}
+
+// NewMockIIdempotency creates a new mock instance.
+func NewMockIIdempotency(ctrl *gomock.Controller) *MockIIdempotency {
+	mock := &MockIIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotency) EXPECT() *MockIIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockIIdempotency) Finish(ctx context.Context, record modelIdempotency.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIIdempotencyMockRecorder) Finish(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIIdempotency)(nil).Finish), ctx, record)
+}
+
+// Release mocks base method.
+func (m *MockIIdempotency) Release(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIIdempotencyMockRecorder) Release(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIIdempotency)(nil).Release), ctx, key)
+}
+
+// Start mocks base method.
+func (m *MockIIdempotency) Start(ctx context.Context, key, requestHash string) (modelIdempotency.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, key, requestHash)
+	ret0, _ := ret[0].(modelIdempotency.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIIdempotencyMockRecorder) Start(ctx, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIIdempotency)(nil).Start), ctx, key, requestHash)
+}
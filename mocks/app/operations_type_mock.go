@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: operations_type.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+)
+
+// MockIOperationsType is a mock of IOperationsType interface.
+type MockIOperationsType struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOperationsTypeMockRecorder
+}
+
+// MockIOperationsTypeMockRecorder is the mock recorder for MockIOperationsType.
+type MockIOperationsTypeMockRecorder struct {
+	mock *MockIOperationsType
+}
+
+// NewMockIOperationsType creates a new mock instance.
+func NewMockIOperationsType(ctrl *gomock.Controller) *MockIOperationsType {
+	mock := &MockIOperationsType{ctrl: ctrl}
+	mock.recorder = &MockIOperationsTypeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOperationsType) EXPECT() *MockIOperationsTypeMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIOperationsType) Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOperationsTypeMockRecorder) Create(ctx, create interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOperationsType)(nil).Create), ctx, create)
+}
+
+// Deactivate mocks base method.
+func (m *MockIOperationsType) Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, ID)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockIOperationsTypeMockRecorder) Deactivate(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockIOperationsType)(nil).Deactivate), ctx, ID)
+}
+
+// List mocks base method.
+func (m *MockIOperationsType) List(ctx context.Context) ([]modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIOperationsTypeMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIOperationsType)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockIOperationsType) Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIOperationsTypeMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIOperationsType)(nil).Update), ctx, update)
+}
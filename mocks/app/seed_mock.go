@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: seed.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelSeed "github.com/jorgepiresg/ChallangePismo/model/seed"
+)
+
+// MockISeed is a mock of ISeed interface.
+type MockISeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockISeedMockRecorder
+}
+
+// MockISeedMockRecorder is the mock recorder for MockISeed.
+type MockISeedMockRecorder struct {
+	mock *MockISeed
+}
+
+// NewMockISeed creates a new mock instance.
+func NewMockISeed(ctrl *gomock.Controller) *MockISeed {
+	mock := &MockISeed{ctrl: ctrl}
+	mock.recorder = &MockISeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISeed) EXPECT() *MockISeedMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockISeed) Run(ctx context.Context) (modelSeed.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(modelSeed.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockISeedMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockISeed)(nil).Run), ctx)
+}
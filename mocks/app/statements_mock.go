@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statements.go
+
+// Package mocksApp is a generated GoMock package.
+package mocksApp
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+)
+
+// MockIStatements is a mock of IStatements interface.
+type MockIStatements struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatementsMockRecorder
+}
+
+// MockIStatementsMockRecorder is the mock recorder for MockIStatements.
+type MockIStatementsMockRecorder struct {
+	mock *MockIStatements
+}
+
+// NewMockIStatements creates a new mock instance.
+func NewMockIStatements(ctrl *gomock.Controller) *MockIStatements {
+	mock := &MockIStatements{ctrl: ctrl}
+	mock.recorder = &MockIStatementsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatements) EXPECT() *MockIStatementsMockRecorder {
+	return m.recorder
+}
+
+// CloseCycles mocks base method.
+func (m *MockIStatements) CloseCycles(ctx context.Context, date time.Time) (modelStatements.CloseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseCycles", ctx, date)
+	ret0, _ := ret[0].(modelStatements.CloseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseCycles indicates an expected call of CloseCycles.
+func (mr *MockIStatementsMockRecorder) CloseCycles(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseCycles", reflect.TypeOf((*MockIStatements)(nil).CloseCycles), ctx, date)
+}
+
+// GetByID mocks base method.
+func (m *MockIStatements) GetByID(ctx context.Context, accountID, statementID string) (modelStatements.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, accountID, statementID)
+	ret0, _ := ret[0].(modelStatements.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIStatementsMockRecorder) GetByID(ctx, accountID, statementID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIStatements)(nil).GetByID), ctx, accountID, statementID)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockIStatements) ListByAccountID(ctx context.Context, accountID string) (modelStatements.AccountStatements, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(modelStatements.AccountStatements)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockIStatementsMockRecorder) ListByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockIStatements)(nil).ListByAccountID), ctx, accountID)
+}
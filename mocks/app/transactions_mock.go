@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 )
 
@@ -35,6 +36,66 @@ func (m *MockITransactions) EXPECT() *MockITransactionsMockRecorder {
 	return m.recorder
 }
 
+// GetBalance mocks base method.
+func (m *MockITransactions) GetBalance(ctx context.Context, accountID string) (modelTransactions.BalanceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, accountID)
+	ret0, _ := ret[0].(modelTransactions.BalanceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockITransactionsMockRecorder) GetBalance(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockITransactions)(nil).GetBalance), ctx, accountID)
+}
+
+// ListAllocations mocks base method.
+func (m *MockITransactions) ListAllocations(ctx context.Context, transactionID string) (modelAllocations.TransactionAllocations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllocations", ctx, transactionID)
+	ret0, _ := ret[0].(modelAllocations.TransactionAllocations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllocations indicates an expected call of ListAllocations.
+func (mr *MockITransactionsMockRecorder) ListAllocations(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllocations", reflect.TypeOf((*MockITransactions)(nil).ListAllocations), ctx, transactionID)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockITransactions) ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) (modelTransactions.TransactionsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, filter)
+	ret0, _ := ret[0].(modelTransactions.TransactionsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockITransactionsMockRecorder) ListByAccountID(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockITransactions)(nil).ListByAccountID), ctx, filter)
+}
+
+// ListFutureInstallments mocks base method.
+func (m *MockITransactions) ListFutureInstallments(ctx context.Context, accountID string) (modelTransactions.FutureInstallments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFutureInstallments", ctx, accountID)
+	ret0, _ := ret[0].(modelTransactions.FutureInstallments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFutureInstallments indicates an expected call of ListFutureInstallments.
+func (mr *MockITransactionsMockRecorder) ListFutureInstallments(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFutureInstallments", reflect.TypeOf((*MockITransactions)(nil).ListFutureInstallments), ctx, accountID)
+}
+
 // Make mocks base method.
 func (m *MockITransactions) Make(ctx context.Context, data modelTransactions.MakeTransaction) error {
 	m.ctrl.T.Helper()
@@ -48,3 +109,48 @@ func (mr *MockITransactionsMockRecorder) Make(ctx, data interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockITransactions)(nil).Make), ctx, data)
 }
+
+// Reconcile mocks base method.
+func (m *MockITransactions) Reconcile(ctx context.Context, accountID string) (modelTransactions.ReconcileResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, accountID)
+	ret0, _ := ret[0].(modelTransactions.ReconcileResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockITransactionsMockRecorder) Reconcile(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockITransactions)(nil).Reconcile), ctx, accountID)
+}
+
+// Refund mocks base method.
+func (m *MockITransactions) Refund(ctx context.Context, data modelTransactions.Refund) (modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, data)
+	ret0, _ := ret[0].(modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockITransactionsMockRecorder) Refund(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockITransactions)(nil).Refund), ctx, data)
+}
+
+// Reverse mocks base method.
+func (m *MockITransactions) Reverse(ctx context.Context, transactionID string) (modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, transactionID)
+	ret0, _ := ret[0].(modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockITransactionsMockRecorder) Reverse(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockITransactions)(nil).Reverse), ctx, transactionID)
+}
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 )
 
 // MockIAccounts is a mock of IAccounts interface.
@@ -50,19 +51,16 @@ func (mr *MockIAccountsMockRecorder) Create(ctx, account interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAccounts)(nil).Create), ctx, account)
 }
 
-// GetByDocument mocks base method.
-func (m *MockIAccounts) GetByDocument(ctx context.Context, document string) (modelAccounts.Account, error) {
+// DeleteCache mocks base method.
+func (m *MockIAccounts) DeleteCache(ctx context.Context, account modelAccounts.Account) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByDocument", ctx, document)
-	ret0, _ := ret[0].(modelAccounts.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	m.ctrl.Call(m, "DeleteCache", ctx, account)
 }
 
-// GetByDocument indicates an expected call of GetByDocument.
-func (mr *MockIAccountsMockRecorder) GetByDocument(ctx, document interface{}) *gomock.Call {
+// DeleteCache indicates an expected call of DeleteCache.
+func (mr *MockIAccountsMockRecorder) DeleteCache(ctx, account interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDocument", reflect.TypeOf((*MockIAccounts)(nil).GetByDocument), ctx, document)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCache", reflect.TypeOf((*MockIAccounts)(nil).DeleteCache), ctx, account)
 }
 
 // GetByID mocks base method.
@@ -79,3 +77,106 @@ func (mr *MockIAccountsMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIAccounts)(nil).GetByID), ctx, ID)
 }
+
+// List mocks base method.
+func (m *MockIAccounts) List(ctx context.Context, filter modelAccounts.ListFilter) ([]modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIAccountsMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIAccounts)(nil).List), ctx, filter)
+}
+
+// ListByClosingDay mocks base method.
+func (m *MockIAccounts) ListByClosingDay(ctx context.Context, day int) ([]modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClosingDay", ctx, day)
+	ret0, _ := ret[0].([]modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClosingDay indicates an expected call of ListByClosingDay.
+func (mr *MockIAccountsMockRecorder) ListByClosingDay(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClosingDay", reflect.TypeOf((*MockIAccounts)(nil).ListByClosingDay), ctx, day)
+}
+
+// ListStatusHistory mocks base method.
+func (m *MockIAccounts) ListStatusHistory(ctx context.Context, ID string) ([]modelAccounts.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusHistory", ctx, ID)
+	ret0, _ := ret[0].([]modelAccounts.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusHistory indicates an expected call of ListStatusHistory.
+func (mr *MockIAccountsMockRecorder) ListStatusHistory(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusHistory", reflect.TypeOf((*MockIAccounts)(nil).ListStatusHistory), ctx, ID)
+}
+
+// Lock mocks base method.
+func (m *MockIAccounts) Lock(ctx context.Context, ID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockIAccountsMockRecorder) Lock(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockIAccounts)(nil).Lock), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockIAccounts) Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIAccountsMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIAccounts)(nil).Update), ctx, update)
+}
+
+// UpdateAvailableCreditLimit mocks base method.
+func (m *MockIAccounts) UpdateAvailableCreditLimit(ctx context.Context, ID string, amount modelMoney.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvailableCreditLimit", ctx, ID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAvailableCreditLimit indicates an expected call of UpdateAvailableCreditLimit.
+func (mr *MockIAccountsMockRecorder) UpdateAvailableCreditLimit(ctx, ID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvailableCreditLimit", reflect.TypeOf((*MockIAccounts)(nil).UpdateAvailableCreditLimit), ctx, ID, amount)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockIAccounts) UpdateStatus(ctx context.Context, change modelAccounts.StatusChange) (modelAccounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, change)
+	ret0, _ := ret[0].(modelAccounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockIAccountsMockRecorder) UpdateStatus(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockIAccounts)(nil).UpdateStatus), ctx, change)
+}
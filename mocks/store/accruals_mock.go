@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accruals.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+)
+
+// MockIAccruals is a mock of IAccruals interface.
+type MockIAccruals struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccrualsMockRecorder
+}
+
+// MockIAccrualsMockRecorder is the mock recorder for MockIAccruals.
+type MockIAccrualsMockRecorder struct {
+	mock *MockIAccruals
+}
+
+// NewMockIAccruals creates a new mock instance.
+func NewMockIAccruals(ctrl *gomock.Controller) *MockIAccruals {
+	mock := &MockIAccruals{ctrl: ctrl}
+	mock.recorder = &MockIAccrualsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccruals) EXPECT() *MockIAccrualsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAccruals) Create(ctx context.Context, accrual modelAccruals.Accrual) (modelAccruals.Accrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, accrual)
+	ret0, _ := ret[0].(modelAccruals.Accrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAccrualsMockRecorder) Create(ctx, accrual interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAccruals)(nil).Create), ctx, accrual)
+}
+
+// ListOverdueAccountIDs mocks base method.
+func (m *MockIAccruals) ListOverdueAccountIDs(ctx context.Context, date time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueAccountIDs", ctx, date)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueAccountIDs indicates an expected call of ListOverdueAccountIDs.
+func (mr *MockIAccrualsMockRecorder) ListOverdueAccountIDs(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueAccountIDs", reflect.TypeOf((*MockIAccruals)(nil).ListOverdueAccountIDs), ctx, date)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: allocations.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+)
+
+// MockIAllocations is a mock of IAllocations interface.
+type MockIAllocations struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAllocationsMockRecorder
+}
+
+// MockIAllocationsMockRecorder is the mock recorder for MockIAllocations.
+type MockIAllocationsMockRecorder struct {
+	mock *MockIAllocations
+}
+
+// NewMockIAllocations creates a new mock instance.
+func NewMockIAllocations(ctrl *gomock.Controller) *MockIAllocations {
+	mock := &MockIAllocations{ctrl: ctrl}
+	mock.recorder = &MockIAllocationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAllocations) EXPECT() *MockIAllocationsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAllocations) Create(ctx context.Context, allocations []modelAllocations.Allocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, allocations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAllocationsMockRecorder) Create(ctx, allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAllocations)(nil).Create), ctx, allocations)
+}
+
+// ListByTransactionID mocks base method.
+func (m *MockIAllocations) ListByTransactionID(ctx context.Context, transactionID string) ([]modelAllocations.Allocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTransactionID", ctx, transactionID)
+	ret0, _ := ret[0].([]modelAllocations.Allocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTransactionID indicates an expected call of ListByTransactionID.
+func (mr *MockIAllocationsMockRecorder) ListByTransactionID(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTransactionID", reflect.TypeOf((*MockIAllocations)(nil).ListByTransactionID), ctx, transactionID)
+}
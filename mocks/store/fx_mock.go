@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fx.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRates is a mock of IRates interface.
+type MockIRates struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRatesMockRecorder
+}
+
+// MockIRatesMockRecorder is the mock recorder for MockIRates.
+type MockIRatesMockRecorder struct {
+	mock *MockIRates
+}
+
+// NewMockIRates creates a new mock instance.
+func NewMockIRates(ctrl *gomock.Controller) *MockIRates {
+	mock := &MockIRates{ctrl: ctrl}
+	mock.recorder = &MockIRatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRates) EXPECT() *MockIRatesMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockIRates) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(*big.Rat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockIRatesMockRecorder) Rate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockIRates)(nil).Rate), ctx, from, to)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIHealth is a mock of IHealth interface.
+type MockIHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthMockRecorder
+}
+
+// MockIHealthMockRecorder is the mock recorder for MockIHealth.
+type MockIHealthMockRecorder struct {
+	mock *MockIHealth
+}
+
+// NewMockIHealth creates a new mock instance.
+func NewMockIHealth(ctrl *gomock.Controller) *MockIHealth {
+	mock := &MockIHealth{ctrl: ctrl}
+	mock.recorder = &MockIHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealth) EXPECT() *MockIHealthMockRecorder {
+	return m.recorder
+}
+
+// PingCache mocks base method.
+func (m *MockIHealth) PingCache(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingCache", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingCache indicates an expected call of PingCache.
+func (mr *MockIHealthMockRecorder) PingCache(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingCache", reflect.TypeOf((*MockIHealth)(nil).PingCache), ctx)
+}
+
+// PingDatabase mocks base method.
+func (m *MockIHealth) PingDatabase(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingDatabase", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingDatabase indicates an expected call of PingDatabase.
+func (mr *MockIHealthMockRecorder) PingDatabase(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingDatabase", reflect.TypeOf((*MockIHealth)(nil).PingDatabase), ctx)
+}
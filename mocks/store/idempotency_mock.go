@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+)
+
+// MockIIdempotency is a mock of IIdempotency interface.
+type MockIIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyMockRecorder
+}
+
+// MockIIdempotencyMockRecorder is the mock recorder for MockIIdempotency.
+type MockIIdempotencyMockRecorder struct {
+	mock *MockIIdempotency
+}
+
+// NewMockIIdempotency creates a new mock instance.
+func NewMockIIdempotency(ctrl *gomock.Controller) *MockIIdempotency {
+	mock := &MockIIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotency) EXPECT() *MockIIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIIdempotency) Complete(ctx context.Context, record modelIdempotency.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIIdempotencyMockRecorder) Complete(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIIdempotency)(nil).Complete), ctx, record)
+}
+
+// Create mocks base method.
+func (m *MockIIdempotency) Create(ctx context.Context, record modelIdempotency.Record) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, record)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIIdempotencyMockRecorder) Create(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIIdempotency)(nil).Create), ctx, record)
+}
+
+// Delete mocks base method.
+func (m *MockIIdempotency) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIIdempotencyMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIIdempotency)(nil).Delete), ctx, key)
+}
+
+// GetByKey mocks base method.
+func (m *MockIIdempotency) GetByKey(ctx context.Context, key string) (modelIdempotency.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", ctx, key)
+	ret0, _ := ret[0].(modelIdempotency.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockIIdempotencyMockRecorder) GetByKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockIIdempotency)(nil).GetByKey), ctx, key)
+}
+
+// SetTransaction mocks base method.
+func (m *MockIIdempotency) SetTransaction(ctx context.Context, key, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransaction", ctx, key, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTransaction indicates an expected call of SetTransaction.
+func (mr *MockIIdempotencyMockRecorder) SetTransaction(ctx, key, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransaction", reflect.TypeOf((*MockIIdempotency)(nil).SetTransaction), ctx, key, transactionID)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ledger.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+)
+
+// MockILedger is a mock of ILedger interface.
+type MockILedger struct {
+	ctrl     *gomock.Controller
+	recorder *MockILedgerMockRecorder
+}
+
+// MockILedgerMockRecorder is the mock recorder for MockILedger.
+type MockILedgerMockRecorder struct {
+	mock *MockILedger
+}
+
+// NewMockILedger creates a new mock instance.
+func NewMockILedger(ctrl *gomock.Controller) *MockILedger {
+	mock := &MockILedger{ctrl: ctrl}
+	mock.recorder = &MockILedgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILedger) EXPECT() *MockILedgerMockRecorder {
+	return m.recorder
+}
+
+// GetSettlements mocks base method.
+func (m *MockILedger) GetSettlements(ctx context.Context, transactionID string) ([]modelLedger.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlements", ctx, transactionID)
+	ret0, _ := ret[0].([]modelLedger.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlements indicates an expected call of GetSettlements.
+func (mr *MockILedgerMockRecorder) GetSettlements(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlements", reflect.TypeOf((*MockILedger)(nil).GetSettlements), ctx, transactionID)
+}
+
+// Post mocks base method.
+func (m *MockILedger) Post(ctx context.Context, entry modelLedger.Entry) (modelLedger.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, entry)
+	ret0, _ := ret[0].(modelLedger.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockILedgerMockRecorder) Post(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockILedger)(nil).Post), ctx, entry)
+}
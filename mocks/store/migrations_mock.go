@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: migrations.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	modelMigrations "github.com/jorgepiresg/ChallangePismo/model/migrations"
+)
+
+// MockIMigrations is a mock of IMigrations interface.
+type MockIMigrations struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigrationsMockRecorder
+}
+
+// MockIMigrationsMockRecorder is the mock recorder for MockIMigrations.
+type MockIMigrationsMockRecorder struct {
+	mock *MockIMigrations
+}
+
+// NewMockIMigrations creates a new mock instance.
+func NewMockIMigrations(ctrl *gomock.Controller) *MockIMigrations {
+	mock := &MockIMigrations{ctrl: ctrl}
+	mock.recorder = &MockIMigrationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrations) EXPECT() *MockIMigrationsMockRecorder {
+	return m.recorder
+}
+
+// Down mocks base method.
+func (m *MockIMigrations) Down(ctx context.Context, steps int) ([]modelMigrations.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", ctx, steps)
+	ret0, _ := ret[0].([]modelMigrations.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockIMigrationsMockRecorder) Down(ctx, steps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*MockIMigrations)(nil).Down), ctx, steps)
+}
+
+// List mocks base method.
+func (m *MockIMigrations) List(ctx context.Context) ([]modelMigrations.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]modelMigrations.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIMigrationsMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIMigrations)(nil).List), ctx)
+}
+
+// Up mocks base method.
+func (m *MockIMigrations) Up(ctx context.Context) ([]modelMigrations.Migration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up", ctx)
+	ret0, _ := ret[0].([]modelMigrations.Migration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockIMigrationsMockRecorder) Up(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockIMigrations)(nil).Up), ctx)
+}
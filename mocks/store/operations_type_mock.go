@@ -35,6 +35,48 @@ func (m *MockIOperationsType) EXPECT() *MockIOperationsTypeMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockIOperationsType) Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIOperationsTypeMockRecorder) Create(ctx, create interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIOperationsType)(nil).Create), ctx, create)
+}
+
+// Deactivate mocks base method.
+func (m *MockIOperationsType) Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deactivate", ctx, ID)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deactivate indicates an expected call of Deactivate.
+func (mr *MockIOperationsTypeMockRecorder) Deactivate(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deactivate", reflect.TypeOf((*MockIOperationsType)(nil).Deactivate), ctx, ID)
+}
+
+// DeleteCache mocks base method.
+func (m *MockIOperationsType) DeleteCache(ctx context.Context, ID int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteCache", ctx, ID)
+}
+
+// DeleteCache indicates an expected call of DeleteCache.
+func (mr *MockIOperationsTypeMockRecorder) DeleteCache(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCache", reflect.TypeOf((*MockIOperationsType)(nil).DeleteCache), ctx, ID)
+}
+
 // GetByID mocks base method.
 func (m *MockIOperationsType) GetByID(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +91,48 @@ func (mr *MockIOperationsTypeMockRecorder) GetByID(ctx, ID interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIOperationsType)(nil).GetByID), ctx, ID)
 }
+
+// List mocks base method.
+func (m *MockIOperationsType) List(ctx context.Context) ([]modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIOperationsTypeMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIOperationsType)(nil).List), ctx)
+}
+
+// Seed mocks base method.
+func (m *MockIOperationsType) Seed(ctx context.Context, operationType modelOperaTionsType.OperationType) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", ctx, operationType)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockIOperationsTypeMockRecorder) Seed(ctx, operationType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockIOperationsType)(nil).Seed), ctx, operationType)
+}
+
+// Update mocks base method.
+func (m *MockIOperationsType) Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(modelOperaTionsType.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIOperationsTypeMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIOperationsType)(nil).Update), ctx, update)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statements.go
+
+// Package mocksStore is a generated GoMock package.
+package mocksStore
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+)
+
+// MockIStatements is a mock of IStatements interface.
+type MockIStatements struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStatementsMockRecorder
+}
+
+// MockIStatementsMockRecorder is the mock recorder for MockIStatements.
+type MockIStatementsMockRecorder struct {
+	mock *MockIStatements
+}
+
+// NewMockIStatements creates a new mock instance.
+func NewMockIStatements(ctrl *gomock.Controller) *MockIStatements {
+	mock := &MockIStatements{ctrl: ctrl}
+	mock.recorder = &MockIStatementsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStatements) EXPECT() *MockIStatementsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIStatements) Create(ctx context.Context, statement modelStatements.Statement) (modelStatements.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, statement)
+	ret0, _ := ret[0].(modelStatements.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIStatementsMockRecorder) Create(ctx, statement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIStatements)(nil).Create), ctx, statement)
+}
+
+// GetByID mocks base method.
+func (m *MockIStatements) GetByID(ctx context.Context, ID string) (modelStatements.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(modelStatements.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIStatementsMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIStatements)(nil).GetByID), ctx, ID)
+}
+
+// GetLastByAccountID mocks base method.
+func (m *MockIStatements) GetLastByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]modelStatements.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastByAccountID indicates an expected call of GetLastByAccountID.
+func (mr *MockIStatementsMockRecorder) GetLastByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastByAccountID", reflect.TypeOf((*MockIStatements)(nil).GetLastByAccountID), ctx, accountID)
+}
+
+// GetMovements mocks base method.
+func (m *MockIStatements) GetMovements(ctx context.Context, accountID string, start *time.Time, end time.Time) ([]modelStatements.Movement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMovements", ctx, accountID, start, end)
+	ret0, _ := ret[0].([]modelStatements.Movement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMovements indicates an expected call of GetMovements.
+func (mr *MockIStatementsMockRecorder) GetMovements(ctx, accountID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovements", reflect.TypeOf((*MockIStatements)(nil).GetMovements), ctx, accountID, start, end)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockIStatements) ListByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]modelStatements.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockIStatementsMockRecorder) ListByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockIStatements)(nil).ListByAccountID), ctx, accountID)
+}
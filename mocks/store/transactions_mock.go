@@ -7,8 +7,11 @@ package mocksStore
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 )
 
@@ -50,31 +53,137 @@ func (mr *MockITransactionsMockRecorder) Create(ctx, create interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITransactions)(nil).Create), ctx, create)
 }
 
+// GetBalanceByAccountID mocks base method.
+func (m *MockITransactions) GetBalanceByAccountID(ctx context.Context, accountID string) ([]modelTransactions.OperationTypeBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]modelTransactions.OperationTypeBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceByAccountID indicates an expected call of GetBalanceByAccountID.
+func (mr *MockITransactionsMockRecorder) GetBalanceByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceByAccountID", reflect.TypeOf((*MockITransactions)(nil).GetBalanceByAccountID), ctx, accountID)
+}
+
+// GetByID mocks base method.
+func (m *MockITransactions) GetByID(ctx context.Context, ID string) (modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, ID)
+	ret0, _ := ret[0].(modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockITransactionsMockRecorder) GetByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockITransactions)(nil).GetByID), ctx, ID)
+}
+
+// GetOpenCreditsByAccountID mocks base method.
+func (m *MockITransactions) GetOpenCreditsByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenCreditsByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenCreditsByAccountID indicates an expected call of GetOpenCreditsByAccountID.
+func (mr *MockITransactionsMockRecorder) GetOpenCreditsByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenCreditsByAccountID", reflect.TypeOf((*MockITransactions)(nil).GetOpenCreditsByAccountID), ctx, accountID)
+}
+
+// GetOverdueByAccountID mocks base method.
+func (m *MockITransactions) GetOverdueByAccountID(ctx context.Context, accountID string, date time.Time) ([]modelAccruals.Overdue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueByAccountID", ctx, accountID, date)
+	ret0, _ := ret[0].([]modelAccruals.Overdue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueByAccountID indicates an expected call of GetOverdueByAccountID.
+func (mr *MockITransactionsMockRecorder) GetOverdueByAccountID(ctx, accountID, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueByAccountID", reflect.TypeOf((*MockITransactions)(nil).GetOverdueByAccountID), ctx, accountID, date)
+}
+
+// GetReversedAmount mocks base method.
+func (m *MockITransactions) GetReversedAmount(ctx context.Context, ID string) (modelMoney.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReversedAmount", ctx, ID)
+	ret0, _ := ret[0].(modelMoney.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReversedAmount indicates an expected call of GetReversedAmount.
+func (mr *MockITransactionsMockRecorder) GetReversedAmount(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReversedAmount", reflect.TypeOf((*MockITransactions)(nil).GetReversedAmount), ctx, ID)
+}
+
 // GetToDischargeByAccountID mocks base method.
-func (m *MockITransactions) GetToDischargeByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error) {
+func (m *MockITransactions) GetToDischargeByAccountID(ctx context.Context, accountID, currency string, dueBy *time.Time) ([]modelTransactions.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetToDischargeByAccountID", ctx, accountID)
+	ret := m.ctrl.Call(m, "GetToDischargeByAccountID", ctx, accountID, currency, dueBy)
 	ret0, _ := ret[0].([]modelTransactions.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetToDischargeByAccountID indicates an expected call of GetToDischargeByAccountID.
-func (mr *MockITransactionsMockRecorder) GetToDischargeByAccountID(ctx, accountID interface{}) *gomock.Call {
+func (mr *MockITransactionsMockRecorder) GetToDischargeByAccountID(ctx, accountID, currency, dueBy interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToDischargeByAccountID", reflect.TypeOf((*MockITransactions)(nil).GetToDischargeByAccountID), ctx, accountID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToDischargeByAccountID", reflect.TypeOf((*MockITransactions)(nil).GetToDischargeByAccountID), ctx, accountID, currency, dueBy)
 }
 
-// UpdateBalance mocks base method.
-func (m *MockITransactions) UpdateBalance(ctx context.Context, transaction modelTransactions.Transaction) error {
+// ListByAccountID mocks base method.
+func (m *MockITransactions) ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) ([]modelTransactions.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBalance", ctx, transaction)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, filter)
+	ret0, _ := ret[0].([]modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockITransactionsMockRecorder) ListByAccountID(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockITransactions)(nil).ListByAccountID), ctx, filter)
+}
+
+// ListFutureInstallmentsByAccountID mocks base method.
+func (m *MockITransactions) ListFutureInstallmentsByAccountID(ctx context.Context, accountID string, after time.Time) ([]modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFutureInstallmentsByAccountID", ctx, accountID, after)
+	ret0, _ := ret[0].([]modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFutureInstallmentsByAccountID indicates an expected call of ListFutureInstallmentsByAccountID.
+func (mr *MockITransactionsMockRecorder) ListFutureInstallmentsByAccountID(ctx, accountID, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFutureInstallmentsByAccountID", reflect.TypeOf((*MockITransactions)(nil).ListFutureInstallmentsByAccountID), ctx, accountID, after)
+}
+
+// ListInstallmentsByParentID mocks base method.
+func (m *MockITransactions) ListInstallmentsByParentID(ctx context.Context, parentID string) ([]modelTransactions.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstallmentsByParentID", ctx, parentID)
+	ret0, _ := ret[0].([]modelTransactions.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateBalance indicates an expected call of UpdateBalance.
-func (mr *MockITransactionsMockRecorder) UpdateBalance(ctx, transaction interface{}) *gomock.Call {
+// ListInstallmentsByParentID indicates an expected call of ListInstallmentsByParentID.
+func (mr *MockITransactionsMockRecorder) ListInstallmentsByParentID(ctx, parentID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockITransactions)(nil).UpdateBalance), ctx, transaction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstallmentsByParentID", reflect.TypeOf((*MockITransactions)(nil).ListInstallmentsByParentID), ctx, parentID)
 }
@@ -1,35 +1,249 @@
 package modelAccounts
 
 import (
+	"encoding/base64"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+)
+
+// Statuses of an account. Blocked accounts take no debits, closed ones take no transactions at all.
+const (
+	StatusActive  = "ACTIVE"
+	StatusBlocked = "BLOCKED"
+	StatusClosed  = "CLOSED"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
 )
 
+// Errors of a request refused by validation.
+var (
+	ErrAvailableCreditLimitInvalid = modelErrors.Validation("AVAILABLE_CREDIT_LIMIT_INVALID", "available credit limit invalid")
+	ErrStatementClosingDayInvalid  = modelErrors.Validation("STATEMENT_CLOSING_DAY_INVALID", "statement closing day invalid")
+	ErrPayloadInvalid              = modelErrors.Validation("PAYLOAD_INVALID", "payload invalid")
+	ErrLimitInvalid                = modelErrors.Validation("LIMIT_INVALID", "limit invalid")
+	ErrDocumentNumberInvalid       = modelErrors.Validation("DOCUMENT_NUMBER_INVALID", "document number invalid")
+	ErrDateRangeInvalid            = modelErrors.Validation("DATE_RANGE_INVALID", "date range invalid")
+	ErrStatusInvalid               = modelErrors.Validation("STATUS_INVALID", "status invalid")
+	ErrReasonInvalid               = modelErrors.Validation("REASON_INVALID", "reason invalid")
+	ErrCursorInvalid               = modelErrors.Validation("CURSOR_INVALID", "cursor invalid")
+)
+
+// MaxReasonLength is the longest reason kept for a status change.
+const MaxReasonLength = 255
+
+// transitions holds the statuses an account can move to from each status. Closed is final.
+var transitions = map[string][]string{
+	StatusActive:  {StatusBlocked, StatusClosed},
+	StatusBlocked: {StatusActive, StatusClosed},
+}
+
 type Account struct {
-	ID             string    `json:"account_id,omitempty" db:"account_id"`
-	DocumentNumber string    `json:"document_number,omitempty" db:"document_number"`
-	CreatedAt      time.Time `json:"-" db:"created_at"`
+	ID                   string           `json:"account_id,omitempty" db:"account_id"`
+	DocumentNumber       string           `json:"document_number,omitempty" db:"document_number"`
+	DocumentType         string           `json:"document_type,omitempty" db:"document_type"`
+	AvailableCreditLimit modelMoney.Money `json:"available_credit_limit" db:"available_credit_limit"`
+	Currency             string           `json:"currency" db:"currency"`
+	StatementClosingDay  int              `json:"statement_closing_day" db:"statement_closing_day"`
+	Status               string           `json:"status" db:"status"`
+	CreatedAt            time.Time        `json:"-" db:"created_at"`
 }
 
 type Create struct {
 	DocumentNumber string `json:"document_number" db:"document_number"`
+	DocumentType   string `json:"document_type" db:"document_type"`
+
+	// AvailableCreditLimit is the default credit limit of the configuration when left out.
+	AvailableCreditLimit *modelMoney.Money `json:"available_credit_limit" db:"available_credit_limit"`
+	Currency             string            `json:"currency" db:"currency"`
+	StatementClosingDay  int               `json:"statement_closing_day" db:"statement_closing_day"`
+}
+
+// Update holds the fields of an account that can change after it is created. Fields left out are kept. A new document
+// number must be of the same type as the account document, and a new available credit limit replaces the one left.
+type Update struct {
+	AccountID            string            `param:"account_id" json:"-" swaggerignore:"true"`
+	DocumentNumber       *string           `json:"document_number"`
+	StatementClosingDay  *int              `json:"statement_closing_day"`
+	AvailableCreditLimit *modelMoney.Money `json:"available_credit_limit"`
+}
+
+type ListFilter struct {
+	DocumentNumber string    `query:"document_number"`
+	StartDate      time.Time `query:"start_date"`
+	EndDate        time.Time `query:"end_date"`
+	Cursor         string    `query:"cursor"`
+	Limit          int       `query:"limit"`
+	After          *Cursor
+}
+
+type AccountsPage struct {
+	Accounts   []Account `json:"accounts"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type Cursor struct {
+	CreatedAt time.Time
+	AccountID string
 }
 
 type CreateResponse struct {
 	AccountID string `json:"account_id"`
 }
 
+type ChangeStatus struct {
+	AccountID string `param:"account_id" json:"-" swaggerignore:"true"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+}
+
+// StatusChange is a status change of an account, kept with its reason.
+type StatusChange struct {
+	ChangeID   int64     `db:"change_id" json:"change_id"`
+	AccountID  string    `db:"account_id" json:"account_id"`
+	FromStatus string    `db:"from_status" json:"from_status"`
+	ToStatus   string    `db:"to_status" json:"to_status"`
+	Reason     string    `db:"reason" json:"reason"`
+	ChangedAt  time.Time `db:"changed_at" json:"changed_at"`
+}
+
+type StatusHistory struct {
+	AccountID string         `json:"account_id"`
+	Changes   []StatusChange `json:"changes"`
+}
+
 func (c Create) Valid() error {
 
-	if len(c.DocumentNumber) != 11 {
-		return fmt.Errorf("document number invalid")
+	if err := ValidDocument(c.DocumentType, c.DocumentNumber); err != nil {
+		return err
+	}
+
+	if c.AvailableCreditLimit != nil && *c.AvailableCreditLimit < 0 {
+		return ErrAvailableCreditLimitInvalid
+	}
+
+	if !modelMoney.ValidCurrency(c.Currency) {
+		return modelMoney.ErrCurrencyInvalid
+	}
+
+	if !modelStatements.ValidClosingDay(c.StatementClosingDay) {
+		return ErrStatementClosingDayInvalid
+	}
+
+	return nil
+}
+
+func (u Update) Valid() error {
+
+	if u.DocumentNumber == nil && u.StatementClosingDay == nil && u.AvailableCreditLimit == nil {
+		return ErrPayloadInvalid
+	}
 
+	if u.StatementClosingDay != nil && !modelStatements.ValidClosingDay(*u.StatementClosingDay) {
+		return ErrStatementClosingDayInvalid
 	}
 
-	if _, err := strconv.Atoi(c.DocumentNumber); err != nil {
-		return fmt.Errorf("document number invalid")
+	if u.AvailableCreditLimit != nil && *u.AvailableCreditLimit < 0 {
+		return ErrAvailableCreditLimitInvalid
 	}
 
 	return nil
 }
+
+func (f *ListFilter) Valid() error {
+
+	if f.Limit < 0 || f.Limit > MaxListLimit {
+		return ErrLimitInvalid
+	}
+
+	if f.Limit == 0 {
+		f.Limit = DefaultListLimit
+	}
+
+	if f.DocumentNumber != "" && (!onlyDigits(f.DocumentNumber) || len(f.DocumentNumber) > MaxDocumentLength) {
+		return ErrDocumentNumberInvalid
+	}
+
+	if !f.StartDate.IsZero() && !f.EndDate.IsZero() && f.StartDate.After(f.EndDate) {
+		return ErrDateRangeInvalid
+	}
+
+	if f.Cursor != "" {
+		cursor, err := DecodeCursor(f.Cursor)
+		if err != nil {
+			return err
+		}
+		f.After = &cursor
+	}
+
+	return nil
+}
+
+func (c *ChangeStatus) Valid() error {
+
+	c.Status = strings.ToUpper(strings.TrimSpace(c.Status))
+	c.Reason = strings.TrimSpace(c.Reason)
+
+	if c.Status != StatusActive && c.Status != StatusBlocked && c.Status != StatusClosed {
+		return ErrStatusInvalid
+	}
+
+	if c.Reason == "" || len(c.Reason) > MaxReasonLength {
+		return ErrReasonInvalid
+	}
+
+	return nil
+}
+
+// CanChangeStatus reports whether an account can move from one status to the other.
+func CanChangeStatus(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Encode returns an opaque token pointing right after the account, to be sent back as the cursor of the next page.
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%s|%s", c.CreatedAt.UTC().Format(time.RFC3339Nano), c.AccountID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+
+	var cursor Cursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return cursor, ErrCursorInvalid
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	if _, err := uuid.Parse(parts[1]); err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	cursor.CreatedAt = createdAt
+	cursor.AccountID = parts[1]
+
+	return cursor, nil
+}
@@ -2,30 +2,68 @@ package modelAccounts
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 )
 
 func TestValid(t *testing.T) {
+
+	negative := modelMoney.MustParse("-1")
+
 	tests := map[string]struct {
 		input Create
 		err   error
 	}{
 		"should be able to validate document": {
 			input: Create{
-				DocumentNumber: "11111111111",
+				DocumentNumber:      "52998224725",
+				DocumentType:        DocumentTypeCPF,
+				Currency:            "BRL",
+				StatementClosingDay: 1,
 			},
 		},
+		"should not be able to validate document with error statement closing day invalid": {
+			input: Create{
+				DocumentNumber:      "52998224725",
+				DocumentType:        DocumentTypeCPF,
+				Currency:            "BRL",
+				StatementClosingDay: 29,
+			},
+			err: fmt.Errorf("statement closing day invalid"),
+		},
 		"should not be able to validate document with error length document input is invalid": {
 			input: Create{
-				DocumentNumber: "1111111111",
+				DocumentNumber: "5299822472",
+				DocumentType:   DocumentTypeCPF,
 			},
-			err: fmt.Errorf("document number invalid"),
+			err: ErrDocumentNumberLength,
+		},
+		"should not be able to validate document with error available credit limit negative": {
+			input: Create{
+				DocumentNumber:       "52998224725",
+				DocumentType:         DocumentTypeCPF,
+				AvailableCreditLimit: &negative,
+			},
+			err: fmt.Errorf("available credit limit invalid"),
+		},
+		"should not be able to validate document with error currency invalid": {
+			input: Create{
+				DocumentNumber: "52998224725",
+				DocumentType:   DocumentTypeCPF,
+				Currency:       "XXX",
+			},
+			err: fmt.Errorf("currency invalid"),
 		},
 		"should not be able to validate document with error only numbers input": {
 			input: Create{
-				DocumentNumber: "1111111111A",
+				DocumentNumber: "5299822472A",
+				DocumentType:   DocumentTypeCPF,
 			},
-			err: fmt.Errorf("document number invalid"),
+			err: ErrDocumentNumberNotNumeric,
 		},
 	}
 
@@ -34,9 +72,193 @@ func TestValid(t *testing.T) {
 
 			err := tt.input.Valid()
 
-			if err != nil && err.Error() != tt.err.Error() {
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
 			}
 		})
 	}
 }
+
+func TestChangeStatusValid(t *testing.T) {
+	tests := map[string]struct {
+		input    ChangeStatus
+		expected ChangeStatus
+		err      error
+	}{
+		"should be able to validate status change": {
+			input:    ChangeStatus{Status: " blocked ", Reason: " suspected fraud "},
+			expected: ChangeStatus{Status: StatusBlocked, Reason: "suspected fraud"},
+		},
+		"should not be able to validate status change with error status invalid": {
+			input: ChangeStatus{Status: "FROZEN", Reason: "suspected fraud"},
+			err:   fmt.Errorf("status invalid"),
+		},
+		"should not be able to validate status change with error reason empty": {
+			input: ChangeStatus{Status: StatusBlocked, Reason: " "},
+			err:   fmt.Errorf("reason invalid"),
+		},
+		"should not be able to validate status change with error reason too long": {
+			input: ChangeStatus{Status: StatusBlocked, Reason: strings.Repeat("a", MaxReasonLength+1)},
+			err:   fmt.Errorf("reason invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.input != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, tt.input)
+			}
+		})
+	}
+}
+
+func TestCanChangeStatus(t *testing.T) {
+	tests := map[string]struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		"should be able to block an active account": {
+			from: StatusActive, to: StatusBlocked, expected: true,
+		},
+		"should be able to unblock a blocked account": {
+			from: StatusBlocked, to: StatusActive, expected: true,
+		},
+		"should be able to close an active account": {
+			from: StatusActive, to: StatusClosed, expected: true,
+		},
+		"should be able to close a blocked account": {
+			from: StatusBlocked, to: StatusClosed, expected: true,
+		},
+		"should not be able to block a blocked account": {
+			from: StatusBlocked, to: StatusBlocked,
+		},
+		"should not be able to reopen a closed account": {
+			from: StatusClosed, to: StatusActive,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := CanChangeStatus(tt.from, tt.to); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdateValid(t *testing.T) {
+
+	document := "52998224725"
+	closingDay := 10
+	invalidClosingDay := 29
+	limit, negative := modelMoney.MustParse("1500"), modelMoney.MustParse("-1")
+
+	tests := map[string]struct {
+		input Update
+		err   error
+	}{
+		"should be able to validate update of document": {
+			input: Update{DocumentNumber: &document},
+		},
+		"should be able to validate update of statement closing day": {
+			input: Update{StatementClosingDay: &closingDay},
+		},
+		"should be able to validate update of available credit limit": {
+			input: Update{AvailableCreditLimit: &limit},
+		},
+		"should not be able to validate update with error available credit limit negative": {
+			input: Update{AvailableCreditLimit: &negative},
+			err:   fmt.Errorf("available credit limit invalid"),
+		},
+		"should not be able to validate update with no field": {
+			input: Update{},
+			err:   fmt.Errorf("payload invalid"),
+		},
+		"should not be able to validate update with error statement closing day invalid": {
+			input: Update{DocumentNumber: &document, StatementClosingDay: &invalidClosingDay},
+			err:   fmt.Errorf("statement closing day invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestListFilterValid(t *testing.T) {
+
+	createdAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	accountID := "4a1c1f7e-2a55-4c8b-9d9e-0f3b5b1a2c3d"
+
+	tests := map[string]struct {
+		input    ListFilter
+		expected ListFilter
+		err      error
+	}{
+		"should be able to validate filter with default limit": {
+			input:    ListFilter{DocumentNumber: "111"},
+			expected: ListFilter{DocumentNumber: "111", Limit: DefaultListLimit},
+		},
+		"should be able to validate filter with cursor": {
+			input: ListFilter{Limit: 10, Cursor: Cursor{CreatedAt: createdAt, AccountID: accountID}.Encode()},
+			expected: ListFilter{
+				Limit:  10,
+				Cursor: Cursor{CreatedAt: createdAt, AccountID: accountID}.Encode(),
+				After:  &Cursor{CreatedAt: createdAt, AccountID: accountID},
+			},
+		},
+		"should not be able to validate filter with error limit invalid": {
+			input: ListFilter{Limit: MaxListLimit + 1},
+			err:   fmt.Errorf("limit invalid"),
+		},
+		"should not be able to validate filter with error document number invalid": {
+			input: ListFilter{DocumentNumber: "111.111"},
+			err:   fmt.Errorf("document number invalid"),
+		},
+		"should not be able to validate filter with error document number too long": {
+			input: ListFilter{DocumentNumber: "112223330001810"},
+			err:   fmt.Errorf("document number invalid"),
+		},
+		"should not be able to validate filter with error date range invalid": {
+			input: ListFilter{StartDate: createdAt, EndDate: createdAt.Add(-time.Hour)},
+			err:   fmt.Errorf("date range invalid"),
+		},
+		"should not be able to validate filter with error cursor invalid": {
+			input: ListFilter{Cursor: "invalid"},
+			err:   fmt.Errorf("cursor invalid"),
+		},
+		"should not be able to validate filter with error cursor account id invalid": {
+			input: ListFilter{Cursor: Cursor{CreatedAt: createdAt, AccountID: "id"}.Encode()},
+			err:   fmt.Errorf("cursor invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && !reflect.DeepEqual(tt.input, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, tt.input)
+			}
+		})
+	}
+}
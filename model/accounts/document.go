@@ -0,0 +1,121 @@
+package modelAccounts
+
+import modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+
+// Document types. Individuals are identified by a CPF, of 11 digits, and businesses by a CNPJ, of 14 digits.
+const (
+	DocumentTypeCPF  = "CPF"
+	DocumentTypeCNPJ = "CNPJ"
+)
+
+// Errors of a document refused by validation.
+var (
+	ErrDocumentTypeInvalid        = modelErrors.Validation("DOCUMENT_TYPE_INVALID", "document type invalid")
+	ErrDocumentNumberNotNumeric   = modelErrors.Validation("DOCUMENT_NUMBER_NOT_NUMERIC", "document number must have only digits")
+	ErrDocumentNumberLength       = modelErrors.Validation("DOCUMENT_NUMBER_LENGTH_INVALID", "document number length invalid")
+	ErrDocumentNumberRepeated     = modelErrors.Validation("DOCUMENT_NUMBER_REPEATED_DIGITS", "document number with all digits repeated")
+	ErrDocumentNumberCheckDigits  = modelErrors.Validation("DOCUMENT_NUMBER_CHECK_DIGITS_INVALID", "document number check digits invalid")
+	ErrDocumentNumberTypeMismatch = modelErrors.Validation("DOCUMENT_NUMBER_TYPE_MISMATCH", "document number does not match the document type")
+)
+
+var documentLengths = map[string]int{
+	DocumentTypeCPF:  11,
+	DocumentTypeCNPJ: 14,
+}
+
+// MaxDocumentLength is the length of the longest document number, a CNPJ.
+const MaxDocumentLength = 14
+
+// DocumentType returns the type of the document number by its length, or an empty string when no type has that length.
+func DocumentType(document string) string {
+	for documentType, length := range documentLengths {
+		if len(document) == length {
+			return documentType
+		}
+	}
+	return ""
+}
+
+// ValidDocument checks the document number against its type, check digits included. The number must be clean, with
+// digits only.
+func ValidDocument(documentType, document string) error {
+
+	length, ok := documentLengths[documentType]
+	if !ok {
+		return ErrDocumentTypeInvalid
+	}
+
+	if !onlyDigits(document) {
+		return ErrDocumentNumberNotNumeric
+	}
+
+	if len(document) != length {
+		if DocumentType(document) != "" {
+			return ErrDocumentNumberTypeMismatch
+		}
+		return ErrDocumentNumberLength
+	}
+
+	if repeatedDigits(document) {
+		return ErrDocumentNumberRepeated
+	}
+
+	var weights [][]int
+	if documentType == DocumentTypeCPF {
+		weights = [][]int{
+			{10, 9, 8, 7, 6, 5, 4, 3, 2},
+			{11, 10, 9, 8, 7, 6, 5, 4, 3, 2},
+		}
+	} else {
+		weights = [][]int{
+			{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2},
+			{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2},
+		}
+	}
+
+	for _, weight := range weights {
+		if checkDigit(document, weight) != int(document[len(weight)]-'0') {
+			return ErrDocumentNumberCheckDigits
+		}
+	}
+
+	return nil
+}
+
+// checkDigit computes the modulo 11 check digit of the first digits of the document, one for each weight.
+func checkDigit(document string, weights []int) int {
+
+	sum := 0
+	for i, weight := range weights {
+		sum += int(document[i]-'0') * weight
+	}
+
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+func onlyDigits(document string) bool {
+
+	if document == "" {
+		return false
+	}
+
+	for _, r := range document {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func repeatedDigits(document string) bool {
+	for i := 1; i < len(document); i++ {
+		if document[i] != document[0] {
+			return false
+		}
+	}
+	return true
+}
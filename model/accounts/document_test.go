@@ -0,0 +1,107 @@
+package modelAccounts
+
+import (
+	"testing"
+)
+
+func TestValidDocument(t *testing.T) {
+	tests := map[string]struct {
+		documentType string
+		document     string
+		err          error
+	}{
+		"should be able to validate CPF": {
+			documentType: DocumentTypeCPF,
+			document:     "52998224725",
+		},
+		"should be able to validate CPF with check digit zero": {
+			documentType: DocumentTypeCPF,
+			document:     "12345678909",
+		},
+		"should be able to validate CNPJ": {
+			documentType: DocumentTypeCNPJ,
+			document:     "11222333000181",
+		},
+		"should not be able to validate document with error document type invalid": {
+			documentType: "RG",
+			document:     "52998224725",
+			err:          ErrDocumentTypeInvalid,
+		},
+		"should not be able to validate document with error sign": {
+			documentType: DocumentTypeCPF,
+			document:     "+5299822472",
+			err:          ErrDocumentNumberNotNumeric,
+		},
+		"should not be able to validate document with error empty": {
+			documentType: DocumentTypeCPF,
+			err:          ErrDocumentNumberNotNumeric,
+		},
+		"should not be able to validate document with error length invalid": {
+			documentType: DocumentTypeCNPJ,
+			document:     "112223330001",
+			err:          ErrDocumentNumberLength,
+		},
+		"should not be able to validate CPF with error type mismatch": {
+			documentType: DocumentTypeCPF,
+			document:     "11222333000181",
+			err:          ErrDocumentNumberTypeMismatch,
+		},
+		"should not be able to validate CPF with error repeated digits": {
+			documentType: DocumentTypeCPF,
+			document:     "11111111111",
+			err:          ErrDocumentNumberRepeated,
+		},
+		"should not be able to validate CPF with error first check digit": {
+			documentType: DocumentTypeCPF,
+			document:     "52998224715",
+			err:          ErrDocumentNumberCheckDigits,
+		},
+		"should not be able to validate CPF with error second check digit": {
+			documentType: DocumentTypeCPF,
+			document:     "52998224726",
+			err:          ErrDocumentNumberCheckDigits,
+		},
+		"should not be able to validate CNPJ with error check digits": {
+			documentType: DocumentTypeCNPJ,
+			document:     "11222333000182",
+			err:          ErrDocumentNumberCheckDigits,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := ValidDocument(tt.documentType, tt.document)
+
+			if err != tt.err {
+				t.Errorf(`Expected err: "%v" got "%v"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestDocumentType(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"should be able to get the type of a CPF": {
+			input: "52998224725", expected: DocumentTypeCPF,
+		},
+		"should be able to get the type of a CNPJ": {
+			input: "11222333000181", expected: DocumentTypeCNPJ,
+		},
+		"should not be able to get the type of a document of unknown length": {
+			input: "111",
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := DocumentType(tt.input); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package modelAccruals
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+)
+
+// InterestDays is the number of days the monthly interest rate is spread over, a thirtieth of it accrues each day.
+const InterestDays = 30
+
+// Rates are the charges on overdue debits: Interest is the monthly rate on what is overdue, accrued daily, and LateFee the
+// rate charged once on what is overdue of each statement. A nil rate charges nothing.
+type Rates struct {
+	Interest *big.Rat
+	LateFee  *big.Rat
+}
+
+// Overdue is an open debit whose statement is past its due date, with its open balance at the start of the accrual date,
+// and whether the interest of its currency at the date, and the late fee of its statement, were already charged.
+type Overdue struct {
+	TransactionID   string           `db:"transaction_id"`
+	Currency        string           `db:"currency"`
+	Balance         modelMoney.Money `db:"balance"`
+	StatementID     string           `db:"statement_id"`
+	DueDate         time.Time        `db:"due_date"`
+	InterestCharged bool             `db:"interest_charged"`
+	LateFeeCharged  bool             `db:"late_fee_charged"`
+}
+
+// Accrual is a charge made on the overdue debits of an account at a date. The late fee keeps the statement it was charged
+// for, interest has none.
+type Accrual struct {
+	AccrualID       int64            `db:"accrual_id" json:"accrual_id"`
+	AccountID       string           `db:"account_id" json:"account_id"`
+	TransactionID   string           `db:"transaction_id" json:"transaction_id"`
+	OperationTypeID int              `db:"operation_type_id" json:"operation_type_id"`
+	Currency        string           `db:"currency" json:"currency"`
+	Amount          modelMoney.Money `db:"amount" json:"amount"`
+	AccrualDate     time.Time        `db:"accrual_date" json:"accrual_date"`
+	StatementID     *string          `db:"statement_id" json:"statement_id,omitempty"`
+	CreatedAt       time.Time        `db:"created_at" json:"created_at"`
+}
+
+type AccrueResult struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Accounts int       `json:"accounts"`
+	Charges  int       `json:"charges"`
+	Failed   int       `json:"failed"`
+}
+
+// Day is the start of the day of date, in UTC, the date charges accrue at.
+func Day(date time.Time) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NewAccruals returns the charges of the account at the date for its overdue debits, in the order of the currencies: a day
+// of interest on all that is overdue in each currency not charged it yet, and the late fee of each statement not charged one
+// yet. Charges that round to zero are left out.
+func NewAccruals(accountID string, date time.Time, overdue []Overdue, rates Rates) []Accrual {
+
+	var currencies, statements []string
+
+	interest := map[string]modelMoney.Money{}
+	interestCharged := map[string]bool{}
+	lateFees := map[string]modelMoney.Money{}
+	statementCurrency := map[string]string{}
+
+	for _, o := range overdue {
+
+		if _, ok := interest[o.Currency]; !ok {
+			currencies = append(currencies, o.Currency)
+		}
+		interest[o.Currency] += o.Balance.Abs()
+		interestCharged[o.Currency] = interestCharged[o.Currency] || o.InterestCharged
+
+		if o.LateFeeCharged {
+			continue
+		}
+
+		if _, ok := lateFees[o.StatementID]; !ok {
+			statements = append(statements, o.StatementID)
+			statementCurrency[o.StatementID] = o.Currency
+		}
+		lateFees[o.StatementID] += o.Balance.Abs()
+	}
+
+	sort.Strings(currencies)
+
+	var accruals []Accrual
+
+	for _, currency := range currencies {
+
+		if rates.Interest != nil && !interestCharged[currency] {
+			daily := new(big.Rat).Quo(rates.Interest, big.NewRat(InterestDays, 1))
+			if amount := interest[currency].Convert(daily); amount > 0 {
+				accruals = append(accruals, Accrual{
+					AccountID:       accountID,
+					OperationTypeID: modelOperaTionsType.InterestID,
+					Currency:        currency,
+					Amount:          amount,
+					AccrualDate:     date,
+				})
+			}
+		}
+
+		if rates.LateFee == nil {
+			continue
+		}
+
+		for _, statementID := range statements {
+
+			if statementCurrency[statementID] != currency {
+				continue
+			}
+
+			if amount := lateFees[statementID].Convert(rates.LateFee); amount > 0 {
+				statementID := statementID
+				accruals = append(accruals, Accrual{
+					AccountID:       accountID,
+					OperationTypeID: modelOperaTionsType.LateFeeID,
+					Currency:        currency,
+					Amount:          amount,
+					AccrualDate:     date,
+					StatementID:     &statementID,
+				})
+			}
+		}
+	}
+
+	return accruals
+}
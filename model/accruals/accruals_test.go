@@ -0,0 +1,135 @@
+package modelAccruals
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+)
+
+func TestDay(t *testing.T) {
+
+	tests := map[string]struct {
+		input    time.Time
+		expected time.Time
+	}{
+		"should be able to get the start of the day": {
+			input:    time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		"should be able to get the start of the day in UTC": {
+			input:    time.Date(2024, 1, 10, 22, 0, 0, 0, time.FixedZone("BRT", -3*60*60)),
+			expected: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := Day(tt.input)
+
+			if !res.Equal(tt.expected) || res.Location() != time.UTC {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestNewAccruals(t *testing.T) {
+
+	date := time.Date(2024, 2, 21, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+	rates := Rates{Interest: big.NewRat(12, 100), LateFee: big.NewRat(2, 100)}
+	january, february, usd := "january_id", "february_id", "usd_id"
+
+	type input struct {
+		overdue []Overdue
+		rates   Rates
+	}
+
+	tests := map[string]struct {
+		input    input
+		expected []Accrual
+	}{
+		"should be able to accrue interest and the late fee": {
+			input: input{
+				overdue: []Overdue{
+					{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-60"), StatementID: february, DueDate: dueDate},
+					{TransactionID: "2", Currency: "BRL", Balance: modelMoney.MustParse("-40"), StatementID: february, DueDate: dueDate},
+				},
+				rates: rates,
+			},
+			expected: []Accrual{
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "BRL", Amount: modelMoney.MustParse("0.40"), AccrualDate: date},
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.LateFeeID, Currency: "BRL", Amount: modelMoney.MustParse("2"), AccrualDate: date, StatementID: &february},
+			},
+		},
+		"should be able to accrue interest without the late fee already charged": {
+			input: input{
+				overdue: []Overdue{
+					{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-100"), StatementID: january, LateFeeCharged: true},
+					{TransactionID: "2", Currency: "BRL", Balance: modelMoney.MustParse("-50"), StatementID: february},
+				},
+				rates: rates,
+			},
+			expected: []Accrual{
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "BRL", Amount: modelMoney.MustParse("0.60"), AccrualDate: date},
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.LateFeeID, Currency: "BRL", Amount: modelMoney.MustParse("1"), AccrualDate: date, StatementID: &february},
+			},
+		},
+		"should be able to accrue the late fee without the interest already charged": {
+			input: input{
+				overdue: []Overdue{
+					{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-100"), StatementID: february, InterestCharged: true},
+					{TransactionID: "2", Currency: "USD", Balance: modelMoney.MustParse("-100"), StatementID: usd, InterestCharged: true, LateFeeCharged: true},
+				},
+				rates: rates,
+			},
+			expected: []Accrual{
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.LateFeeID, Currency: "BRL", Amount: modelMoney.MustParse("2"), AccrualDate: date, StatementID: &february},
+			},
+		},
+		"should be able to accrue each currency on its own in the order of the currencies": {
+			input: input{
+				overdue: []Overdue{
+					{TransactionID: "1", Currency: "USD", Balance: modelMoney.MustParse("-100"), StatementID: usd},
+					{TransactionID: "2", Currency: "BRL", Balance: modelMoney.MustParse("-50"), StatementID: february},
+				},
+				rates: rates,
+			},
+			expected: []Accrual{
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "BRL", Amount: modelMoney.MustParse("0.20"), AccrualDate: date},
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.LateFeeID, Currency: "BRL", Amount: modelMoney.MustParse("1"), AccrualDate: date, StatementID: &february},
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.InterestID, Currency: "USD", Amount: modelMoney.MustParse("0.40"), AccrualDate: date},
+				{AccountID: "id", OperationTypeID: modelOperaTionsType.LateFeeID, Currency: "USD", Amount: modelMoney.MustParse("2"), AccrualDate: date, StatementID: &usd},
+			},
+		},
+		"should be able to accrue nothing with charges rounding to zero": {
+			input: input{
+				overdue: []Overdue{
+					{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-1"), StatementID: february},
+				},
+				rates: Rates{Interest: big.NewRat(12, 100)},
+			},
+		},
+		"should be able to accrue nothing without overdue debits": {
+			input: input{
+				rates: rates,
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := NewAccruals("id", date, tt.input.overdue, tt.input.rates)
+
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package modelAllocations
+
+import (
+	"time"
+
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+// Allocation records amount of a payment applied to a debit. Reversals and refunds that reopen a settlement record it with
+// a negative amount and the compensating transaction as ReversalID, so the allocations of a pair add up to what is still
+// applied between them.
+type Allocation struct {
+	AllocationID int64            `db:"allocation_id" json:"allocation_id"`
+	EntryID      string           `db:"entry_id" json:"entry_id"`
+	PaymentID    string           `db:"payment_id" json:"payment_id"`
+	DebitID      string           `db:"debit_id" json:"debit_id"`
+	Amount       modelMoney.Money `db:"amount" json:"amount"`
+	Currency     string           `db:"currency" json:"currency"`
+	ReversalID   *string          `db:"reversal_id" json:"reversal_id,omitempty"`
+	AppliedAt    time.Time        `db:"applied_at" json:"applied_at"`
+}
+
+type TransactionAllocations struct {
+	TransactionID string       `json:"transaction_id"`
+	Allocations   []Allocation `json:"allocations"`
+}
+
+// FromEntry returns the allocations of the settlements made and reopened by a posted ledger entry, read from its
+// receivable postings.
+func FromEntry(entry modelLedger.Entry) []Allocation {
+
+	var allocations []Allocation
+
+	for _, posting := range entry.Postings {
+
+		if posting.Ledger != modelLedger.LedgerReceivable || posting.CounterpartTransactionID == nil {
+			continue
+		}
+
+		allocation := Allocation{
+			EntryID:   entry.EntryID,
+			PaymentID: *posting.CounterpartTransactionID,
+			DebitID:   posting.TransactionID,
+			Amount:    posting.Credit - posting.Debit,
+			Currency:  posting.Currency,
+		}
+
+		if entry.Kind == modelLedger.KindReversal || entry.Kind == modelLedger.KindRefund {
+			reversalID := entry.TransactionID
+			allocation.ReversalID = &reversalID
+		}
+
+		allocations = append(allocations, allocation)
+	}
+
+	return allocations
+}
@@ -0,0 +1,67 @@
+package modelAllocations
+
+import (
+	"reflect"
+	"testing"
+
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+)
+
+func TestFromEntry(t *testing.T) {
+
+	payment := modelTransactions.Transaction{TransactionID: "payment", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("60")}
+	debit := modelTransactions.Transaction{TransactionID: "debit", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-50")}
+	other := modelTransactions.Transaction{TransactionID: "other", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-20")}
+	refund := modelTransactions.Transaction{TransactionID: "refund", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-30")}
+
+	reversalID := "refund"
+
+	tests := map[string]struct {
+		input    func() modelLedger.Entry
+		expected []Allocation
+	}{
+		"should be able to get the allocations of a discharge": {
+			input: func() modelLedger.Entry {
+				entry := modelLedger.NewDischargeEntry(payment)
+				entry.Settle(payment, debit, modelMoney.MustParse("50"))
+				entry.Settle(payment, other, modelMoney.MustParse("10"))
+				entry.EntryID = "entry"
+				return entry
+			},
+			expected: []Allocation{
+				{EntryID: "entry", PaymentID: "payment", DebitID: "debit", Amount: modelMoney.MustParse("50"), Currency: "BRL"},
+				{EntryID: "entry", PaymentID: "payment", DebitID: "other", Amount: modelMoney.MustParse("10"), Currency: "BRL"},
+			},
+		},
+		"should be able to get the allocations reopened by a refund": {
+			input: func() modelLedger.Entry {
+				entry := modelLedger.NewReversalEntry(modelLedger.KindRefund, refund)
+				entry.Reopen(payment, debit, modelMoney.MustParse("30"))
+				entry.Reverse(payment, refund, modelMoney.MustParse("30"), 0)
+				entry.EntryID = "entry"
+				return entry
+			},
+			expected: []Allocation{
+				{EntryID: "entry", PaymentID: "payment", DebitID: "debit", Amount: modelMoney.MustParse("-30"), Currency: "BRL", ReversalID: &reversalID},
+			},
+		},
+		"should be able to get no allocations of a transaction": {
+			input: func() modelLedger.Entry {
+				return modelLedger.NewTransactionEntry(debit)
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := FromEntry(tt.input())
+
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package modelErrors
+
+import "errors"
+
+// Kind is the class of an error, telling clients whether a request can succeed as is, after changing it or later.
+type Kind string
+
+const (
+	KindValidation    Kind = "VALIDATION"
+	KindNotFound      Kind = "NOT_FOUND"
+	KindConflict      Kind = "CONFLICT"
+	KindLimitExceeded Kind = "LIMIT_EXCEEDED"
+	KindUnprocessable Kind = "UNPROCESSABLE"
+	KindUnavailable   Kind = "UNAVAILABLE"
+)
+
+// CodeUnavailable is the code of every error of a dependency, such as the database, that failed.
+const CodeUnavailable = "UNAVAILABLE"
+
+// Error is an error of the domain. Code is machine readable and the same for every release, Message is for people and
+// may change. Two errors with the same code are the same error to errors.Is, whatever caused them.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Validation is a request refused for its content. It fails the same way until it is changed.
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// NotFound is a request for something that does not exist.
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict is a request refused for the current state of what it changes, such as something already done.
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// LimitExceeded is a request that goes over a limit, such as the credit limit.
+func LimitExceeded(code, message string) *Error {
+	return New(KindLimitExceeded, code, message)
+}
+
+// Unprocessable is a valid request that a business rule refuses, such as a debit on a blocked account.
+func Unprocessable(code, message string) *Error {
+	return New(KindUnprocessable, code, message)
+}
+
+// Unavailable is a request that failed because a dependency failed. It may succeed later.
+func Unavailable(message string, err error) *Error {
+	return &Error{
+		Kind:    KindUnavailable,
+		Code:    CodeUnavailable,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// Wrap returns err as is when it is already an error of the domain, and as unavailable with the message otherwise.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := As(err); ok {
+		return err
+	}
+
+	return Unavailable(message, err)
+}
+
+// As returns the error of the domain in err, if any.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
@@ -0,0 +1,81 @@
+package modelErrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIs(t *testing.T) {
+
+	notFound := NotFound("ACCOUNT_NOT_FOUND", "account not found")
+
+	tests := map[string]struct {
+		err      error
+		target   error
+		expected bool
+	}{
+		"should be the same error": {
+			err: notFound, target: notFound, expected: true,
+		},
+		"should be the same error by code": {
+			err: NotFound("ACCOUNT_NOT_FOUND", "account id not found"), target: notFound, expected: true,
+		},
+		"should be the same error wrapped": {
+			err: fmt.Errorf("fail: %w", notFound), target: notFound, expected: true,
+		},
+		"should be the same unavailable error whatever caused it": {
+			err: Unavailable("fail to get account", fmt.Errorf("any")), target: Unavailable("", nil), expected: true,
+		},
+		"should not be the same error with another code": {
+			err: NotFound("TRANSACTION_NOT_FOUND", "transaction not found"), target: notFound,
+		},
+		"should not be the same error of another type": {
+			err: fmt.Errorf("account not found"), target: notFound,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := errors.Is(tt.err, tt.target); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+
+	cause := fmt.Errorf("connection refused")
+	conflict := Conflict("TRANSACTION_REVERSED", "transaction already reversed")
+
+	tests := map[string]struct {
+		input    error
+		expected error
+	}{
+		"should be able to keep an error of the domain": {
+			input:    conflict,
+			expected: conflict,
+		},
+		"should be able to wrap any other error as unavailable": {
+			input:    cause,
+			expected: Unavailable("fail to get account", cause),
+		},
+		"should be able to wrap no error": {},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := Wrap(tt.input, "fail to get account")
+
+			if fmt.Sprint(res) != fmt.Sprint(tt.expected) || !errors.Is(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+			if tt.input != nil && !errors.Is(res, tt.input) {
+				t.Errorf("Expected %v to keep its cause %v", res, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package modelHealth
+
+import "time"
+
+// Statuses of the service and of each dependency. A degraded service still serves requests, more slowly or with less.
+const (
+	StatusUp       = "UP"
+	StatusDegraded = "DEGRADED"
+	StatusDown     = "DOWN"
+)
+
+// Dependencies checked for readiness.
+const (
+	DependencyDatabase = "postgres"
+	DependencyCache    = "redis"
+)
+
+type Check struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+// NewCheck is the check of the dependency that answered in latency, down when err is not nil.
+func NewCheck(name string, latency time.Duration, err error) Check {
+
+	check := Check{
+		Name:      name,
+		Status:    StatusUp,
+		LatencyMS: float64(latency.Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		check.Status = StatusDown
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+// NewReport sums up the checks: down when a required dependency is down, degraded when any other is, up otherwise.
+func NewReport(checks []Check, required ...string) Report {
+
+	report := Report{Status: StatusUp, Checks: checks}
+
+	for _, check := range checks {
+
+		if check.Status == StatusUp {
+			continue
+		}
+
+		if contains(required, check.Name) {
+			report.Status = StatusDown
+			return report
+		}
+
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
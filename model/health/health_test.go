@@ -0,0 +1,62 @@
+package modelHealth
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewCheck(t *testing.T) {
+
+	tests := map[string]struct {
+		latency  time.Duration
+		err      error
+		expected Check
+	}{
+		"should be able to check a dependency up": {
+			latency:  1500 * time.Microsecond,
+			expected: Check{Name: DependencyDatabase, Status: StatusUp, LatencyMS: 1.5},
+		},
+		"should be able to check a dependency down": {
+			latency:  2 * time.Second,
+			err:      fmt.Errorf("connection refused"),
+			expected: Check{Name: DependencyDatabase, Status: StatusDown, LatencyMS: 2000, Error: "connection refused"},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			if res := NewCheck(DependencyDatabase, tt.latency, tt.err); !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+
+	database := Check{Name: DependencyDatabase, Status: StatusUp}
+	databaseDown := Check{Name: DependencyDatabase, Status: StatusDown}
+	cache := Check{Name: DependencyCache, Status: StatusUp}
+	cacheDown := Check{Name: DependencyCache, Status: StatusDown}
+
+	tests := map[string]struct {
+		input    []Check
+		expected string
+	}{
+		"should be up with every dependency up":               {input: []Check{database, cache}, expected: StatusUp},
+		"should be degraded with an optional dependency down": {input: []Check{database, cacheDown}, expected: StatusDegraded},
+		"should be down with a required dependency down":      {input: []Check{databaseDown, cache}, expected: StatusDown},
+		"should be down with every dependency down":           {input: []Check{cacheDown, databaseDown}, expected: StatusDown},
+		"should be up without dependencies":                   {expected: StatusUp},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			if res := NewReport(tt.input, DependencyDatabase); res.Status != tt.expected {
+				t.Errorf("Expected status %s got %s", tt.expected, res.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package modelIdempotency
+
+import (
+	"context"
+	"time"
+)
+
+type Record struct {
+	Key         string `db:"idempotency_key" json:"idempotency_key"`
+	RequestHash string `db:"request_hash" json:"request_hash"`
+	StatusCode  int    `db:"status_code" json:"status_code"`
+	Body        string `db:"body" json:"body"`
+
+	// TransactionID is the transaction the request made, recorded in the database transaction that made it.
+	TransactionID *string `db:"transaction_id" json:"transaction_id,omitempty"`
+
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+type keyContext struct{}
+
+// Completed reports whether the response of the first request was already stored, a zero status means it is still being processed.
+func (r Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Processed reports whether the first request made its transaction, even when its response was never stored.
+func (r Record) Processed() bool {
+	return r.TransactionID != nil
+}
+
+// WithKey returns a context carrying the idempotency key of the request, so the transaction it makes is recorded with it.
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContext{}, key)
+}
+
+// KeyFrom returns the idempotency key carried by the context, if any.
+func KeyFrom(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(keyContext{}).(string)
+	return key, ok && key != ""
+}
@@ -0,0 +1,213 @@
+package modelLedger
+
+import (
+	"fmt"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+)
+
+// Ledgers of the chart of accounts. The open balance of a transaction is the credit minus the debit of its account and
+// receivable postings: debits start negative in the receivable ledger, payments start positive in the account ledger.
+const (
+	// LedgerAccount holds what is owed to the cardholder, payments not yet applied to any debit.
+	LedgerAccount = "ACCOUNT"
+	// LedgerReceivable holds what the cardholder owes, debits not yet settled by any payment.
+	LedgerReceivable = "RECEIVABLE"
+	// LedgerCash holds the money that went out to merchants or came in from the cardholder.
+	LedgerCash = "CASH"
+)
+
+const (
+	KindTransaction = "TRANSACTION"
+	KindDischarge   = "DISCHARGE"
+	KindOpening     = "OPENING"
+	KindReversal    = "REVERSAL"
+	KindRefund      = "REFUND"
+)
+
+type Entry struct {
+	EntryID       string    `db:"entry_id" json:"entry_id"`
+	AccountID     string    `db:"account_id" json:"account_id"`
+	TransactionID string    `db:"transaction_id" json:"transaction_id"`
+	Kind          string    `db:"kind" json:"kind"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	Postings      []Posting `db:"-" json:"postings"`
+}
+
+type Posting struct {
+	PostingID     int64            `db:"posting_id" json:"posting_id"`
+	EntryID       string           `db:"entry_id" json:"entry_id"`
+	AccountID     string           `db:"account_id" json:"account_id"`
+	Ledger        string           `db:"ledger" json:"ledger"`
+	TransactionID string           `db:"transaction_id" json:"transaction_id"`
+	Currency      string           `db:"currency" json:"currency"`
+	Debit         modelMoney.Money `db:"debit" json:"debit"`
+	Credit        modelMoney.Money `db:"credit" json:"credit"`
+
+	// CounterpartTransactionID is the other side of a settlement: the debit a payment was applied to, or the payment
+	// that settled a debit.
+	CounterpartTransactionID *string `db:"counterpart_transaction_id" json:"counterpart_transaction_id,omitempty"`
+}
+
+// Settlement is the net amount applied between a transaction and one of its counterparts.
+type Settlement struct {
+	TransactionID   string           `db:"transaction_id" json:"transaction_id"`
+	OperationTypeID int              `db:"operation_type_id" json:"operation_type_id"`
+	Amount          modelMoney.Money `db:"amount" json:"amount"`
+	SettledAt       time.Time        `db:"settled_at" json:"settled_at"`
+}
+
+// NewTransactionEntry records a new transaction: a debit is receivable from the cardholder and paid out in cash, a payment
+// comes in as cash and is owed to the cardholder until it is applied.
+func NewTransactionEntry(transaction modelTransactions.Transaction) Entry {
+
+	entry := Entry{
+		AccountID:     transaction.AccountID,
+		TransactionID: transaction.TransactionID,
+		Kind:          KindTransaction,
+	}
+
+	if transaction.Amount < 0 {
+		entry.debit(LedgerReceivable, transaction, transaction.Amount.Abs())
+		entry.credit(LedgerCash, transaction, transaction.Amount.Abs())
+		return entry
+	}
+
+	entry.debit(LedgerCash, transaction, transaction.Amount)
+	entry.credit(LedgerAccount, transaction, transaction.Amount)
+
+	return entry
+}
+
+// NewInstallmentsEntry records a purchase in installments: each installment is receivable on its own and the whole
+// purchase is paid out in cash.
+func NewInstallmentsEntry(purchase modelTransactions.Transaction, installments []modelTransactions.Transaction) Entry {
+
+	entry := Entry{
+		AccountID:     purchase.AccountID,
+		TransactionID: purchase.TransactionID,
+		Kind:          KindTransaction,
+	}
+
+	for _, installment := range installments {
+		entry.debit(LedgerReceivable, installment, installment.Amount.Abs())
+	}
+
+	entry.credit(LedgerCash, purchase, purchase.Amount.Abs())
+
+	return entry
+}
+
+// NewDischargeEntry starts the entry applying a payment to open debits, see Settle.
+func NewDischargeEntry(payment modelTransactions.Transaction) Entry {
+	return Entry{
+		AccountID:     payment.AccountID,
+		TransactionID: payment.TransactionID,
+		Kind:          KindDischarge,
+	}
+}
+
+// Settle applies amount of the payment to the debit, moving it out of what is owed to the cardholder and out of what is
+// receivable from them.
+func (e *Entry) Settle(payment, debit modelTransactions.Transaction, amount modelMoney.Money) {
+	e.pair(LedgerAccount, payment, LedgerReceivable, debit, amount)
+}
+
+// Reopen undoes amount of a settlement: the debit is receivable again and the payment is owed back to the cardholder.
+func (e *Entry) Reopen(payment, debit modelTransactions.Transaction, amount modelMoney.Money) {
+	e.pair(LedgerReceivable, debit, LedgerAccount, payment, amount)
+}
+
+// NewReversalEntry starts the entry of a compensating transaction, kind being KindReversal or KindRefund, see Reverse.
+func NewReversalEntry(kind string, compensating modelTransactions.Transaction) Entry {
+	return Entry{
+		AccountID:     compensating.AccountID,
+		TransactionID: compensating.TransactionID,
+		Kind:          kind,
+	}
+}
+
+// Reverse gives amount of the original transaction back through cash, taking it out of the open balance of the original.
+// The unsettled part, which was applied before the journal recorded counterparts and could not be reopened, stays open
+// on the compensating transaction instead.
+func (e *Entry) Reverse(original, compensating modelTransactions.Transaction, amount, unsettled modelMoney.Money) {
+
+	if original.Amount < 0 {
+		e.debit(LedgerCash, compensating, amount)
+		if amount > unsettled {
+			e.credit(LedgerReceivable, original, amount-unsettled)
+		}
+		if unsettled > 0 {
+			e.credit(LedgerAccount, compensating, unsettled)
+		}
+		return
+	}
+
+	if amount > unsettled {
+		e.debit(LedgerAccount, original, amount-unsettled)
+	}
+	if unsettled > 0 {
+		e.debit(LedgerReceivable, compensating, unsettled)
+	}
+	e.credit(LedgerCash, compensating, amount)
+}
+
+// Valid checks every posting moves a positive amount to a single side and that debits equal credits in each currency.
+func (e Entry) Valid() error {
+
+	if len(e.Postings) == 0 {
+		return fmt.Errorf("ledger entry empty")
+	}
+
+	totals := map[string]modelMoney.Money{}
+
+	for _, posting := range e.Postings {
+
+		if posting.Debit < 0 || posting.Credit < 0 || (posting.Debit == 0) == (posting.Credit == 0) {
+			return fmt.Errorf("ledger posting invalid")
+		}
+
+		totals[posting.Currency] += posting.Debit - posting.Credit
+	}
+
+	for _, total := range totals {
+		if total != 0 {
+			return fmt.Errorf("ledger entry unbalanced")
+		}
+	}
+
+	return nil
+}
+
+func (e *Entry) debit(ledger string, transaction modelTransactions.Transaction, amount modelMoney.Money) {
+	e.Postings = append(e.Postings, e.posting(ledger, transaction, amount, 0))
+}
+
+func (e *Entry) credit(ledger string, transaction modelTransactions.Transaction, amount modelMoney.Money) {
+	e.Postings = append(e.Postings, e.posting(ledger, transaction, 0, amount))
+}
+
+// pair debits one transaction and credits the other, each posting pointing at the other transaction as its counterpart.
+func (e *Entry) pair(debitLedger string, debited modelTransactions.Transaction, creditLedger string, credited modelTransactions.Transaction, amount modelMoney.Money) {
+
+	debit := e.posting(debitLedger, debited, amount, 0)
+	debit.CounterpartTransactionID = &credited.TransactionID
+
+	credit := e.posting(creditLedger, credited, 0, amount)
+	credit.CounterpartTransactionID = &debited.TransactionID
+
+	e.Postings = append(e.Postings, debit, credit)
+}
+
+func (e *Entry) posting(ledger string, transaction modelTransactions.Transaction, debit, credit modelMoney.Money) Posting {
+	return Posting{
+		AccountID:     e.AccountID,
+		Ledger:        ledger,
+		TransactionID: transaction.TransactionID,
+		Currency:      transaction.Currency,
+		Debit:         debit,
+		Credit:        credit,
+	}
+}
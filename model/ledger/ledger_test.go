@@ -0,0 +1,241 @@
+package modelLedger
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+)
+
+func TestNewTransactionEntry(t *testing.T) {
+	tests := map[string]struct {
+		input    modelTransactions.Transaction
+		expected []Posting
+	}{
+		"should be able to record a debit": {
+			input: modelTransactions.Transaction{TransactionID: "1", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-10")},
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "1", Currency: "BRL", Debit: modelMoney.MustParse("10")},
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "1", Currency: "BRL", Credit: modelMoney.MustParse("10")},
+			},
+		},
+		"should be able to record a payment": {
+			input: modelTransactions.Transaction{TransactionID: "2", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("60")},
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "2", Currency: "BRL", Debit: modelMoney.MustParse("60")},
+				{AccountID: "id", Ledger: LedgerAccount, TransactionID: "2", Currency: "BRL", Credit: modelMoney.MustParse("60")},
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := NewTransactionEntry(tt.input)
+
+			if res.Kind != KindTransaction || res.TransactionID != tt.input.TransactionID || res.AccountID != tt.input.AccountID {
+				t.Errorf("Expected entry of transaction %s got %v", tt.input.TransactionID, res)
+			}
+			if !reflect.DeepEqual(res.Postings, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res.Postings)
+			}
+			if err := res.Valid(); err != nil {
+				t.Errorf(`Expected valid entry got "%s"`, err)
+			}
+		})
+	}
+}
+
+func TestNewInstallmentsEntry(t *testing.T) {
+
+	purchase := modelTransactions.Transaction{TransactionID: "parent", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-100")}
+	installments := []modelTransactions.Transaction{
+		{TransactionID: "1", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-50")},
+		{TransactionID: "2", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-50")},
+	}
+
+	entry := NewInstallmentsEntry(purchase, installments)
+
+	expected := []Posting{
+		{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "1", Currency: "BRL", Debit: modelMoney.MustParse("50")},
+		{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "2", Currency: "BRL", Debit: modelMoney.MustParse("50")},
+		{AccountID: "id", Ledger: LedgerCash, TransactionID: "parent", Currency: "BRL", Credit: modelMoney.MustParse("100")},
+	}
+
+	if entry.Kind != KindTransaction || entry.TransactionID != "parent" {
+		t.Errorf("Expected entry of transaction parent got %v", entry)
+	}
+	if !reflect.DeepEqual(entry.Postings, expected) {
+		t.Errorf("Expected result %v got %v", expected, entry.Postings)
+	}
+	if err := entry.Valid(); err != nil {
+		t.Errorf(`Expected valid entry got "%s"`, err)
+	}
+}
+
+func TestSettle(t *testing.T) {
+
+	payment := modelTransactions.Transaction{TransactionID: "p", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("60")}
+	debit := modelTransactions.Transaction{TransactionID: "d", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-20")}
+
+	entry := NewDischargeEntry(payment)
+	entry.Settle(payment, debit, modelMoney.MustParse("20"))
+
+	expected := []Posting{
+		{AccountID: "id", Ledger: LedgerAccount, TransactionID: "p", Currency: "BRL", Debit: modelMoney.MustParse("20"), CounterpartTransactionID: &debit.TransactionID},
+		{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "d", Currency: "BRL", Credit: modelMoney.MustParse("20"), CounterpartTransactionID: &payment.TransactionID},
+	}
+
+	if entry.Kind != KindDischarge || entry.TransactionID != "p" {
+		t.Errorf("Expected discharge entry of payment p got %v", entry)
+	}
+	if !reflect.DeepEqual(entry.Postings, expected) {
+		t.Errorf("Expected result %v got %v", expected, entry.Postings)
+	}
+}
+
+func TestReverse(t *testing.T) {
+
+	debit := modelTransactions.Transaction{TransactionID: "d", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("-100")}
+	payment := modelTransactions.Transaction{TransactionID: "p", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("100")}
+	other := modelTransactions.Transaction{TransactionID: "o", AccountID: "id", Currency: "BRL", Amount: modelMoney.MustParse("30")}
+	compensating := modelTransactions.Transaction{TransactionID: "r", AccountID: "id", Currency: "BRL"}
+
+	p, d := "p", "d"
+
+	tests := map[string]struct {
+		original  modelTransactions.Transaction
+		reopen    *modelTransactions.Transaction
+		amount    modelMoney.Money
+		unsettled modelMoney.Money
+		expected  []Posting
+	}{
+		"should be able to reverse a debit": {
+			original: debit,
+			amount:   modelMoney.MustParse("100"),
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "r", Currency: "BRL", Debit: modelMoney.MustParse("100")},
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "d", Currency: "BRL", Credit: modelMoney.MustParse("100")},
+			},
+		},
+		"should be able to refund a debit reopening its settlement": {
+			original: debit,
+			reopen:   &payment,
+			amount:   modelMoney.MustParse("80"),
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "d", Currency: "BRL", Debit: modelMoney.MustParse("10"), CounterpartTransactionID: &p},
+				{AccountID: "id", Ledger: LedgerAccount, TransactionID: "p", Currency: "BRL", Credit: modelMoney.MustParse("10"), CounterpartTransactionID: &d},
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "r", Currency: "BRL", Debit: modelMoney.MustParse("80")},
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "d", Currency: "BRL", Credit: modelMoney.MustParse("80")},
+			},
+		},
+		"should be able to reverse a debit keeping the unsettled part as credit": {
+			original:  debit,
+			amount:    modelMoney.MustParse("100"),
+			unsettled: modelMoney.MustParse("30"),
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "r", Currency: "BRL", Debit: modelMoney.MustParse("100")},
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "d", Currency: "BRL", Credit: modelMoney.MustParse("70")},
+				{AccountID: "id", Ledger: LedgerAccount, TransactionID: "r", Currency: "BRL", Credit: modelMoney.MustParse("30")},
+			},
+		},
+		"should be able to reverse a payment": {
+			original: other,
+			amount:   modelMoney.MustParse("30"),
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerAccount, TransactionID: "o", Currency: "BRL", Debit: modelMoney.MustParse("30")},
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "r", Currency: "BRL", Credit: modelMoney.MustParse("30")},
+			},
+		},
+		"should be able to reverse a payment keeping the unsettled part as debt": {
+			original:  other,
+			amount:    modelMoney.MustParse("30"),
+			unsettled: modelMoney.MustParse("30"),
+			expected: []Posting{
+				{AccountID: "id", Ledger: LedgerReceivable, TransactionID: "r", Currency: "BRL", Debit: modelMoney.MustParse("30")},
+				{AccountID: "id", Ledger: LedgerCash, TransactionID: "r", Currency: "BRL", Credit: modelMoney.MustParse("30")},
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			entry := NewReversalEntry(KindRefund, compensating)
+
+			if tt.reopen != nil {
+				entry.Reopen(*tt.reopen, tt.original, modelMoney.MustParse("10"))
+			}
+			entry.Reverse(tt.original, compensating, tt.amount, tt.unsettled)
+
+			if entry.Kind != KindRefund || entry.TransactionID != "r" {
+				t.Errorf("Expected refund entry of transaction r got %v", entry)
+			}
+			if !reflect.DeepEqual(entry.Postings, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, entry.Postings)
+			}
+			if err := entry.Valid(); err != nil {
+				t.Errorf(`Expected valid entry got "%s"`, err)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := map[string]struct {
+		input Entry
+		err   error
+	}{
+		"should be able to validate balanced entry": {
+			input: Entry{Postings: []Posting{
+				{Currency: "BRL", Debit: modelMoney.MustParse("10")},
+				{Currency: "BRL", Credit: modelMoney.MustParse("4")},
+				{Currency: "BRL", Credit: modelMoney.MustParse("6")},
+			}},
+		},
+		"should not be able to validate empty entry": {
+			input: Entry{},
+			err:   fmt.Errorf("ledger entry empty"),
+		},
+		"should not be able to validate posting with both sides": {
+			input: Entry{Postings: []Posting{
+				{Currency: "BRL", Debit: modelMoney.MustParse("10"), Credit: modelMoney.MustParse("10")},
+			}},
+			err: fmt.Errorf("ledger posting invalid"),
+		},
+		"should not be able to validate posting with zero amount": {
+			input: Entry{Postings: []Posting{{Currency: "BRL"}}},
+			err:   fmt.Errorf("ledger posting invalid"),
+		},
+		"should not be able to validate unbalanced entry": {
+			input: Entry{Postings: []Posting{
+				{Currency: "BRL", Debit: modelMoney.MustParse("10")},
+				{Currency: "BRL", Credit: modelMoney.MustParse("9.99")},
+			}},
+			err: fmt.Errorf("ledger entry unbalanced"),
+		},
+		"should not be able to validate entry balanced across currencies": {
+			input: Entry{Postings: []Posting{
+				{Currency: "BRL", Debit: modelMoney.MustParse("10")},
+				{Currency: "USD", Credit: modelMoney.MustParse("10")},
+			}},
+			err: fmt.Errorf("ledger entry unbalanced"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package modelMigrations
+
+import "time"
+
+// Migration is a change of the database schema. Up applies it and Down rolls it back; the checksum is the one of Up, so a
+// migration changed after it was applied is noticed.
+type Migration struct {
+	Version   int64      `db:"version" json:"version"`
+	Name      string     `db:"name" json:"name"`
+	Checksum  string     `db:"checksum" json:"checksum"`
+	AppliedAt *time.Time `db:"applied_at" json:"applied_at,omitempty"`
+	Up        string     `db:"-" json:"-"`
+	Down      string     `db:"-" json:"-"`
+}
+
+// Applied reports whether the migration is recorded as applied in the database.
+func (m Migration) Applied() bool {
+	return m.AppliedAt != nil
+}
@@ -0,0 +1,56 @@
+package modelMoney
+
+import (
+	"math/big"
+	"strings"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+)
+
+const DefaultCurrency = "BRL"
+
+// ErrCurrencyInvalid is a currency that is not an active ISO 4217 code with 2 decimal places.
+var ErrCurrencyInvalid = modelErrors.Validation("CURRENCY_INVALID", "currency invalid")
+
+// currencies holds the active ISO 4217 alphabetic codes of the currencies with 2 decimal places, the only ones Money, kept
+// in cents, holds exactly. Currencies without decimals, like JPY, or with 3, like KWD, are left out.
+var currencies = toSet(`AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BRL BSD BTN BWP BYN BZD
+CAD CDF CHF CNY COP CRC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ
+GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD MDL MGA
+MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR
+RON RSD RUB SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD
+TWD TZS UAH USD UYU UZS VES WST XCD YER ZAR ZMW ZWL`)
+
+// CleanCurrency trims and upper cases a currency code.
+func CleanCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidCurrency reports whether code is an active ISO 4217 currency with 2 decimal places.
+func ValidCurrency(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+// Convert multiplies the amount by an exchange rate, rounding half to even to the cent.
+func (m Money) Convert(rate *big.Rat) Money {
+
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), rate)
+
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+
+	doubled := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if cmp := doubled.Cmp(value.Denom()); cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+
+	return Money(quotient.Int64())
+}
+
+func toSet(codes string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, code := range strings.Fields(codes) {
+		set[code] = struct{}{}
+	}
+	return set
+}
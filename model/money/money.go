@@ -0,0 +1,184 @@
+package modelMoney
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const scale = 100
+
+// Max is the largest amount a NUMERIC(15,2) column holds, 9999999999999.99.
+const Max Money = 1e15 - 1
+
+// Money is an exact amount in minor units (cents). It is read and written as a decimal with two places, in JSON,
+// query params and NUMERIC columns, so sums never accumulate float rounding errors.
+type Money int64
+
+func FromCents(cents int64) Money {
+	return Money(cents)
+}
+
+// Parse reads a decimal such as "10", "-0.3" or "10.50". More than two decimal places is an error, never rounded,
+// and so is an amount beyond Max.
+func Parse(value string) (Money, error) {
+
+	value = strings.TrimSpace(value)
+
+	negative := strings.HasPrefix(value, "-")
+	if negative || strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+
+	units, cents, hasCents := strings.Cut(value, ".")
+	if units == "" && cents == "" {
+		return 0, fmt.Errorf("money %q invalid", value)
+	}
+
+	if hasCents && (cents == "" || len(cents) > 2) {
+		return 0, fmt.Errorf("money %q invalid", value)
+	}
+
+	if units == "" {
+		units = "0"
+	}
+
+	cents = (cents + "00")[:2]
+
+	if !isDigits(units) || !isDigits(cents) {
+		return 0, fmt.Errorf("money %q invalid", value)
+	}
+
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil || u > int64(Max/scale) {
+		return 0, fmt.Errorf("money %q invalid", value)
+	}
+
+	c, _ := strconv.ParseInt(cents, 10, 64)
+
+	m := Money(u*scale + c)
+	if negative {
+		m = -m
+	}
+
+	return m, nil
+}
+
+func MustParse(value string) Money {
+	m, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m Money) Cents() int64 {
+	return int64(m)
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Split divides the amount in n parts adding up to it, the first ones a cent larger when it does not divide evenly.
+func (m Money) Split(n int) []Money {
+
+	if n <= 0 {
+		return nil
+	}
+
+	sign, abs := Money(1), m
+	if m < 0 {
+		sign, abs = -1, -m
+	}
+
+	quotient, remainder := abs/Money(n), abs%Money(n)
+
+	parts := make([]Money, n)
+	for i := range parts {
+		parts[i] = quotient
+		if Money(i) < remainder {
+			parts[i]++
+		}
+		parts[i] *= sign
+	}
+
+	return parts
+}
+
+func (m Money) String() string {
+
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/scale, cents%scale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts numbers and strings, reading the literal digits so 0.1 is exactly ten cents.
+func (m *Money) UnmarshalJSON(data []byte) error {
+
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		return nil
+	}
+
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam binds query and path params.
+func (m *Money) UnmarshalParam(param string) error {
+	return m.UnmarshalJSON([]byte(param))
+}
+
+func (m *Money) Scan(src interface{}) error {
+
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.UnmarshalJSON(value)
+	case string:
+		return m.UnmarshalJSON([]byte(value))
+	case int64:
+		*m = Money(value * scale)
+	case float64:
+		*m = Money(math.Round(value * scale))
+	default:
+		return fmt.Errorf("cannot scan %T into money", src)
+	}
+
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -0,0 +1,207 @@
+package modelMoney
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected Money
+		err      error
+	}{
+		"should be able to parse units":             {input: "10", expected: 1000},
+		"should be able to parse one decimal place": {input: "0.1", expected: 10},
+		"should be able to parse two decimal place": {input: "10.55", expected: 1055},
+		"should be able to parse negative":          {input: "-0.30", expected: -30},
+		"should be able to parse without units":     {input: ".5", expected: 50},
+		"should not be able to parse three decimal places": {
+			input: "0.001",
+			err:   fmt.Errorf(`money "0.001" invalid`),
+		},
+		"should not be able to parse letters": {
+			input: "1a",
+			err:   fmt.Errorf(`money "1a" invalid`),
+		},
+		"should not be able to parse empty": {
+			input: "",
+			err:   fmt.Errorf(`money "" invalid`),
+		},
+		"should be able to parse max": {
+			input:    "9999999999999.99",
+			expected: Max,
+		},
+		"should not be able to parse beyond max": {
+			input: "-10000000000000",
+			err:   fmt.Errorf(`money "10000000000000" invalid`),
+		},
+		"should not be able to parse more than one sign": {
+			input: "-+5",
+			err:   fmt.Errorf(`money "+5" invalid`),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res, err := Parse(tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[Money]string{
+		0:     "0.00",
+		5:     "0.05",
+		-30:   "-0.30",
+		1055:  "10.55",
+		-1000: "-10.00",
+	}
+
+	for input, expected := range tests {
+		if res := input.String(); res != expected {
+			t.Errorf("Expected result %v got %v", expected, res)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+
+	var m Money
+
+	if err := m.UnmarshalJSON([]byte(`0.1`)); err != nil || m != 10 {
+		t.Errorf("Expected 10 cents got %d (%v)", m, err)
+	}
+
+	if err := m.UnmarshalJSON([]byte(`"12.34"`)); err != nil || m != 1234 {
+		t.Errorf("Expected 1234 cents got %d (%v)", m, err)
+	}
+
+	if res, _ := Money(-1234).MarshalJSON(); string(res) != "-12.34" {
+		t.Errorf("Expected -12.34 got %s", res)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := map[string]struct {
+		input    interface{}
+		expected Money
+	}{
+		"should be able to scan numeric":   {input: []byte("-23.50"), expected: -2350},
+		"should be able to scan string":    {input: "0.30", expected: 30},
+		"should be able to scan integer":   {input: int64(-60), expected: -6000},
+		"should be able to scan float":     {input: 0.1 + 0.2, expected: 30},
+		"should be able to scan null":      {input: nil, expected: 0},
+		"should be able to scan aggregate": {input: []byte("0"), expected: 0},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			var m Money
+			if err := m.Scan(tt.input); err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if m != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, m)
+			}
+		})
+	}
+}
+
+func TestSumIsExact(t *testing.T) {
+
+	var sum Money
+	for i := 0; i < 10; i++ {
+		sum += MustParse("0.1")
+	}
+
+	if sum != MustParse("1") {
+		t.Errorf("Expected 1.00 got %s", sum)
+	}
+}
+
+func TestValidCurrency(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected bool
+	}{
+		"should be able to validate currency":                  {input: CleanCurrency(" usd "), expected: true},
+		"should not be able to validate unknown code":          {input: "XXX"},
+		"should not be able to validate lower case code":       {input: "brl"},
+		"should not be able to validate empty code":            {input: ""},
+		"should not be able to validate code without decimals": {input: "JPY"},
+		"should not be able to validate code with 3 decimals":  {input: "KWD"},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := ValidCurrency(tt.input); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		input    Money
+		rate     string
+		expected Money
+	}{
+		"should be able to convert":                       {input: MustParse("10"), rate: "4.9512", expected: MustParse("49.51")},
+		"should be able to convert rounding half to even": {input: MustParse("0.05"), rate: "0.5", expected: MustParse("0.02")},
+		"should be able to convert negative":              {input: MustParse("-0.15"), rate: "0.5", expected: MustParse("-0.08")},
+		"should be able to convert with identity rate":    {input: MustParse("12.34"), rate: "1", expected: MustParse("12.34")},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			rate, _ := new(big.Rat).SetString(tt.rate)
+
+			if res := tt.input.Convert(rate); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		input    Money
+		parts    int
+		expected []Money
+	}{
+		"should be able to split evenly":               {input: MustParse("30"), parts: 3, expected: []Money{MustParse("10"), MustParse("10"), MustParse("10")}},
+		"should be able to split with the cents first": {input: MustParse("100"), parts: 3, expected: []Money{MustParse("33.34"), MustParse("33.33"), MustParse("33.33")}},
+		"should be able to split negative":             {input: MustParse("-0.05"), parts: 2, expected: []Money{MustParse("-0.03"), MustParse("-0.02")}},
+		"should not be able to split in no parts":      {input: MustParse("10"), parts: 0},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := tt.input.Split(tt.parts)
+
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -1,7 +1,122 @@
 package modelOperaTionsType
 
+import (
+	"strings"
+	"time"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+)
+
+// Errors of a request refused by validation.
+var (
+	ErrDescriptionInvalid = modelErrors.Validation("DESCRIPTION_INVALID", "description invalid")
+	ErrOperationInvalid   = modelErrors.Validation("OPERATION_INVALID", "operation invalid")
+	ErrPayloadInvalid     = modelErrors.Validation("PAYLOAD_INVALID", "payload invalid")
+)
+
+// InstallmentPurchaseID is the operation type of purchases split in installments.
+const InstallmentPurchaseID = 2
+
+// Operation types of the compensating transactions made by reversals and refunds. Their operation is 0, they cannot be
+// made directly.
+const (
+	ReversalID = 5
+	RefundID   = 6
+)
+
+// Operation types of the interest and late fees charged on overdue debits. They are debits made only by the service, and
+// stay off the credit limit: charging them does not take from it and paying them does not give back to it.
+const (
+	InterestID = 7
+	LateFeeID  = 8
+)
+
+// Defaults are the operation types every environment has, also created by the migrations.
+var Defaults = []OperationType{
+	{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+	{OperationTypeID: InstallmentPurchaseID, Description: "COMPRA PARCELADA", Operation: -1},
+	{OperationTypeID: 3, Description: "SAQUE", Operation: -1},
+	{OperationTypeID: 4, Description: "PAGAMENTO", Operation: 1},
+	{OperationTypeID: ReversalID, Description: "ESTORNO", Operation: 0},
+	{OperationTypeID: RefundID, Description: "REEMBOLSO", Operation: 0},
+	{OperationTypeID: InterestID, Description: "JUROS", Operation: -1},
+	{OperationTypeID: LateFeeID, Description: "MULTA", Operation: -1},
+}
+
 type OperationType struct {
-	OperationTypeID int    `db:"operation_type_id" json:"operation_type_id"`
-	Description     string `db:"description" json:"description"`
-	Operation       int    `db:"operation" json:"operation"`
+	OperationTypeID int        `db:"operation_type_id" json:"operation_type_id"`
+	Description     string     `db:"description" json:"description"`
+	Operation       int        `db:"operation" json:"operation"`
+	DeactivatedAt   *time.Time `db:"deactivated_at" json:"deactivated_at,omitempty"`
+}
+
+type Create struct {
+	Description string `db:"description" json:"description"`
+	Operation   int    `db:"operation" json:"operation"`
+}
+
+type Update struct {
+	OperationTypeID int     `db:"operation_type_id" param:"operation_type_id" json:"-" swaggerignore:"true"`
+	Description     *string `db:"description" json:"description"`
+	Operation       *int    `db:"operation" json:"operation"`
+}
+
+// Active reports whether transactions can still be made with the operation type.
+func (o OperationType) Active() bool {
+	return o.DeactivatedAt == nil
+}
+
+// System reports whether the operation type is only used by the service itself, such as reversals and charges.
+func (o OperationType) System() bool {
+	return o.Operation == 0 || Charge(o.OperationTypeID)
+}
+
+// Charge reports whether the operation type is one of the charges on overdue debits, interest or late fee.
+func Charge(operationTypeID int) bool {
+	return operationTypeID == InterestID || operationTypeID == LateFeeID
+}
+
+func (c *Create) Valid() error {
+
+	c.Description = CleanDescription(c.Description)
+
+	if c.Description == "" {
+		return ErrDescriptionInvalid
+	}
+
+	if !validOperation(c.Operation) {
+		return ErrOperationInvalid
+	}
+
+	return nil
+}
+
+func (u *Update) Valid() error {
+
+	if u.Description == nil && u.Operation == nil {
+		return ErrPayloadInvalid
+	}
+
+	if u.Description != nil {
+		description := CleanDescription(*u.Description)
+		if description == "" {
+			return ErrDescriptionInvalid
+		}
+		u.Description = &description
+	}
+
+	if u.Operation != nil && !validOperation(*u.Operation) {
+		return ErrOperationInvalid
+	}
+
+	return nil
+}
+
+func CleanDescription(description string) string {
+	return strings.ToUpper(strings.TrimSpace(description))
+}
+
+// validOperation accepts the sign of debits, -1, and of credits, 1.
+func validOperation(operation int) bool {
+	return operation == -1 || operation == 1
 }
@@ -0,0 +1,117 @@
+package modelOperaTionsType
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCreateValid(t *testing.T) {
+	tests := map[string]struct {
+		input    Create
+		expected string
+		err      error
+	}{
+		"should be able to validate operation type": {
+			input:    Create{Description: " tarifa ", Operation: -1},
+			expected: "TARIFA",
+		},
+		"should not be able to validate operation type with error description invalid": {
+			input: Create{Description: " ", Operation: 1},
+			err:   fmt.Errorf("description invalid"),
+		},
+		"should not be able to validate operation type with error operation invalid": {
+			input: Create{Description: "TARIFA", Operation: 2},
+			err:   fmt.Errorf("operation invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.input.Description != tt.expected {
+				t.Errorf("Expected description %s got %s", tt.expected, tt.input.Description)
+			}
+		})
+	}
+}
+
+func TestUpdateValid(t *testing.T) {
+
+	description := "tarifa"
+	blank := " "
+	debit := -1
+	zero := 0
+
+	tests := map[string]struct {
+		input Update
+		err   error
+	}{
+		"should be able to validate operation type description": {
+			input: Update{Description: &description},
+		},
+		"should be able to validate operation type operation": {
+			input: Update{Operation: &debit},
+		},
+		"should not be able to validate operation type without changes": {
+			input: Update{},
+			err:   fmt.Errorf("payload invalid"),
+		},
+		"should not be able to validate operation type with error description invalid": {
+			input: Update{Description: &blank},
+			err:   fmt.Errorf("description invalid"),
+		},
+		"should not be able to validate operation type with error operation invalid": {
+			input: Update{Operation: &zero},
+			err:   fmt.Errorf("operation invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSystem(t *testing.T) {
+
+	tests := map[string]struct {
+		input    OperationType
+		expected bool
+	}{
+		"should be able to report a debit as not system": {
+			input: OperationType{OperationTypeID: 1, Operation: -1},
+		},
+		"should be able to report a reversal as system": {
+			input:    OperationType{OperationTypeID: ReversalID, Operation: 0},
+			expected: true,
+		},
+		"should be able to report interest as system": {
+			input:    OperationType{OperationTypeID: InterestID, Operation: -1},
+			expected: true,
+		},
+		"should be able to report a late fee as system": {
+			input:    OperationType{OperationTypeID: LateFeeID, Operation: -1},
+			expected: true,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := tt.input.System(); res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
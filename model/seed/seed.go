@@ -0,0 +1,42 @@
+package modelSeed
+
+import (
+	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+// Accounts are the demo accounts created by the seed, one for each document type.
+var Accounts = []modelAccounts.Create{
+	{
+		DocumentNumber:       "52998224725",
+		DocumentType:         modelAccounts.DocumentTypeCPF,
+		AvailableCreditLimit: limit("5000"),
+		Currency:             "BRL",
+		StatementClosingDay:  10,
+	},
+	{
+		DocumentNumber:       "12345678909",
+		DocumentType:         modelAccounts.DocumentTypeCPF,
+		AvailableCreditLimit: limit("1500"),
+		Currency:             "BRL",
+		StatementClosingDay:  20,
+	},
+	{
+		DocumentNumber:       "11222333000181",
+		DocumentType:         modelAccounts.DocumentTypeCNPJ,
+		AvailableCreditLimit: limit("20000"),
+		Currency:             "BRL",
+		StatementClosingDay:  1,
+	},
+}
+
+func limit(value string) *modelMoney.Money {
+	limit := modelMoney.MustParse(value)
+	return &limit
+}
+
+// Result tells how many operation types and accounts the seed created. The ones already there are not counted.
+type Result struct {
+	OperationTypes int `json:"operation_types"`
+	Accounts       int `json:"accounts"`
+}
@@ -0,0 +1,18 @@
+package modelSeed
+
+import "testing"
+
+func TestAccounts(t *testing.T) {
+
+	documents := map[string]bool{}
+
+	for _, account := range Accounts {
+		if err := account.Valid(); err != nil {
+			t.Errorf(`Expected account %s valid got "%s"`, account.DocumentNumber, err)
+		}
+		if documents[account.DocumentNumber] {
+			t.Errorf("Expected account %s once", account.DocumentNumber)
+		}
+		documents[account.DocumentNumber] = true
+	}
+}
@@ -0,0 +1,141 @@
+package modelStatements
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+const (
+	// DefaultClosingDay is the statement closing day of accounts created without one.
+	DefaultClosingDay = 1
+	// MaxClosingDay keeps every closing day in every month.
+	MaxClosingDay = 28
+	// DueDays is how long after the closing date a statement is due.
+	DueDays = 10
+)
+
+// MinimumPaymentRate is the part of the closing balance due by the due date.
+var MinimumPaymentRate = big.NewRat(15, 100)
+
+// Statement is the billing cycle of an account in one currency. It covers the transactions from PeriodStart, or from the
+// first one when the account had no statement before, until ClosingDate, exclusive. Installments count in the cycle they
+// are due, and balances are what the cardholder owes, negative when they are in credit.
+type Statement struct {
+	StatementID    string           `db:"statement_id" json:"statement_id"`
+	AccountID      string           `db:"account_id" json:"account_id"`
+	Currency       string           `db:"currency" json:"currency"`
+	PeriodStart    *time.Time       `db:"period_start" json:"period_start,omitempty"`
+	ClosingDate    time.Time        `db:"closing_date" json:"closing_date"`
+	OpeningBalance modelMoney.Money `db:"opening_balance" json:"opening_balance"`
+	Purchases      modelMoney.Money `db:"purchases" json:"purchases"`
+	Payments       modelMoney.Money `db:"payments" json:"payments"`
+	ClosingBalance modelMoney.Money `db:"closing_balance" json:"closing_balance"`
+	MinimumPayment modelMoney.Money `db:"minimum_payment" json:"minimum_payment"`
+	DueDate        time.Time        `db:"due_date" json:"due_date"`
+	CreatedAt      time.Time        `db:"created_at" json:"created_at"`
+}
+
+// Movement sums the transactions of a cycle in one currency: Purchases are the debits and Payments the credits, which
+// include reversals and refunds.
+type Movement struct {
+	Currency  string           `db:"currency"`
+	Purchases modelMoney.Money `db:"purchases"`
+	Payments  modelMoney.Money `db:"payments"`
+}
+
+type AccountStatements struct {
+	AccountID  string      `json:"account_id"`
+	Statements []Statement `json:"statements"`
+}
+
+type CloseResult struct {
+	ClosingDate time.Time `json:"closing_date"`
+	Accounts    int       `json:"accounts"`
+	Statements  int       `json:"statements"`
+	Failed      int       `json:"failed"`
+}
+
+func ValidClosingDay(day int) bool {
+	return day >= 1 && day <= MaxClosingDay
+}
+
+// ClosingDate is the start of the day of date, in UTC. A cycle closed at it covers the transactions made before that day.
+func ClosingDate(date time.Time) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NewCycle closes the cycle of the account at the closing date, one statement per currency that has movement or a balance
+// carried from the previous statements, in the order of the currencies. The previous statements are the ones of the last
+// cycle closed, and the movements the ones since its closing date.
+func NewCycle(accountID string, closingDate time.Time, previous []Statement, movements []Movement) []Statement {
+
+	var periodStart *time.Time
+
+	byCurrency := map[string]*Statement{}
+
+	statement := func(currency string) *Statement {
+		if s, ok := byCurrency[currency]; ok {
+			return s
+		}
+		byCurrency[currency] = &Statement{
+			AccountID:   accountID,
+			Currency:    currency,
+			PeriodStart: periodStart,
+			ClosingDate: closingDate,
+			DueDate:     closingDate.AddDate(0, 0, DueDays),
+		}
+		return byCurrency[currency]
+	}
+
+	if len(previous) > 0 {
+		start := previous[0].ClosingDate
+		periodStart = &start
+	}
+
+	for _, p := range previous {
+		if p.ClosingBalance != 0 {
+			statement(p.Currency).OpeningBalance = p.ClosingBalance
+		}
+	}
+
+	for _, m := range movements {
+		if m.Purchases != 0 || m.Payments != 0 {
+			s := statement(m.Currency)
+			s.Purchases = m.Purchases
+			s.Payments = m.Payments
+		}
+	}
+
+	statements := make([]Statement, 0, len(byCurrency))
+
+	for _, s := range byCurrency {
+		s.ClosingBalance = s.OpeningBalance + s.Purchases - s.Payments
+		s.MinimumPayment = MinimumPayment(s.ClosingBalance)
+		statements = append(statements, *s)
+	}
+
+	sort.Slice(statements, func(i, j int) bool {
+		return statements[i].Currency < statements[j].Currency
+	})
+
+	return statements
+}
+
+// MinimumPayment is MinimumPaymentRate of what is owed, or all of it when that rounds to zero.
+func MinimumPayment(closingBalance modelMoney.Money) modelMoney.Money {
+
+	if closingBalance <= 0 {
+		return 0
+	}
+
+	minimum := closingBalance.Convert(MinimumPaymentRate)
+	if minimum == 0 {
+		return closingBalance
+	}
+
+	return minimum
+}
@@ -0,0 +1,147 @@
+package modelStatements
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+func TestClosingDate(t *testing.T) {
+
+	tests := map[string]struct {
+		input    time.Time
+		expected time.Time
+	}{
+		"should be able to get the start of the day": {
+			input:    time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		"should be able to get the start of the day in UTC": {
+			input:    time.Date(2024, 1, 10, 22, 0, 0, 0, time.FixedZone("BRT", -3*60*60)),
+			expected: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := ClosingDate(tt.input)
+
+			if !res.Equal(tt.expected) || res.Location() != time.UTC {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestMinimumPayment(t *testing.T) {
+
+	tests := map[string]struct {
+		input    modelMoney.Money
+		expected modelMoney.Money
+	}{
+		"should be able to get the minimum payment": {
+			input:    modelMoney.MustParse("100"),
+			expected: modelMoney.MustParse("15"),
+		},
+		"should be able to get the minimum payment rounded to the cent": {
+			input:    modelMoney.MustParse("10.01"),
+			expected: modelMoney.MustParse("1.50"),
+		},
+		"should be able to get the whole balance when the minimum payment rounds to zero": {
+			input:    modelMoney.MustParse("0.03"),
+			expected: modelMoney.MustParse("0.03"),
+		},
+		"should be able to get no minimum payment of a balance in credit": {
+			input:    modelMoney.MustParse("-10"),
+			expected: 0,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := MinimumPayment(tt.input)
+
+			if res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestNewCycle(t *testing.T) {
+
+	previousClosing := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	closing := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		previous  []Statement
+		movements []Movement
+		expected  []Statement
+	}{
+		"should be able to close the first cycle": {
+			movements: []Movement{{Currency: "BRL", Purchases: modelMoney.MustParse("100"), Payments: modelMoney.MustParse("30")}},
+			expected: []Statement{
+				{
+					AccountID:      "id",
+					Currency:       "BRL",
+					ClosingDate:    closing,
+					Purchases:      modelMoney.MustParse("100"),
+					Payments:       modelMoney.MustParse("30"),
+					ClosingBalance: modelMoney.MustParse("70"),
+					MinimumPayment: modelMoney.MustParse("10.50"),
+					DueDate:        due,
+				},
+			},
+		},
+		"should be able to close a cycle carrying the previous balances": {
+			previous: []Statement{
+				{AccountID: "id", Currency: "BRL", ClosingDate: previousClosing, ClosingBalance: modelMoney.MustParse("70")},
+				{AccountID: "id", Currency: "USD", ClosingDate: previousClosing, ClosingBalance: modelMoney.MustParse("20")},
+				{AccountID: "id", Currency: "EUR", ClosingDate: previousClosing},
+			},
+			movements: []Movement{{Currency: "BRL", Payments: modelMoney.MustParse("100")}},
+			expected: []Statement{
+				{
+					AccountID:      "id",
+					Currency:       "BRL",
+					PeriodStart:    &previousClosing,
+					ClosingDate:    closing,
+					OpeningBalance: modelMoney.MustParse("70"),
+					Payments:       modelMoney.MustParse("100"),
+					ClosingBalance: modelMoney.MustParse("-30"),
+					DueDate:        due,
+				},
+				{
+					AccountID:      "id",
+					Currency:       "USD",
+					PeriodStart:    &previousClosing,
+					ClosingDate:    closing,
+					OpeningBalance: modelMoney.MustParse("20"),
+					ClosingBalance: modelMoney.MustParse("20"),
+					MinimumPayment: modelMoney.MustParse("3"),
+					DueDate:        due,
+				},
+			},
+		},
+		"should be able to close a cycle without balances or movement": {
+			previous: []Statement{{AccountID: "id", Currency: "BRL", ClosingDate: previousClosing}},
+			expected: []Statement{},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res := NewCycle("id", closing, tt.previous, tt.movements)
+
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -1,37 +1,342 @@
 package modelTransactions
 
 import (
+	"encoding/base64"
 	"fmt"
+	"math/big"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+	MaxInstallments  = 24
+)
+
+// Errors of a request refused by validation.
+var (
+	ErrAmountInvalid       = modelErrors.Validation("AMOUNT_INVALID", "amount invalid")
+	ErrInstallmentsInvalid = modelErrors.Validation("INSTALLMENTS_INVALID", "installments invalid")
+	ErrLimitInvalid        = modelErrors.Validation("LIMIT_INVALID", "limit invalid")
+	ErrAmountRangeInvalid  = modelErrors.Validation("AMOUNT_RANGE_INVALID", "amount range invalid")
+	ErrDateRangeInvalid    = modelErrors.Validation("DATE_RANGE_INVALID", "date range invalid")
+	ErrCursorInvalid       = modelErrors.Validation("CURSOR_INVALID", "cursor invalid")
 )
 
 type Transaction struct {
-	TransactionID   string    `db:"transaction_id"`
-	AccountID       string    `db:"account_id"`
-	OperationTypeID int       `db:"operation_type_id"`
-	Amount          float64   `db:"amount"`
-	Balance         float64   `db:"balance"`
-	EventDate       time.Time `db:"event_date"`
+	TransactionID         string            `db:"transaction_id" json:"transaction_id"`
+	AccountID             string            `db:"account_id" json:"account_id"`
+	OperationTypeID       int               `db:"operation_type_id" json:"operation_type_id"`
+	Amount                modelMoney.Money  `db:"amount" json:"amount"`
+	Balance               modelMoney.Money  `db:"balance" json:"balance"`
+	Currency              string            `db:"currency" json:"currency"`
+	OriginalAmount        *modelMoney.Money `db:"original_amount" json:"original_amount,omitempty"`
+	OriginalCurrency      *string           `db:"original_currency" json:"original_currency,omitempty"`
+	ReversedTransactionID *string           `db:"reversed_transaction_id" json:"reversed_transaction_id,omitempty"`
+	Installments          *int              `db:"installments" json:"installments,omitempty"`
+	ParentTransactionID   *string           `db:"parent_transaction_id" json:"parent_transaction_id,omitempty"`
+	InstallmentNumber     *int              `db:"installment_number" json:"installment_number,omitempty"`
+	DueDate               *time.Time        `db:"due_date" json:"due_date,omitempty"`
+	EventDate             time.Time         `db:"event_date" json:"event_date"`
+
+	// LimitAmount is the amount, in the account currency, the transaction took from the available credit limit. It is nil
+	// on the transactions made before it was kept.
+	LimitAmount *modelMoney.Money `db:"limit_amount" json:"-"`
 }
 
 type MakeTransaction struct {
-	AccountID       string  `json:"account_id" db:"account_id"`
-	OperationTypeID int     `json:"operation_type_id" db:"operation_type_id"`
-	Amount          float64 `json:"amount" db:"amount"`
+	AccountID       string           `json:"account_id" db:"account_id"`
+	OperationTypeID int              `json:"operation_type_id" db:"operation_type_id"`
+	Amount          modelMoney.Money `json:"amount" db:"amount"`
+	Currency        string           `json:"currency" db:"currency"`
+
+	// Installments splits a COMPRA PARCELADA in monthly installments, the first one due at the purchase.
+	Installments int `json:"installments" db:"installments"`
+
+	// ParentTransactionID, InstallmentNumber and DueDate are set on the installments of a purchase.
+	ParentTransactionID *string    `json:"-" db:"parent_transaction_id"`
+	InstallmentNumber   int        `json:"-" db:"installment_number"`
+	DueDate             *time.Time `json:"-" db:"due_date"`
+
+	// OriginalAmount and OriginalCurrency keep what was paid when the amount was converted into the account currency.
+	OriginalAmount   *modelMoney.Money `json:"-" db:"original_amount"`
+	OriginalCurrency *string           `json:"-" db:"original_currency"`
+
+	// LimitAmount is the amount, in the account currency, taken from the available credit limit. The installments of a
+	// purchase take it in their shares, the purchase itself takes none.
+	LimitAmount modelMoney.Money `json:"-" db:"limit_amount"`
+
+	// ReversedTransactionID is set on the compensating transactions made by reversals and refunds.
+	ReversedTransactionID *string `json:"-" db:"reversed_transaction_id"`
+}
+
+type FutureInstallments struct {
+	AccountID    string        `json:"account_id"`
+	Installments []Transaction `json:"installments"`
+}
+
+type Refund struct {
+	TransactionID string           `param:"transaction_id" json:"-" swaggerignore:"true"`
+	Amount        modelMoney.Money `json:"amount"`
+}
+
+type ListFilter struct {
+	AccountID       string           `param:"account_id"`
+	OperationTypeID int              `query:"operation_type_id"`
+	StartDate       time.Time        `query:"start_date"`
+	EndDate         time.Time        `query:"end_date"`
+	MinAmount       modelMoney.Money `query:"min_amount"`
+	MaxAmount       modelMoney.Money `query:"max_amount"`
+	OpenBalanceOnly bool             `query:"open_balance_only"`
+	Cursor          string           `query:"cursor"`
+	Limit           int              `query:"limit"`
+	After           *Cursor
+}
+
+type TransactionsPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+type BalanceSummary struct {
+	AccountID       string                 `json:"account_id"`
+	Currency        string                 `json:"currency"`
+	OutstandingDebt modelMoney.Money       `json:"outstanding_debt"`
+	UnappliedCredit modelMoney.Money       `json:"unapplied_credit"`
+	Balance         modelMoney.Money       `json:"balance"`
+	OtherCurrencies []CurrencyBalance      `json:"other_currencies,omitempty"`
+	OperationsType  []OperationTypeBalance `json:"operations_type"`
+}
+
+// ReconcileResult tells how many open credits of the account were applied again to its open debits.
+type ReconcileResult struct {
+	AccountID string `json:"account_id"`
+	Credits   int    `json:"credits"`
+}
+
+type CurrencyBalance struct {
+	Currency        string           `json:"currency"`
+	OutstandingDebt modelMoney.Money `json:"outstanding_debt"`
+	UnappliedCredit modelMoney.Money `json:"unapplied_credit"`
+	Balance         modelMoney.Money `json:"balance"`
+}
+
+type OperationTypeBalance struct {
+	OperationTypeID  int              `db:"operation_type_id" json:"operation_type_id"`
+	Description      string           `db:"description" json:"description"`
+	Currency         string           `db:"currency" json:"currency"`
+	OutstandingDebt  modelMoney.Money `db:"outstanding_debt" json:"outstanding_debt"`
+	UnappliedCredit  modelMoney.Money `db:"unapplied_credit" json:"unapplied_credit"`
+	OpenTransactions int              `db:"open_transactions" json:"open_transactions"`
+}
+
+type Cursor struct {
+	EventDate     time.Time
+	TransactionID string
 }
 
 func (dt *MakeTransaction) SetOperationInAmount(operation int) error {
 
-	dt.Amount = dt.Amount * float64(operation)
+	dt.Amount = dt.Amount * modelMoney.Money(operation)
 
 	return nil
 }
 
 func (dt *MakeTransaction) ValidateAmount() error {
 
-	if dt.Amount <= 0 {
-		return fmt.Errorf("amount invalid")
+	if dt.Amount <= 0 || dt.Amount > modelMoney.Max {
+		return ErrAmountInvalid
+	}
+
+	return nil
+}
+
+func (dt *MakeTransaction) ValidateInstallments() error {
+
+	if dt.Installments < 0 || dt.Installments > MaxInstallments {
+		return ErrInstallmentsInvalid
+	}
+
+	return nil
+}
+
+// NewInstallments splits the purchase, and the limit it takes, in count installments, due monthly from the purchase date.
+// Cents that do not divide evenly go to the first installments.
+func NewInstallments(purchase Transaction, count int, limit modelMoney.Money) []MakeTransaction {
+
+	installments := make([]MakeTransaction, count)
+	limits := limit.Split(count)
+
+	for i, amount := range purchase.Amount.Split(count) {
+
+		dueDate := addMonths(purchase.EventDate, i)
+
+		installments[i] = MakeTransaction{
+			AccountID:           purchase.AccountID,
+			OperationTypeID:     purchase.OperationTypeID,
+			Amount:              amount,
+			Currency:            purchase.Currency,
+			LimitAmount:         limits[i],
+			ParentTransactionID: &purchase.TransactionID,
+			InstallmentNumber:   i + 1,
+			DueDate:             &dueDate,
+		}
+	}
+
+	return installments
+}
+
+// LimitShare returns the part of the limit taken by the transaction that goes back when amount more of it is settled, or,
+// when amount is negative, that is taken again when it is reopened. Shares follow what is settled so far, read from the
+// open balance, so a transaction settled in parts gives back exactly the limit it took. It is 0 when LimitAmount is nil.
+func (t Transaction) LimitShare(amount modelMoney.Money) modelMoney.Money {
+
+	settled := t.Amount.Abs() - t.Balance.Abs()
+
+	return t.limitSettled(settled+amount) - t.limitSettled(settled)
+}
+
+// limitSettled returns the limit taken by the part settled of the transaction, pro rata of its amount.
+func (t Transaction) limitSettled(settled modelMoney.Money) modelMoney.Money {
+
+	if t.LimitAmount == nil || t.Amount == 0 {
+		return 0
+	}
+
+	return t.LimitAmount.Abs().Convert(big.NewRat(settled.Cents(), t.Amount.Abs().Cents()))
+}
+
+// addMonths keeps the day of the month, moving it to the last day of shorter months.
+func addMonths(date time.Time, months int) time.Time {
+
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+
+	day := date.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
+
+func (r Refund) Valid() error {
+
+	if r.Amount <= 0 {
+		return ErrAmountInvalid
 	}
 
 	return nil
 }
+
+func (f *ListFilter) Valid() error {
+
+	if f.Limit < 0 || f.Limit > MaxListLimit {
+		return ErrLimitInvalid
+	}
+
+	if f.Limit == 0 {
+		f.Limit = DefaultListLimit
+	}
+
+	if f.MinAmount < 0 || f.MaxAmount < 0 {
+		return ErrAmountRangeInvalid
+	}
+
+	if f.MaxAmount > 0 && f.MinAmount > f.MaxAmount {
+		return ErrAmountRangeInvalid
+	}
+
+	if !f.StartDate.IsZero() && !f.EndDate.IsZero() && f.StartDate.After(f.EndDate) {
+		return ErrDateRangeInvalid
+	}
+
+	if f.Cursor != "" {
+		cursor, err := DecodeCursor(f.Cursor)
+		if err != nil {
+			return err
+		}
+		f.After = &cursor
+	}
+
+	return nil
+}
+
+// NewBalanceSummary totals the per operation type balances of an account. Debt and credit are reported as positive amounts.
+// Amounts are never summed across currencies: the top level totals are in the account currency and the other currencies
+// are totaled apart, in the order they first appear.
+func NewBalanceSummary(accountID, currency string, operationsType []OperationTypeBalance) BalanceSummary {
+
+	summary := BalanceSummary{
+		AccountID:      accountID,
+		Currency:       currency,
+		OperationsType: []OperationTypeBalance{},
+	}
+
+	others := map[string]int{}
+
+	for _, operationType := range operationsType {
+
+		summary.OperationsType = append(summary.OperationsType, operationType)
+
+		if operationType.Currency == currency {
+			summary.OutstandingDebt += operationType.OutstandingDebt
+			summary.UnappliedCredit += operationType.UnappliedCredit
+			continue
+		}
+
+		i, ok := others[operationType.Currency]
+		if !ok {
+			i = len(summary.OtherCurrencies)
+			others[operationType.Currency] = i
+			summary.OtherCurrencies = append(summary.OtherCurrencies, CurrencyBalance{Currency: operationType.Currency})
+		}
+
+		summary.OtherCurrencies[i].OutstandingDebt += operationType.OutstandingDebt
+		summary.OtherCurrencies[i].UnappliedCredit += operationType.UnappliedCredit
+		summary.OtherCurrencies[i].Balance = summary.OtherCurrencies[i].UnappliedCredit - summary.OtherCurrencies[i].OutstandingDebt
+	}
+
+	summary.Balance = summary.UnappliedCredit - summary.OutstandingDebt
+
+	return summary
+}
+
+// Encode returns an opaque token pointing right after the transaction, to be sent back as the cursor of the next page.
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%s|%s", c.EventDate.UTC().Format(time.RFC3339Nano), c.TransactionID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(token string) (Cursor, error) {
+
+	var cursor Cursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return cursor, ErrCursorInvalid
+	}
+
+	eventDate, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	if _, err := uuid.Parse(parts[1]); err != nil {
+		return cursor, ErrCursorInvalid
+	}
+
+	cursor.EventDate = eventDate
+	cursor.TransactionID = parts[1]
+
+	return cursor, nil
+}
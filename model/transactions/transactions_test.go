@@ -0,0 +1,302 @@
+package modelTransactions
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+)
+
+func TestListFilterValid(t *testing.T) {
+
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	transactionID := "4a1c1f7e-2a55-4c8b-9d9e-0f3b5b1a2c3d"
+
+	tests := map[string]struct {
+		input    ListFilter
+		expected ListFilter
+		err      error
+	}{
+		"should be able to validate filter with default limit": {
+			input:    ListFilter{AccountID: "id"},
+			expected: ListFilter{AccountID: "id", Limit: DefaultListLimit},
+		},
+		"should be able to validate filter with cursor": {
+			input: ListFilter{AccountID: "id", Limit: 10, Cursor: Cursor{EventDate: eventDate, TransactionID: transactionID}.Encode()},
+			expected: ListFilter{
+				AccountID: "id",
+				Limit:     10,
+				Cursor:    Cursor{EventDate: eventDate, TransactionID: transactionID}.Encode(),
+				After:     &Cursor{EventDate: eventDate, TransactionID: transactionID},
+			},
+		},
+		"should not be able to validate filter with error limit invalid": {
+			input: ListFilter{Limit: MaxListLimit + 1},
+			err:   fmt.Errorf("limit invalid"),
+		},
+		"should not be able to validate filter with error amount range invalid": {
+			input: ListFilter{MinAmount: modelMoney.MustParse("10"), MaxAmount: modelMoney.MustParse("5")},
+			err:   fmt.Errorf("amount range invalid"),
+		},
+		"should not be able to validate filter with error date range invalid": {
+			input: ListFilter{StartDate: eventDate, EndDate: eventDate.Add(-time.Hour)},
+			err:   fmt.Errorf("date range invalid"),
+		},
+		"should not be able to validate filter with error cursor invalid": {
+			input: ListFilter{Cursor: "invalid"},
+			err:   fmt.Errorf("cursor invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(tt.input, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, tt.input)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 123, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected Cursor
+		err      error
+	}{
+		"should be able to decode cursor": {
+			input:    Cursor{EventDate: eventDate, TransactionID: "4a1c1f7e-2a55-4c8b-9d9e-0f3b5b1a2c3d"}.Encode(),
+			expected: Cursor{EventDate: eventDate, TransactionID: "4a1c1f7e-2a55-4c8b-9d9e-0f3b5b1a2c3d"},
+		},
+		"should not be able to decode cursor with invalid transaction id": {
+			input: Cursor{EventDate: eventDate, TransactionID: "id"}.Encode(),
+			err:   ErrCursorInvalid,
+		},
+		"should not be able to decode cursor with invalid event date": {
+			input: base64.RawURLEncoding.EncodeToString([]byte("date|4a1c1f7e-2a55-4c8b-9d9e-0f3b5b1a2c3d")),
+			err:   ErrCursorInvalid,
+		},
+		"should not be able to decode cursor not encoded": {
+			input: "not a cursor",
+			err:   ErrCursorInvalid,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+
+			res, err := DecodeCursor(tt.input)
+
+			if fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%v" got "%v"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestRefundValid(t *testing.T) {
+	tests := map[string]struct {
+		input Refund
+		err   error
+	}{
+		"should be able to validate refund": {
+			input: Refund{TransactionID: "id", Amount: modelMoney.MustParse("10")},
+		},
+		"should not be able to validate refund with error amount invalid": {
+			input: Refund{TransactionID: "id"},
+			err:   fmt.Errorf("amount invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.Valid()
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewInstallments(t *testing.T) {
+
+	purchase := Transaction{
+		TransactionID:   "parent",
+		AccountID:       "id",
+		OperationTypeID: 2,
+		Amount:          modelMoney.MustParse("-100"),
+		Currency:        "BRL",
+		EventDate:       time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+	}
+
+	res := NewInstallments(purchase, 3, modelMoney.MustParse("-20"))
+
+	amounts := []modelMoney.Money{modelMoney.MustParse("-33.34"), modelMoney.MustParse("-33.33"), modelMoney.MustParse("-33.33")}
+	limits := []modelMoney.Money{modelMoney.MustParse("-6.67"), modelMoney.MustParse("-6.67"), modelMoney.MustParse("-6.66")}
+	dueDates := []time.Time{
+		time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 31, 10, 0, 0, 0, time.UTC),
+	}
+
+	if len(res) != 3 {
+		t.Fatalf("Expected 3 installments got %d", len(res))
+	}
+
+	for i, installment := range res {
+
+		expected := MakeTransaction{
+			AccountID:           "id",
+			OperationTypeID:     2,
+			Amount:              amounts[i],
+			Currency:            "BRL",
+			LimitAmount:         limits[i],
+			ParentTransactionID: &purchase.TransactionID,
+			InstallmentNumber:   i + 1,
+			DueDate:             &dueDates[i],
+		}
+
+		if !reflect.DeepEqual(installment, expected) {
+			t.Errorf("Expected installment %v got %v", expected, installment)
+		}
+	}
+}
+
+func TestLimitShare(t *testing.T) {
+
+	limit := modelMoney.MustParse("-20")
+
+	tests := map[string]struct {
+		transaction Transaction
+		amounts     []modelMoney.Money
+		expected    []modelMoney.Money
+	}{
+		"should be able to give back the whole limit": {
+			transaction: Transaction{Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-100"), LimitAmount: &limit},
+			amounts:     []modelMoney.Money{modelMoney.MustParse("100")},
+			expected:    []modelMoney.Money{modelMoney.MustParse("20")},
+		},
+		"should be able to give back exactly the limit taken when settled in parts": {
+			transaction: Transaction{Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-100"), LimitAmount: &limit},
+			amounts:     []modelMoney.Money{modelMoney.MustParse("33.33"), modelMoney.MustParse("33.33"), modelMoney.MustParse("33.34")},
+			expected:    []modelMoney.Money{modelMoney.MustParse("6.67"), modelMoney.MustParse("6.66"), modelMoney.MustParse("6.67")},
+		},
+		"should be able to take back the limit of a reopened settlement": {
+			transaction: Transaction{Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-50"), LimitAmount: &limit},
+			amounts:     []modelMoney.Money{modelMoney.MustParse("-50")},
+			expected:    []modelMoney.Money{modelMoney.MustParse("-10")},
+		},
+		"should be able to give back nothing without a limit": {
+			transaction: Transaction{Amount: modelMoney.MustParse("-100"), Balance: modelMoney.MustParse("-100")},
+			amounts:     []modelMoney.Money{modelMoney.MustParse("100")},
+			expected:    []modelMoney.Money{0},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+
+			transaction := tt.transaction
+
+			for i, amount := range tt.amounts {
+
+				if res := transaction.LimitShare(amount); res != tt.expected[i] {
+					t.Errorf("Expected share %s got %s", tt.expected[i], res)
+				}
+
+				transaction.Balance += amount
+			}
+		})
+	}
+}
+
+func TestValidateAmount(t *testing.T) {
+	tests := map[string]struct {
+		input MakeTransaction
+		err   error
+	}{
+		"should be able to validate amount": {
+			input: MakeTransaction{Amount: modelMoney.MustParse("10")},
+		},
+		"should be able to validate max amount": {
+			input: MakeTransaction{Amount: modelMoney.Max},
+		},
+		"should not be able to validate zero amount": {
+			input: MakeTransaction{},
+			err:   fmt.Errorf("amount invalid"),
+		},
+		"should not be able to validate amount beyond max": {
+			input: MakeTransaction{Amount: modelMoney.Max + 1},
+			err:   fmt.Errorf("amount invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.ValidateAmount()
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateInstallments(t *testing.T) {
+	tests := map[string]struct {
+		input MakeTransaction
+		err   error
+	}{
+		"should be able to validate without installments": {
+			input: MakeTransaction{},
+		},
+		"should be able to validate max installments": {
+			input: MakeTransaction{Installments: MaxInstallments},
+		},
+		"should not be able to validate negative installments": {
+			input: MakeTransaction{Installments: -1},
+			err:   fmt.Errorf("installments invalid"),
+		},
+		"should not be able to validate more than max installments": {
+			input: MakeTransaction{Installments: MaxInstallments + 1},
+			err:   fmt.Errorf("installments invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			err := tt.input.ValidateInstallments()
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
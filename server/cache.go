@@ -5,17 +5,22 @@ import (
 	"log"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
 )
 
+// startCache connects to Redis. The server starts even when it is down, without the cache until it comes back, and
+// reported degraded by the readiness probe meanwhile.
 func (s *server) startCache() *redis.Client {
 
 	cache := redis.NewClient(&redis.Options{
 		Addr: s.config.Cache.Addr,
 	})
+	cache.AddHook(tracing.Redis{})
 
 	err := cache.Ping(context.Background()).Err()
 	if err != nil {
-		log.Fatal("cache ping: ", err.Error())
+		log.Println("cache ping: ", err.Error())
+		return cache
 	}
 
 	log.Println("cache started")
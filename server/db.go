@@ -3,12 +3,14 @@ package server
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/jorgepiresg/ChallangePismo/migrations"
+	storeMigrations "github.com/jorgepiresg/ChallangePismo/store/migrations"
 	_ "github.com/lib/pq"
 )
 
@@ -32,47 +34,96 @@ func (s *server) createSqlConn() *sqlx.DB {
 	db.SetMaxOpenConns(300)
 	db.SetConnMaxLifetime(30 * time.Minute)
 
-	s.runMigrationsUp(db)
-
 	log.Println("database started")
 	return db
 }
 
-func (s *server) runMigrationsUp(db *sqlx.DB) {
+// MigrateUp applies the pending migrations. Migrations are read from the directory of the config or, by default, embedded
+// in the binary.
+func (s *server) MigrateUp() error {
 
-	migrationFile := s.config.DB.MigrationFile
+	return s.migrations(func(ctx context.Context, runner storeMigrations.IMigrations) error {
 
-	entries, err := os.ReadDir(migrationFile)
-	if err != nil {
-		log.Fatal(err)
-	}
+		applied, err := runner.Up(ctx)
+		for _, migration := range applied {
+			log.Printf("migration %d_%s applied", migration.Version, migration.Name)
+		}
+		return err
+	})
+}
 
-	tx := db.MustBegin()
+// MigrateDown rolls back the last steps applied migrations.
+func (s *server) MigrateDown(steps int) error {
 
-	for _, e := range entries {
+	return s.migrations(func(ctx context.Context, runner storeMigrations.IMigrations) error {
 
-		if !isUp(e.Name()) {
-			continue
+		rolledBack, err := runner.Down(ctx, steps)
+		for _, migration := range rolledBack {
+			log.Printf("migration %d_%s rolled back", migration.Version, migration.Name)
 		}
+		return err
+	})
+}
+
+// MigrationStatus prints every migration, applied or pending.
+func (s *server) MigrationStatus() error {
+
+	return s.migrations(func(ctx context.Context, runner storeMigrations.IMigrations) error {
 
-		bytes, err := os.ReadFile(fmt.Sprintf("%s/%s", migrationFile, e.Name()))
+		migrations, err := runner.List(ctx)
 		if err != nil {
-			continue
+			return err
+		}
+
+		for _, migration := range migrations {
+			status := "pending"
+			if migration.Applied() {
+				status = "applied at " + migration.AppliedAt.Format(time.RFC3339)
+			}
+			log.Printf("migration %d_%s %s", migration.Version, migration.Name, status)
 		}
+		return nil
+	})
+}
 
-		query := string(bytes)
+// migrations runs fn with the migrations runner, on the database of the server when it is started, or on a connection of
+// its own otherwise.
+func (s *server) migrations(fn func(ctx context.Context, runner storeMigrations.IMigrations) error) error {
 
-		tx.MustExec(query)
+	if s.log == nil {
+		s.startLog()
 	}
 
-	tx.Commit()
+	db := s.db
+	if db == nil {
+		db = s.createSqlConn()
+		defer db.Close()
+	}
 
-}
+	runner, err := s.newMigrations(db)
+	if err != nil {
+		return err
+	}
 
-func isUp(fileName string) bool {
-	return strings.Contains(fileName, "up")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	return fn(ctx, runner)
 }
 
-func isDown(fileName string) bool {
-	return strings.Contains(fileName, "down")
+func (s *server) newMigrations(db *sqlx.DB) (storeMigrations.IMigrations, error) {
+
+	var fsys fs.FS = migrations.FS
+	if s.config.DB.MigrationFile != "" {
+		fsys = os.DirFS(s.config.DB.MigrationFile)
+	}
+
+	return storeMigrations.New(storeMigrations.Options{
+		DB:  db,
+		Log: s.log,
+		FS:  fsys,
+		Settings: map[string]string{
+			"pismo.default_credit_limit": s.config.Accounts.DefaultCreditLimit.String(),
+		},
+	})
 }
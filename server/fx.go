@@ -0,0 +1,21 @@
+package server
+
+import (
+	"log"
+
+	"github.com/jorgepiresg/ChallangePismo/store/fx"
+)
+
+func (s *server) startFX() fx.IRates {
+
+	rates, err := fx.New(fx.Options{
+		File: s.config.FX.RatesFile,
+		Log:  s.log,
+	})
+	if err != nil {
+		log.Fatal("fx rates: ", err.Error())
+	}
+
+	log.Println("fx rates started")
+	return rates
+}
@@ -1,29 +1,53 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
 	"github.com/jorgepiresg/ChallangePismo/api"
 	"github.com/jorgepiresg/ChallangePismo/app"
 	"github.com/jorgepiresg/ChallangePismo/config"
+	"github.com/jorgepiresg/ChallangePismo/metrics"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
 	"github.com/jorgepiresg/ChallangePismo/store"
 	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/labstack/echo/v4"
 	emiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	_ "github.com/jorgepiresg/ChallangePismo/docs"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
 type Server interface {
-	Start()
+	Start(migrate bool)
+	CloseCycles(date time.Time) error
+	Accrue(from, to time.Time) error
+	MigrateUp() error
+	MigrateDown(steps int) error
+	MigrationStatus() error
+	Seed() error
+	ReconcileBalances(accountID string) error
 }
 
 type server struct {
 	echo   *echo.Echo
 	config config.Config
+	db     *sqlx.DB
+	cache  *redis.Client
+	tasks  *utils.Tasks
+	tracer *sdktrace.TracerProvider
 	store  store.Store
 	log    *logrus.Logger
 }
@@ -42,34 +66,211 @@ func New(cfg config.Config) Server {
 
 // @host localhost:8080/
 // @BasePath api/v1
-func (s *server) Start() {
+//
+// Start serves the API until it fails or the process gets SIGINT or SIGTERM, then shuts down. Pending migrations are
+// applied first when migrate is set; otherwise the schema is expected up to date, migrated by the migrate command.
+func (s *server) Start(migrate bool) {
+
+	s.startLog()
+	s.startTracing()
+	s.startStore()
+
+	if migrate {
+		if err := s.MigrateUp(); err != nil {
+			log.Fatal("migrate: ", err.Error())
+		}
+	}
+
+	metrics.RegisterPool(s.db.DB)
+	s.startEcho(s.newApp())
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.echo.Start(s.config.ServerPort)
+	}()
+
+	log.Println("Start server PID: ", os.Getpid())
+
+	select {
+	case err := <-errs:
+		log.Println("cannot starting server ", err.Error())
+	case <-ctx.Done():
+		log.Println("shutting down server")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.shutdown(ctx); err != nil {
+		log.Println("cannot shutting down server ", err.Error())
+	}
+}
+
+// CloseCycles closes the billing cycles of the accounts whose statements close on the day of date, without starting the
+// HTTP server. It is meant to run once a day, and can be run again for a date already closed.
+func (s *server) CloseCycles(date time.Time) error {
+
+	s.startLog()
+	s.startStore()
+
+	return s.run(30*time.Minute, func(ctx context.Context, app app.App) error {
+
+		res, err := app.Statements.CloseCycles(ctx, date)
+
+		log.Printf("closed cycles at %s: %d accounts, %d statements, %d failed", res.ClosingDate.Format(time.DateOnly), res.Accounts, res.Statements, res.Failed)
+
+		return err
+	})
+}
+
+// Accrue charges interest and late fees on the debits overdue at each day from from to to, without starting the HTTP server.
+// It is meant to run once a day, after the cycles are closed, and can be run again for days already accrued.
+func (s *server) Accrue(from, to time.Time) error {
+
+	s.startLog()
+	s.startStore()
+
+	return s.run(30*time.Minute, func(ctx context.Context, app app.App) error {
+
+		res, err := app.Accruals.Accrue(ctx, from, to)
+
+		log.Printf("accrued from %s to %s: %d accounts, %d charges, %d failed", res.From.Format(time.DateOnly), res.To.Format(time.DateOnly), res.Accounts, res.Charges, res.Failed)
+
+		return err
+	})
+}
+
+// Seed creates the default operation types and the demo accounts missing, without starting the HTTP server.
+func (s *server) Seed() error {
+
+	s.startLog()
+	s.startStore()
+
+	return s.run(time.Minute, func(ctx context.Context, app app.App) error {
+
+		res, err := app.Seed.Run(ctx)
+
+		log.Printf("seeded: %d operation types, %d accounts", res.OperationTypes, res.Accounts)
+
+		return err
+	})
+}
+
+// ReconcileBalances applies again the open credits of the account to its open debits, without starting the HTTP server.
+func (s *server) ReconcileBalances(accountID string) error {
+
+	s.startLog()
+	s.startStore()
+
+	return s.run(5*time.Minute, func(ctx context.Context, app app.App) error {
+
+		res, err := app.Transactions.Reconcile(ctx, accountID)
+
+		log.Printf("reconciled balances of account %s: %d credits", res.AccountID, res.Credits)
+
+		return err
+	})
+}
+
+// run runs a command, given up to timeout, on the app of the started store, then shuts down as Start does: the tasks the
+// command left running, such as cache writes, are waited for and the database and the cache are closed. The shutdown error
+// is returned along with the one of the command.
+func (s *server) run(timeout time.Duration, command func(ctx context.Context, app app.App) error) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := command(ctx, s.newApp())
+
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancelShutdown()
+
+	if shutdownErr := s.shutdown(ctx); shutdownErr != nil {
+		err = errors.Join(err, fmt.Errorf("shutdown: %w", shutdownErr))
+	}
+
+	return err
+}
+
+func (s *server) newApp() app.App {
+	return app.New(app.Options{
+		Store:                       s.store,
+		Log:                         s.log,
+		ConvertPayments:             s.config.FX.ConvertPayments,
+		DischargeFutureInstallments: s.config.Installments.DischargeFuture,
+		AccrualRates: modelAccruals.Rates{
+			Interest: s.config.Accrual.InterestRate,
+			LateFee:  s.config.Accrual.LateFeeRate,
+		},
+		DefaultCreditLimit: s.config.Accounts.DefaultCreditLimit,
+	})
+}
+
+func (s *server) startEcho(app app.App) {
 
 	s.echo = echo.New()
 	s.echo.HTTPErrorHandler = createHTTPErrorHandler()
 
+	s.echo.Use(otelecho.Middleware(s.config.Tracing.ServiceName, otelecho.WithSkipper(untraced)))
+	s.echo.Use(metrics.Middleware())
 	s.echo.Use(emiddleware.BodyLimit("2M"))
 	s.echo.Use(emiddleware.Recover())
 	s.echo.Use(emiddleware.RequestID())
 	s.echo.Use(emiddleware.CORS())
 	s.echo.GET("/swagger/*", echoSwagger.WrapHandler)
-
-	s.startLog()
-	s.startStore()
-
-	app := app.New(app.Options{
-		Store: s.store,
-		Log:   s.log,
-	})
+	s.echo.GET("/metrics", metrics.Handler())
 
 	api.New(api.Options{
-		Group: s.echo.Group("/api"),
-		App:   app,
+		Group:  s.echo.Group("/api"),
+		App:    app,
+		Health: s.echo.Group("/health"),
 	})
+}
 
-	log.Println("Start server PID: ", os.Getpid())
-	if err := s.echo.Start(s.config.ServerPort); err != nil {
-		log.Println("cannot starting server ", err.Error())
+// untraced skips the requests of the probes, the metrics and the docs, which would only add noise to the traces.
+func untraced(c echo.Context) bool {
+	path := c.Request().URL.Path
+	return path == "/metrics" || strings.HasPrefix(path, "/health/") || strings.HasPrefix(path, "/swagger/")
+}
+
+// shutdown stops taking requests and waits, until ctx is done, for the requests being served and then for the tasks they
+// left running, before closing the database and the cache. They are closed even when the wait is cut short.
+func (s *server) shutdown(ctx context.Context) error {
+
+	var errs []error
+
+	if s.echo != nil {
+		if err := s.echo.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http: %w", err))
+		}
+	}
+
+	if err := s.tasks.Wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tasks: %w", err))
 	}
+
+	if s.tracer != nil {
+		if err := s.tracer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("tracing: %w", err))
+		}
+	}
+
+	if s.db != nil {
+		if err := s.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("database: %w", err))
+		}
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("cache: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func createHTTPErrorHandler() echo.HTTPErrorHandler {
@@ -78,17 +279,25 @@ func createHTTPErrorHandler() echo.HTTPErrorHandler {
 			return
 		}
 
-		if err := c.JSON(utils.GetHTTPCode(err), err); err != nil {
+		e := utils.GetError(err)
+		if err := c.JSON(e.HTTPCode, e); err != nil {
 			log.Println(err)
 		}
 	}
 }
 
 func (s *server) startStore() {
+
+	s.db = s.createSqlConn()
+	s.cache = s.startCache()
+	s.tasks = &utils.Tasks{}
+
 	s.store = store.New(store.Options{
-		DB:    s.createSqlConn(),
+		DB:    s.db,
 		Log:   s.log,
-		Cache: s.startCache(),
+		Cache: s.cache,
+		FX:    s.startFX(),
+		Tasks: s.tasks,
 	})
 }
 
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jorgepiresg/ChallangePismo/app"
+	"github.com/jorgepiresg/ChallangePismo/config"
+	mocksApp "github.com/jorgepiresg/ChallangePismo/mocks/app"
+	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/sirupsen/logrus"
+)
+
+func TestShutdown(t *testing.T) {
+
+	t.Run("should be able to finish a discharge started before shutdown", func(t *testing.T) {
+
+		ctrl := gomock.NewController(t)
+		transactionsMock := mocksApp.NewMockITransactions(ctrl)
+
+		s := &server{log: logrus.New(), tasks: &utils.Tasks{}}
+
+		started, release := make(chan struct{}), make(chan struct{})
+		var discharged, cached int32
+
+		transactionsMock.EXPECT().Make(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, data modelTransactions.MakeTransaction) error {
+			close(started)
+			<-release
+			atomic.StoreInt32(&discharged, 1)
+
+			s.tasks.Go(func() {
+				time.Sleep(20 * time.Millisecond)
+				atomic.StoreInt32(&cached, 1)
+			})
+			return nil
+		})
+
+		addr := serve(t, s, app.App{Transactions: transactionsMock})
+
+		status := make(chan int, 1)
+		go func() {
+			res, err := http.Post("http://"+addr+"/api/v1/transactions", "application/json", strings.NewReader(`{"account_id":"id","operation_type_id":4,"amount":60}`))
+			if err != nil {
+				status <- 0
+				return
+			}
+			res.Body.Close()
+			status <- res.StatusCode
+		}()
+
+		<-started
+
+		done := make(chan error, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			done <- s.shutdown(ctx)
+		}()
+
+		select {
+		case err := <-done:
+			t.Fatalf("Expected shutdown to wait for the discharge, got %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			t.Errorf("Expected new connections refused while shutting down")
+		}
+
+		close(release)
+
+		if err := <-done; err != nil {
+			t.Errorf(`Expected err: nil got "%s"`, err)
+		}
+		if code := <-status; code != http.StatusCreated {
+			t.Errorf("Expected status %d got %d", http.StatusCreated, code)
+		}
+		if atomic.LoadInt32(&discharged) != 1 {
+			t.Errorf("Expected discharge finished")
+		}
+		if atomic.LoadInt32(&cached) != 1 {
+			t.Errorf("Expected task finished")
+		}
+	})
+
+	t.Run("should not be able to wait for tasks past the deadline", func(t *testing.T) {
+
+		s := &server{log: logrus.New(), tasks: &utils.Tasks{}}
+		serve(t, s, app.App{})
+
+		release := make(chan struct{})
+		defer close(release)
+		s.tasks.Go(func() { <-release })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if err := s.shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf(`Expected err: "%s" got "%v"`, context.DeadlineExceeded, err)
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+
+	t.Run("should be able to wait for the tasks the command left running", func(t *testing.T) {
+
+		s := &server{log: logrus.New(), tasks: &utils.Tasks{}, config: config.Config{ShutdownTimeout: time.Second}}
+
+		var cached int32
+		commandErr := errors.New("any")
+
+		err := s.run(time.Second, func(ctx context.Context, app app.App) error {
+			s.tasks.Go(func() {
+				time.Sleep(20 * time.Millisecond)
+				atomic.StoreInt32(&cached, 1)
+			})
+			return commandErr
+		})
+
+		if !errors.Is(err, commandErr) {
+			t.Errorf(`Expected err: "%s" got "%v"`, commandErr, err)
+		}
+		if atomic.LoadInt32(&cached) != 1 {
+			t.Errorf("Expected task finished")
+		}
+	})
+
+	t.Run("should not be able to wait for tasks past the shutdown timeout", func(t *testing.T) {
+
+		s := &server{log: logrus.New(), tasks: &utils.Tasks{}, config: config.Config{ShutdownTimeout: 20 * time.Millisecond}}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		err := s.run(time.Second, func(ctx context.Context, app app.App) error {
+			s.tasks.Go(func() { <-release })
+			return nil
+		})
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf(`Expected err: "%s" got "%v"`, context.DeadlineExceeded, err)
+		}
+	})
+}
+
+// serve starts the API of s with app on a free local port and returns its address.
+func serve(t *testing.T, s *server, app app.App) string {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when listening", err)
+	}
+
+	s.startEcho(app)
+	s.echo.HideBanner = true
+	s.echo.HidePort = true
+	s.echo.Listener = listener
+
+	go s.echo.Start("")
+
+	return listener.Addr().String()
+}
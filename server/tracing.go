@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// startTracing sets the tracer provider exporting the spans as the config says, and the W3C propagation of the trace
+// context, so a trace started by a caller carries on here. Without an exporter spans are still started, but dropped.
+func (s *server) startTracing() {
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newSpanExporter(s.config.Tracing.Exporter)
+	if err != nil {
+		log.Fatal("tracing: ", err.Error())
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(s.config.Tracing.ServiceName))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	s.tracer = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(s.tracer)
+
+	log.Println("tracing started")
+}
+
+func newSpanExporter(name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "otlp":
+		return otlptracehttp.New(context.Background())
+	case "stdout":
+		return stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("exporter %q invalid", name)
+	}
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/jorgepiresg/ChallangePismo/config"
+	"github.com/jorgepiresg/ChallangePismo/store"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+
+	t.Run("should be able to trace a request from the handler to the cache written after it", func(t *testing.T) {
+
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(provider)
+		t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+		db, mock, err := sqlxmock.Newx()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		cache, redisMock := redismock.NewClientMock()
+		cache.AddHook(tracing.Redis{})
+
+		accountID := "8f14e45f-ceea-467f-a0e6-7e1a5b2c3d4e"
+
+		redisMock.ExpectGet("account_id_" + accountID).RedisNil()
+		mock.ExpectQuery("SELECT account_id").WillReturnRows(mock.NewRows([]string{"account_id", "document_number", "document_type", "available_credit_limit", "currency", "statement_closing_day", "status", "created_at"}).
+			AddRow(accountID, "52998224725", "CPF", 0, "BRL", 10, "ACTIVE", time.Now()))
+		redisMock.Regexp().ExpectSet("account_id_"+accountID, `.*`, 10*time.Minute).SetVal("OK")
+
+		s := &server{
+			config: config.Config{Tracing: config.Tracing{ServiceName: "pismo"}},
+			log:    logrus.New(),
+			tasks:  &utils.Tasks{},
+		}
+		s.store = store.New(store.Options{DB: db, Log: s.log, Cache: cache, Tasks: s.tasks})
+
+		addr := serve(t, s, s.newApp())
+
+		res, err := http.Get("http://" + addr + "/api/v1/accounts/" + accountID)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when requesting", err)
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("Expected status %d got %d", http.StatusOK, res.StatusCode)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := s.shutdown(ctx); err != nil {
+			t.Fatalf(`Expected err: nil got "%s"`, err)
+		}
+
+		spans := map[string]tracetest.SpanStub{}
+		for _, span := range exporter.GetSpans() {
+			spans[span.Name] = span
+		}
+
+		parents := map[string]string{
+			"accounts.GetByAccountID": "/api/v1/accounts/:account_id",
+			"accounts SELECT":         "accounts.GetByAccountID",
+			"redis SET":               "accounts.GetByAccountID",
+		}
+
+		for name, parent := range parents {
+			span, ok := spans[name]
+			if !ok {
+				t.Errorf("Expected span %s, got %v", name, exporter.GetSpans().Snapshots())
+				continue
+			}
+			if span.Parent.SpanID() != spans[parent].SpanContext.SpanID() {
+				t.Errorf("Expected span %s child of %s", name, parent)
+			}
+			if span.SpanContext.TraceID() != spans[parent].SpanContext.TraceID() {
+				t.Errorf("Expected span %s in the trace of %s", name, parent)
+			}
+		}
+	})
+}
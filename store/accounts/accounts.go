@@ -6,12 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/jorgepiresg/ChallangePismo/metrics"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
 	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,19 +26,43 @@ import (
 type IAccounts interface {
 	Create(ctx context.Context, account modelAccounts.Create) (modelAccounts.Account, error)
 	GetByID(ctx context.Context, ID string) (modelAccounts.Account, error)
-	GetByDocument(ctx context.Context, document string) (modelAccounts.Account, error)
+	UpdateAvailableCreditLimit(ctx context.Context, ID string, amount modelMoney.Money) error
+	Lock(ctx context.Context, ID string) error
+	ListByClosingDay(ctx context.Context, day int) ([]modelAccounts.Account, error)
+	List(ctx context.Context, filter modelAccounts.ListFilter) ([]modelAccounts.Account, error)
+	Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error)
+	UpdateStatus(ctx context.Context, change modelAccounts.StatusChange) (modelAccounts.Account, error)
+	ListStatusHistory(ctx context.Context, ID string) ([]modelAccounts.StatusChange, error)
+	DeleteCache(ctx context.Context, account modelAccounts.Account)
 }
 
+var (
+	// ErrStatusChanged is returned by UpdateStatus when the account is no longer in the status the change is from.
+	ErrStatusChanged = modelErrors.Conflict("ACCOUNT_STATUS_CHANGED", "account status changed")
+
+	// ErrDocumentNumberTaken is returned by Create and Update when another account has the document number.
+	ErrDocumentNumberTaken = modelErrors.Conflict("DOCUMENT_NUMBER_TAKEN", "document number taken")
+)
+
+// uniqueViolation is the Postgres error code of a unique index violation.
+const uniqueViolation = "23505"
+
+const columns = `account_id, document_number, document_type, available_credit_limit, currency, statement_closing_day, status, created_at`
+
 type Options struct {
-	DB    *sqlx.DB
+	DB    sqlx.ExtContext
 	Log   *logrus.Logger
 	Cache *redis.Client
+
+	// Tasks tracks the cache writes left running after a query.
+	Tasks *utils.Tasks
 }
 
 type accounts struct {
-	db    *sqlx.DB
+	db    sqlx.ExtContext
 	log   *logrus.Logger
 	cache *redis.Client
+	tasks *utils.Tasks
 }
 
 func New(opts Options) IAccounts {
@@ -39,6 +70,7 @@ func New(opts Options) IAccounts {
 		db:    opts.DB,
 		log:   opts.Log,
 		cache: opts.Cache,
+		tasks: opts.Tasks,
 	}
 }
 
@@ -46,11 +78,11 @@ func (a accounts) Create(ctx context.Context, create modelAccounts.Create) (mode
 
 	var account modelAccounts.Account
 
-	rows, err := a.db.NamedQueryContext(ctx, `INSERT INTO accounts (document_number) VALUES (:document_number) RETURNING *`, create)
+	rows, err := sqlx.NamedQueryContext(ctx, a.db, `INSERT INTO accounts (document_number, document_type, available_credit_limit, currency, statement_closing_day) VALUES (:document_number, :document_type, :available_credit_limit, :currency, :statement_closing_day) RETURNING `+columns, create)
 	if err != nil {
-		a.log.WithField("document", create.DocumentNumber).Error(err)
-		return account, err
+		return account, a.writeError(a.log.WithField("document", create.DocumentNumber), err)
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		err = rows.StructScan(&account)
@@ -60,12 +92,22 @@ func (a accounts) Create(ctx context.Context, create modelAccounts.Create) (mode
 		}
 	}
 
+	if err := rows.Err(); err != nil {
+		return account, a.writeError(a.log.WithField("document", create.DocumentNumber), err)
+	}
+
 	return account, nil
 }
 
 func (a accounts) GetByID(ctx context.Context, ID string) (modelAccounts.Account, error) {
 
 	var account modelAccounts.Account
+
+	// account ids are UUIDs, a malformed one matches no account and would only fail the query
+	if _, err := uuid.Parse(ID); err != nil {
+		return account, sql.ErrNoRows
+	}
+
 	cacheKey := fmt.Sprintf("account_id_%s", ID)
 
 	err := a.getCache(ctx, cacheKey, &account)
@@ -73,7 +115,7 @@ func (a accounts) GetByID(ctx context.Context, ID string) (modelAccounts.Account
 		return account, err
 	}
 
-	err = a.db.GetContext(ctx, &account, `SELECT account_id, document_number, created_at FROM accounts where account_id = $1`, ID)
+	err = sqlx.GetContext(ctx, a.db, &account, `SELECT `+columns+` FROM accounts where account_id = $1`, ID)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			a.log.WithField("account_id", ID).Error(err)
@@ -81,35 +123,198 @@ func (a accounts) GetByID(ctx context.Context, ID string) (modelAccounts.Account
 		return account, err
 	}
 
-	go a.setCache(context.Background(), cacheKey, account)
+	a.tasks.Go(func() { a.setCache(tracing.Detach(ctx), cacheKey, account) })
 
 	return account, nil
 }
 
-func (a accounts) GetByDocument(ctx context.Context, document string) (modelAccounts.Account, error) {
+// UpdateAvailableCreditLimit adds amount, which may be negative, to the available credit limit of the account.
+// The cached account is left as is, callers must call DeleteCache once the change is committed.
+func (a accounts) UpdateAvailableCreditLimit(ctx context.Context, ID string, amount modelMoney.Money) error {
 
-	var account modelAccounts.Account
+	_, err := a.db.ExecContext(ctx, `UPDATE accounts SET available_credit_limit = available_credit_limit + $1 WHERE account_id = $2`, amount, ID)
+	if err != nil {
+		a.log.WithField("account_id", ID).Error(err)
+		return err
+	}
 
-	cacheKey := fmt.Sprintf("account_document_%s", document)
+	return nil
+}
 
-	err := a.getCache(ctx, cacheKey, &account)
-	if err == nil && !reflect.DeepEqual(account, modelAccounts.Account{}) {
-		return account, err
-	}
+// Lock takes the row lock of the account until the database transaction ends, serializing it with every other movement of
+// the account. It must run inside WithTx.
+func (a accounts) Lock(ctx context.Context, ID string) error {
 
-	err = a.db.GetContext(ctx, &account, `SELECT account_id, document_number, created_at FROM accounts where document_number = $1`, document)
+	var accountID string
+	err := sqlx.GetContext(ctx, a.db, &accountID, `SELECT account_id FROM accounts WHERE account_id = $1 FOR UPDATE`, ID)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
-			a.log.WithField("document", document).Error(err)
+			a.log.WithField("account_id", ID).Error(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListByClosingDay returns the accounts whose statements close on the day of the month.
+func (a accounts) ListByClosingDay(ctx context.Context, day int) ([]modelAccounts.Account, error) {
+
+	var accounts []modelAccounts.Account
+	err := sqlx.SelectContext(ctx, a.db, &accounts, `SELECT `+columns+` FROM accounts
+	WHERE statement_closing_day = $1
+	ORDER BY created_at, account_id`, day)
+	if err != nil {
+		a.log.WithField("statement_closing_day", day).Error(err)
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// List returns the accounts matching the filter, the newest first. The document number matches as a prefix.
+func (a accounts) List(ctx context.Context, filter modelAccounts.ListFilter) ([]modelAccounts.Account, error) {
+
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+
+	where := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
 		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.DocumentNumber != "" {
+		where("document_number LIKE %s || '%%'", filter.DocumentNumber)
+	}
+
+	if !filter.StartDate.IsZero() {
+		where("created_at >= %s", filter.StartDate)
+	}
+
+	if !filter.EndDate.IsZero() {
+		where("created_at <= %s", filter.EndDate)
+	}
+
+	if filter.After != nil {
+		where("(created_at, account_id) < (%s, %s)", filter.After.CreatedAt, filter.After.AccountID)
+	}
+
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`SELECT %s FROM accounts WHERE %s ORDER BY created_at DESC, account_id DESC LIMIT $%d`, columns, strings.Join(conditions, " AND "), len(args))
+
+	var accounts []modelAccounts.Account
+	err := sqlx.SelectContext(ctx, a.db, &accounts, query, args...)
+	if err != nil {
+		a.log.WithField("filter", filter).Error(err)
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Update changes the fields that are set, keeping the others. The cached account is left as is, callers must call
+// DeleteCache with the account before and after the change, so both document numbers are dropped from the cache.
+func (a accounts) Update(ctx context.Context, update modelAccounts.Update) (modelAccounts.Account, error) {
+
+	var account modelAccounts.Account
+
+	err := sqlx.GetContext(ctx, a.db, &account, `UPDATE accounts SET
+	document_number = COALESCE($1, document_number),
+	statement_closing_day = COALESCE($2, statement_closing_day),
+	available_credit_limit = COALESCE($3, available_credit_limit)
+	WHERE account_id = $4
+	RETURNING `+columns, update.DocumentNumber, update.StatementClosingDay, update.AvailableCreditLimit, update.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, err
+		}
+		return account, a.writeError(a.log.WithField("account_id", update.AccountID), err)
+	}
+
+	return account, nil
+}
+
+// UpdateStatus moves the account from the status the change is from to the one it is to, recording the change with its
+// reason. The cached account is left as is, callers must call DeleteCache once the change is committed.
+func (a accounts) UpdateStatus(ctx context.Context, change modelAccounts.StatusChange) (modelAccounts.Account, error) {
+
+	var account modelAccounts.Account
+
+	rows, err := sqlx.NamedQueryContext(ctx, a.db, `WITH account AS (
+		UPDATE accounts SET status = :to_status WHERE account_id = CAST(:account_id AS UUID) AND status = :from_status
+		RETURNING `+columns+`
+	), change AS (
+		INSERT INTO accounts_status_history (account_id, from_status, to_status, reason)
+		SELECT account_id, :from_status, :to_status, :reason FROM account
+	)
+	SELECT `+columns+` FROM account`, change)
+	if err != nil {
+		a.log.WithField("body", change).Error(err)
 		return account, err
 	}
+	defer rows.Close()
 
-	go a.setCache(context.Background(), cacheKey, account)
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			a.log.WithField("body", change).Error(err)
+			return account, err
+		}
+		return account, ErrStatusChanged
+	}
+
+	if err := rows.StructScan(&account); err != nil {
+		a.log.WithField("body", change).Error(err)
+		return account, err
+	}
 
 	return account, nil
 }
 
+// ListStatusHistory returns the status changes of the account in the order they were made.
+func (a accounts) ListStatusHistory(ctx context.Context, ID string) ([]modelAccounts.StatusChange, error) {
+
+	var changes []modelAccounts.StatusChange
+	err := sqlx.SelectContext(ctx, a.db, &changes, `SELECT change_id, account_id, from_status, to_status, reason, changed_at
+	FROM accounts_status_history
+	WHERE account_id = $1
+	ORDER BY changed_at, change_id`, ID)
+	if err != nil {
+		a.log.WithField("account_id", ID).Error(err)
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (a accounts) DeleteCache(ctx context.Context, account modelAccounts.Account) {
+
+	keys := []string{fmt.Sprintf("account_id_%s", account.ID)}
+
+	err := a.cache.Del(ctx, keys...).Err()
+	if err != nil {
+		a.log.WithField("cache_keys", keys).Warning(err)
+	}
+}
+
+// writeError translates the violation of the document number unique index into ErrDocumentNumberTaken, logging any
+// other error.
+func (a accounts) writeError(log *logrus.Entry, err error) error {
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return ErrDocumentNumberTaken
+	}
+
+	log.Error(err)
+
+	return err
+}
+
 func (a accounts) setCache(ctx context.Context, key string, account modelAccounts.Account) {
 
 	err := a.cache.Set(ctx, key, utils.ToJSON(account), 10*time.Minute).Err()
@@ -121,9 +326,12 @@ func (a accounts) setCache(ctx context.Context, key string, account modelAccount
 func (a accounts) getCache(ctx context.Context, key string, account *modelAccounts.Account) error {
 	res, err := a.cache.Get(ctx, key).Result()
 	if err != nil {
+		metrics.CacheReads.WithLabelValues("accounts", metrics.CacheResult(err)).Inc()
 		return err
 	}
 
+	metrics.CacheReads.WithLabelValues("accounts", metrics.CacheHit).Inc()
+
 	if err := utils.FromJson(res, account); err != nil {
 		a.log.WithField("cache_key", key).Error(err)
 		return err
@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
@@ -9,13 +10,17 @@ import (
 
 	"github.com/go-redis/redismock/v8"
 	modelAccounts "github.com/jorgepiresg/ChallangePismo/model/accounts"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 )
 
 func TestCreate(t *testing.T) {
 
+	limit := modelMoney.MustParse("1500")
+
 	type fields struct {
 		sqlx sqlxmock.Sqlmock
 	}
@@ -28,16 +33,25 @@ func TestCreate(t *testing.T) {
 	}{
 		"should be able to insert account": {
 			input: modelAccounts.Create{
-				DocumentNumber: "111111111111",
+				DocumentNumber:       "11222333000181",
+				DocumentType:         "CNPJ",
+				AvailableCreditLimit: &limit,
+				Currency:             "USD",
+				StatementClosingDay:  10,
 			},
 			prepare: func(f *fields) {
-				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "111111111111", time.Time{})
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "document_type", "available_credit_limit", "currency", "statement_closing_day", "created_at"}).
+					AddRow("id", "11222333000181", "CNPJ", "1500.00", "USD", 10, time.Time{})
 
-				f.sqlx.ExpectQuery("INSERT INTO accounts").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("INSERT INTO accounts \\(document_number, document_type,").WithArgs("11222333000181", "CNPJ", "1500.00", "USD", 10).WillReturnRows(rows)
 			},
 			expected: modelAccounts.Account{
-				ID:             "id",
-				DocumentNumber: "111111111111",
+				ID:                   "id",
+				DocumentNumber:       "11222333000181",
+				DocumentType:         "CNPJ",
+				AvailableCreditLimit: limit,
+				Currency:             "USD",
+				StatementClosingDay:  10,
 			},
 		},
 		"should not be able to insert account with error at scan": {
@@ -60,6 +74,26 @@ func TestCreate(t *testing.T) {
 			},
 			err: fmt.Errorf("any"),
 		},
+		"should not be able to insert account with document number taken": {
+			input: modelAccounts.Create{
+				DocumentNumber: "52998224725",
+			},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("INSERT INTO accounts").WillReturnError(&pq.Error{Code: "23505", Constraint: "accounts_document_number_key"})
+			},
+			err: ErrDocumentNumberTaken,
+		},
+		"should not be able to insert account with document number taken while reading rows": {
+			input: modelAccounts.Create{
+				DocumentNumber: "52998224725",
+			},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id"}).RowError(0, &pq.Error{Code: "23505"}).AddRow("id")
+
+				f.sqlx.ExpectQuery("INSERT INTO accounts").WillReturnRows(rows)
+			},
+			err: ErrDocumentNumberTaken,
+		},
 	}
 
 	for key, tt := range tests {
@@ -98,6 +132,8 @@ func TestGetByID(t *testing.T) {
 		redis redismock.ClientMock
 	}
 
+	accountID := "8f14e45f-ceea-467f-a0e6-7e1a5b2c3d4e"
+
 	tests := map[string]struct {
 		input    string
 		expected modelAccounts.Account
@@ -105,16 +141,16 @@ func TestGetByID(t *testing.T) {
 		prepare  func(f *fields)
 	}{
 		"should be able to get account by id": {
-			input: "id",
+			input: accountID,
 			prepare: func(f *fields) {
 
-				f.redis.ExpectGet("account_id_id").RedisNil()
+				f.redis.ExpectGet("account_id_" + accountID).RedisNil()
 
 				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("id").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT account_id, document_number, document_type, available_credit_limit, currency, statement_closing_day, status, created_at FROM accounts").WithArgs(accountID).WillReturnRows(rows)
 
-				f.redis.ExpectSet("account_id_id", utils.ToJSON(modelAccounts.Account{
+				f.redis.ExpectSet("account_id_"+accountID, utils.ToJSON(modelAccounts.Account{
 					ID:             "id",
 					DocumentNumber: "11111111111",
 				}), 10*time.Minute).SetVal("")
@@ -127,16 +163,16 @@ func TestGetByID(t *testing.T) {
 		},
 
 		"should be able to get account by id with error to save at cache": {
-			input: "id",
+			input: accountID,
 			prepare: func(f *fields) {
 
-				f.redis.ExpectGet("account_id_id").RedisNil()
+				f.redis.ExpectGet("account_id_" + accountID).RedisNil()
 
 				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("id").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT account_id, document_number, document_type, available_credit_limit, currency, statement_closing_day, status, created_at FROM accounts").WithArgs(accountID).WillReturnRows(rows)
 
-				f.redis.ExpectSet("account_id_id", utils.ToJSON(modelAccounts.Account{
+				f.redis.ExpectSet("account_id_"+accountID, utils.ToJSON(modelAccounts.Account{
 					ID:             "id",
 					DocumentNumber: "11111111111",
 				}), 10*time.Minute).SetErr(fmt.Errorf("any"))
@@ -149,16 +185,16 @@ func TestGetByID(t *testing.T) {
 		},
 
 		"should be able to get account by id with error to unmarshal from cache": {
-			input: "id",
+			input: accountID,
 			prepare: func(f *fields) {
 
-				f.redis.ExpectGet("account_id_id").SetVal(`A`)
+				f.redis.ExpectGet("account_id_" + accountID).SetVal(`A`)
 
 				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("id").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT account_id, document_number, document_type, available_credit_limit, currency, statement_closing_day, status, created_at FROM accounts").WithArgs(accountID).WillReturnRows(rows)
 
-				f.redis.ExpectSet("account_id_id", utils.ToJSON(modelAccounts.Account{
+				f.redis.ExpectSet("account_id_"+accountID, utils.ToJSON(modelAccounts.Account{
 					ID:             "id",
 					DocumentNumber: "11111111111",
 				}), 10*time.Minute).SetErr(fmt.Errorf("any"))
@@ -171,10 +207,10 @@ func TestGetByID(t *testing.T) {
 		},
 
 		"should be able to get account by id in cache": {
-			input: "id",
+			input: accountID,
 			prepare: func(f *fields) {
 
-				f.redis.ExpectGet("account_id_id").SetVal(`{"account_id":"id", "document_number":"11111111111"}`)
+				f.redis.ExpectGet("account_id_" + accountID).SetVal(`{"account_id":"id", "document_number":"11111111111"}`)
 
 			},
 			expected: modelAccounts.Account{
@@ -183,13 +219,19 @@ func TestGetByID(t *testing.T) {
 			},
 		},
 
+		"should not be able to get account by malformed id": {
+			input:   "id",
+			prepare: func(f *fields) {},
+			err:     sql.ErrNoRows,
+		},
+
 		"should not be able to get account by id with error at sqlx": {
-			input: "invalid_id",
+			input: accountID,
 			prepare: func(f *fields) {
 
-				f.redis.ExpectGet("account_id_id").RedisNil()
+				f.redis.ExpectGet("account_id_" + accountID).RedisNil()
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("invalid_id").WillReturnError(fmt.Errorf("any"))
+				f.sqlx.ExpectQuery("SELECT account_id, document_number, document_type, available_credit_limit, currency, statement_closing_day, status, created_at FROM accounts").WithArgs(accountID).WillReturnError(fmt.Errorf("any"))
 			},
 			err: fmt.Errorf("any"),
 		},
@@ -228,103 +270,304 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
-func TestGetByDocument(t *testing.T) {
+func TestUpdateAvailableCreditLimit(t *testing.T) {
 
 	type fields struct {
-		sqlx  sqlxmock.Sqlmock
-		redis redismock.ClientMock
+		sqlx sqlxmock.Sqlmock
 	}
 
 	tests := map[string]struct {
-		input    string
-		expected modelAccounts.Account
-		err      error
-		prepare  func(f *fields)
+		input   string
+		amount  modelMoney.Money
+		err     error
+		prepare func(f *fields)
 	}{
-		"should be able to get account by document": {
-			input: "11111111111",
+		"should be able to update available credit limit": {
+			input:  "id",
+			amount: modelMoney.MustParse("60"),
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE accounts SET available_credit_limit").WithArgs("60.00", "id").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+		},
+		"should not be able to update available credit limit with error at sqlx": {
+			input:  "id",
+			amount: modelMoney.MustParse("60"),
 			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE accounts SET available_credit_limit").WithArgs("60.00", "id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
 
-				f.redis.ExpectGet("account_document_11111111111").RedisNil()
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
 
-				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("11111111111").WillReturnRows(rows)
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
 
-				f.redis.ExpectSet("account_document_11111111111", utils.ToJSON(modelAccounts.Account{
-					ID:             "id",
-					DocumentNumber: "11111111111",
-				}), 10*time.Minute).SetVal("")
-			},
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
 
-			expected: modelAccounts.Account{
-				ID:             "id",
-				DocumentNumber: "11111111111",
+			err = store.UpdateAvailableCreditLimit(context.Background(), tt.input, tt.amount)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		input   string
+		err     error
+		prepare func(f *fields)
+	}{
+		"should be able to lock account": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT account_id FROM accounts WHERE account_id = \\$1 FOR UPDATE").WithArgs("id").WillReturnRows(f.sqlx.NewRows([]string{"account_id"}).AddRow("id"))
 			},
 		},
-		"should be able to get account by document with error to save at cache": {
-			input: "11111111111",
+		"should not be able to lock account not found": {
+			input: "id",
 			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT account_id FROM accounts").WithArgs("id").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
 
-				f.redis.ExpectGet("account_document_11111111111").RedisNil()
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
 
-				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("11111111111").WillReturnRows(rows)
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
 
-				f.redis.ExpectSet("account_document_11111111111", utils.ToJSON(modelAccounts.Account{
-					ID:             "id",
-					DocumentNumber: "11111111111",
-				}), 10*time.Minute).SetErr(fmt.Errorf("any"))
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			err = store.Lock(context.Background(), tt.input)
 
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
+
+func TestListByClosingDay(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		input    int
+		expected []modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list accounts by closing day": {
+			input: 10,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "currency", "statement_closing_day", "created_at"}).AddRow("id", "11111111111", "BRL", 10, time.Time{})
+
+				f.sqlx.ExpectQuery("FROM accounts WHERE statement_closing_day = \\$1").WithArgs(10).WillReturnRows(rows)
 			},
-			expected: modelAccounts.Account{
-				ID:             "id",
-				DocumentNumber: "11111111111",
+			expected: []modelAccounts.Account{{ID: "id", DocumentNumber: "11111111111", Currency: "BRL", StatementClosingDay: 10}},
+		},
+		"should not be able to list accounts by closing day with error at sqlx": {
+			input: 10,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("FROM accounts").WithArgs(10).WillReturnError(fmt.Errorf("any"))
 			},
+			err: fmt.Errorf("any"),
 		},
+	}
 
-		"should be able to get account by document with error to unmarshal from cache": {
-			input: "11111111111",
-			prepare: func(f *fields) {
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
 
-				f.redis.ExpectGet("account_document_11111111111").SetVal(`A`)
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
 
-				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "created_at"}).AddRow("id", "11111111111", time.Time{})
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
 
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("11111111111").WillReturnRows(rows)
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
 
-				f.redis.ExpectSet("account_document_11111111111", utils.ToJSON(modelAccounts.Account{
-					ID:             "id",
-					DocumentNumber: "11111111111",
-				}), 10*time.Minute).SetErr(fmt.Errorf("any"))
+			res, err := store.ListByClosingDay(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
 
+	createdAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	endDate := createdAt.Add(24 * time.Hour)
+
+	tests := map[string]struct {
+		input    modelAccounts.ListFilter
+		expected []modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list accounts": {
+			input: modelAccounts.ListFilter{Limit: 10},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "currency", "statement_closing_day", "status", "created_at"}).AddRow("id", "11111111111", "BRL", 10, "ACTIVE", createdAt)
+
+				f.sqlx.ExpectQuery("FROM accounts WHERE TRUE ORDER BY created_at DESC, account_id DESC LIMIT \\$1").WithArgs(10).WillReturnRows(rows)
 			},
-			expected: modelAccounts.Account{
-				ID:             "id",
-				DocumentNumber: "11111111111",
+			expected: []modelAccounts.Account{{ID: "id", DocumentNumber: "11111111111", Currency: "BRL", StatementClosingDay: 10, Status: "ACTIVE", CreatedAt: createdAt}},
+		},
+		"should be able to list accounts with every filter": {
+			input: modelAccounts.ListFilter{
+				DocumentNumber: "111",
+				StartDate:      createdAt,
+				EndDate:        endDate,
+				Limit:          10,
+				After:          &modelAccounts.Cursor{CreatedAt: endDate, AccountID: "last"},
 			},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WHERE TRUE AND document_number LIKE \\$1 \\|\\| '%' AND created_at >= \\$2 AND created_at <= \\$3 AND \\(created_at, account_id\\) < \\(\\$4, \\$5\\) ORDER BY created_at DESC, account_id DESC LIMIT \\$6").
+					WithArgs("111", createdAt, endDate, endDate, "last", 10).WillReturnRows(f.sqlx.NewRows([]string{"account_id"}))
+			},
+		},
+		"should not be able to list accounts with error at sqlx": {
+			input: modelAccounts.ListFilter{Limit: 10},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("FROM accounts").WithArgs(10).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
 		},
+	}
 
-		"should be able to get account by document in cache": {
-			input: "11111111111",
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.List(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	document := "22222222222"
+	closingDay := 10
+	limit := modelMoney.MustParse("1500")
+
+	tests := map[string]struct {
+		input    modelAccounts.Update
+		expected modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to update account": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &document},
 			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "currency", "statement_closing_day", "status"}).AddRow("id", "22222222222", "BRL", 1, "ACTIVE")
 
-				f.redis.ExpectGet("account_document_11111111111").SetVal(`{"account_id":"id", "document_number":"11111111111"}`)
+				f.sqlx.ExpectQuery("UPDATE accounts SET document_number = COALESCE\\(\\$1, document_number\\), statement_closing_day = COALESCE\\(\\$2, statement_closing_day\\), available_credit_limit = COALESCE\\(\\$3, available_credit_limit\\) WHERE account_id = \\$4").
+					WithArgs(&document, nil, nil, "id").WillReturnRows(rows)
+			},
+			expected: modelAccounts.Account{ID: "id", DocumentNumber: "22222222222", Currency: "BRL", StatementClosingDay: 1, Status: "ACTIVE"},
+		},
+		"should be able to update available credit limit": {
+			input: modelAccounts.Update{AccountID: "id", AvailableCreditLimit: &limit},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "available_credit_limit", "currency", "statement_closing_day", "status"}).
+					AddRow("id", "22222222222", "1500.00", "BRL", 1, "ACTIVE")
 
+				f.sqlx.ExpectQuery("UPDATE accounts SET").WithArgs(nil, nil, "1500.00", "id").WillReturnRows(rows)
 			},
-			expected: modelAccounts.Account{
-				ID:             "id",
-				DocumentNumber: "11111111111",
+			expected: modelAccounts.Account{ID: "id", DocumentNumber: "22222222222", AvailableCreditLimit: limit, Currency: "BRL", StatementClosingDay: 1, Status: "ACTIVE"},
+		},
+		"should not be able to update account with document number taken": {
+			input: modelAccounts.Update{AccountID: "id", DocumentNumber: &document},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("UPDATE accounts").WillReturnError(&pq.Error{Code: "23505"})
 			},
+			err: ErrDocumentNumberTaken,
 		},
-		"should not be able to get account by document with error at sqlx": {
-			input: "11111111111",
+		"should not be able to update account not found": {
+			input: modelAccounts.Update{AccountID: "id", StatementClosingDay: &closingDay},
 			prepare: func(f *fields) {
-
-				f.redis.ExpectGet("account_document_11111111111").RedisNil()
-
-				f.sqlx.ExpectQuery("SELECT account_id, document_number, created_at FROM accounts").WithArgs("11111111111").WillReturnError(fmt.Errorf("any"))
+				f.sqlx.ExpectQuery("UPDATE accounts").WithArgs(nil, &closingDay, nil, "id").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+		"should not be able to update account with error at sqlx": {
+			input: modelAccounts.Update{AccountID: "id", StatementClosingDay: &closingDay},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("UPDATE accounts").WillReturnError(fmt.Errorf("any"))
 			},
 			err: fmt.Errorf("any"),
 		},
@@ -337,22 +580,190 @@ func TestGetByDocument(t *testing.T) {
 			if err != nil {
 				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.Update(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestDeleteCache(t *testing.T) {
+
+	tests := map[string]struct {
+		input   modelAccounts.Account
+		prepare func(redis redismock.ClientMock)
+	}{
+		"should be able to delete account from cache": {
+			input: modelAccounts.Account{ID: "id", DocumentNumber: "11111111111"},
+			prepare: func(redis redismock.ClientMock) {
+				redis.ExpectDel("account_id_id").SetVal(2)
+			},
+		},
+		"should be able to delete account from cache with error": {
+			input: modelAccounts.Account{ID: "id", DocumentNumber: "11111111111"},
+			prepare: func(redis redismock.ClientMock) {
+				redis.ExpectDel("account_id_id").SetErr(fmt.Errorf("any"))
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
 			cacheDB, cacheMock := redismock.NewClientMock()
 
 			store := New(Options{
-				DB:    db,
 				Log:   logrus.New(),
 				Cache: cacheDB,
 			})
 
+			tt.prepare(cacheMock)
+
+			store.DeleteCache(context.Background(), tt.input)
+
+			if err := cacheMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	change := modelAccounts.StatusChange{AccountID: "id", FromStatus: modelAccounts.StatusActive, ToStatus: modelAccounts.StatusBlocked, Reason: "suspected fraud"}
+
+	tests := map[string]struct {
+		input    modelAccounts.StatusChange
+		expected modelAccounts.Account
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to update account status": {
+			input: change,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"account_id", "document_number", "currency", "statement_closing_day", "status", "created_at"}).AddRow("id", "11111111111", "BRL", 10, "BLOCKED", time.Time{})
+
+				f.sqlx.ExpectQuery("WITH account AS \\( UPDATE accounts SET status = \\? WHERE account_id = CAST\\(\\? AS UUID\\) AND status = \\? .* INSERT INTO accounts_status_history").
+					WithArgs("BLOCKED", "id", "ACTIVE", "ACTIVE", "BLOCKED", "suspected fraud").WillReturnRows(rows)
+			},
+			expected: modelAccounts.Account{ID: "id", DocumentNumber: "11111111111", Currency: "BRL", StatementClosingDay: 10, Status: modelAccounts.StatusBlocked},
+		},
+		"should not be able to update account status changed meanwhile": {
+			input: change,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WITH account AS").WillReturnRows(f.sqlx.NewRows([]string{"account_id"}))
+			},
+			err: ErrStatusChanged,
+		},
+		"should not be able to update account status with error at sqlx": {
+			input: change,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WITH account AS").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
 			tt.prepare(&fields{
-				sqlx:  mock,
-				redis: cacheMock,
+				sqlx: mock,
 			})
 
-			res, err := store.GetByDocument(context.Background(), tt.input)
+			res, err := store.UpdateStatus(context.Background(), tt.input)
 
-			if err != nil && err.Error() != tt.err.Error() {
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListStatusHistory(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	changedAt := time.Date(2024, 2, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected []modelAccounts.StatusChange
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list account status history": {
+			input: "id",
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"change_id", "account_id", "from_status", "to_status", "reason", "changed_at"}).AddRow(1, "id", "ACTIVE", "BLOCKED", "suspected fraud", changedAt)
+
+				f.sqlx.ExpectQuery("SELECT change_id, account_id, from_status, to_status, reason, changed_at FROM accounts_status_history WHERE account_id = \\$1").WithArgs("id").WillReturnRows(rows)
+			},
+			expected: []modelAccounts.StatusChange{{ChangeID: 1, AccountID: "id", FromStatus: "ACTIVE", ToStatus: "BLOCKED", Reason: "suspected fraud", ChangedAt: changedAt}},
+		},
+		"should not be able to list account status history with error at sqlx": {
+			input: "id",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("FROM accounts_status_history").WithArgs("id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.ListStatusHistory(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
 			}
 			if !reflect.DeepEqual(res, tt.expected) {
@@ -0,0 +1,84 @@
+package accruals
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/accruals_mock.go -package=mocksStore
+type IAccruals interface {
+	Create(ctx context.Context, accrual modelAccruals.Accrual) (modelAccruals.Accrual, error)
+	ListOverdueAccountIDs(ctx context.Context, date time.Time) ([]string, error)
+}
+
+// ErrAlreadyAccrued is returned by Create when the interest of the day, or the late fee of the statement, was already
+// charged.
+var ErrAlreadyAccrued = modelErrors.Conflict("ALREADY_ACCRUED", "already accrued")
+
+type Options struct {
+	DB  sqlx.ExtContext
+	Log *logrus.Logger
+}
+
+type accruals struct {
+	db  sqlx.ExtContext
+	log *logrus.Logger
+}
+
+func New(opts Options) IAccruals {
+	return accruals{
+		db:  opts.DB,
+		log: opts.Log,
+	}
+}
+
+// Create records the charge, which must run inside the database transaction that made its transaction.
+func (a accruals) Create(ctx context.Context, accrual modelAccruals.Accrual) (modelAccruals.Accrual, error) {
+
+	rows, err := sqlx.NamedQueryContext(ctx, a.db, `INSERT INTO accruals (account_id, transaction_id, operation_type_id, currency, amount, accrual_date, statement_id)
+	VALUES (:account_id, :transaction_id, :operation_type_id, :currency, :amount, :accrual_date, :statement_id)
+	ON CONFLICT DO NOTHING
+	RETURNING accrual_id, account_id, transaction_id, operation_type_id, currency, amount, accrual_date, statement_id, created_at`, accrual)
+	if err != nil {
+		a.log.WithField("body", accrual).Error(err)
+		return accrual, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			a.log.WithField("body", accrual).Error(err)
+			return accrual, err
+		}
+		return accrual, ErrAlreadyAccrued
+	}
+
+	var res modelAccruals.Accrual
+	if err := rows.StructScan(&res); err != nil {
+		a.log.WithField("body", accrual).Error(err)
+		return accrual, err
+	}
+
+	return res, nil
+}
+
+// ListOverdueAccountIDs returns the accounts with a statement that closed owing and was due before the date, the ones that
+// may have overdue debits at it.
+func (a accruals) ListOverdueAccountIDs(ctx context.Context, date time.Time) ([]string, error) {
+
+	var accountIDs []string
+	err := sqlx.SelectContext(ctx, a.db, &accountIDs, `SELECT DISTINCT account_id FROM statements
+	WHERE closing_balance > 0 AND due_date < $1
+	ORDER BY account_id`, date)
+	if err != nil {
+		a.log.WithField("date", date).Error(err)
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
@@ -0,0 +1,150 @@
+package accruals
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+var (
+	accrualDate = time.Date(2024, 2, 21, 0, 0, 0, 0, time.UTC)
+	createdAt   = time.Date(2024, 2, 21, 0, 5, 0, 0, time.UTC)
+	statementID = "statement_id"
+)
+
+var accrualColumns = []string{"accrual_id", "account_id", "transaction_id", "operation_type_id", "currency", "amount", "accrual_date", "statement_id", "created_at"}
+
+func accrual() modelAccruals.Accrual {
+	return modelAccruals.Accrual{
+		AccrualID:       1,
+		AccountID:       "id",
+		TransactionID:   "transaction_id",
+		OperationTypeID: 8,
+		Currency:        "BRL",
+		Amount:          modelMoney.MustParse("2"),
+		AccrualDate:     accrualDate,
+		StatementID:     &statementID,
+		CreatedAt:       createdAt,
+	}
+}
+
+func TestCreate(t *testing.T) {
+
+	input := accrual()
+	input.AccrualID, input.CreatedAt = 0, time.Time{}
+
+	tests := map[string]struct {
+		input    modelAccruals.Accrual
+		expected modelAccruals.Accrual
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to create accrual": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO accruals").
+					WithArgs("id", "transaction_id", 8, "BRL", "2.00", accrualDate, &statementID).
+					WillReturnRows(mock.NewRows(accrualColumns).AddRow(1, "id", "transaction_id", 8, "BRL", "2.00", accrualDate, statementID, createdAt))
+			},
+			expected: accrual(),
+		},
+		"should not be able to create accrual already accrued": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO accruals .* ON CONFLICT DO NOTHING").WillReturnRows(mock.NewRows(accrualColumns))
+			},
+			expected: input,
+			err:      ErrAlreadyAccrued,
+		},
+		"should not be able to create accrual with error at sqlx": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO accruals").WillReturnError(fmt.Errorf("any"))
+			},
+			expected: input,
+			err:      fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Create(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListOverdueAccountIDs(t *testing.T) {
+
+	tests := map[string]struct {
+		expected []string
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to list overdue account ids": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DISTINCT account_id FROM statements WHERE closing_balance > 0 AND due_date < \\$1").
+					WithArgs(accrualDate).WillReturnRows(mock.NewRows([]string{"account_id"}).AddRow("id").AddRow("other_id"))
+			},
+			expected: []string{"id", "other_id"},
+		},
+		"should not be able to list overdue account ids with error at sqlx": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT DISTINCT account_id FROM statements").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.ListOverdueAccountIDs(context.Background(), accrualDate)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package allocations
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/allocations_mock.go -package=mocksStore
+type IAllocations interface {
+	Create(ctx context.Context, allocations []modelAllocations.Allocation) error
+	ListByTransactionID(ctx context.Context, transactionID string) ([]modelAllocations.Allocation, error)
+}
+
+type Options struct {
+	DB  sqlx.ExtContext
+	Log *logrus.Logger
+}
+
+type allocations struct {
+	db  sqlx.ExtContext
+	log *logrus.Logger
+}
+
+func New(opts Options) IAllocations {
+	return allocations{
+		db:  opts.DB,
+		log: opts.Log,
+	}
+}
+
+// Create appends the allocations, which only accept inserts. It must run inside the database transaction that posted
+// their ledger entry.
+func (a allocations) Create(ctx context.Context, allocations []modelAllocations.Allocation) error {
+
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, a.db, `INSERT INTO allocations (entry_id, payment_id, debit_id, amount, currency, reversal_id)
+	VALUES (:entry_id, :payment_id, :debit_id, :amount, :currency, :reversal_id)`, allocations)
+	if err != nil {
+		a.log.WithField("body", allocations).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ListByTransactionID returns the allocations a transaction took part in, as the payment or as the debit, in the order they
+// were applied. The allocations of a purchase in installments are the ones of its installments.
+func (a allocations) ListByTransactionID(ctx context.Context, transactionID string) ([]modelAllocations.Allocation, error) {
+
+	var allocations []modelAllocations.Allocation
+	err := sqlx.SelectContext(ctx, a.db, &allocations, `SELECT allocation_id, entry_id, payment_id, debit_id, amount, currency, reversal_id, applied_at
+	FROM allocations
+	WHERE payment_id = $1 OR debit_id = $1 OR debit_id IN (SELECT transaction_id FROM transactions WHERE parent_transaction_id = $1)
+	ORDER BY applied_at, allocation_id;
+	`, transactionID)
+
+	if err != nil {
+		a.log.WithField("transaction_id", transactionID).Error(err)
+		return nil, err
+	}
+
+	return allocations, nil
+}
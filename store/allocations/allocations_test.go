@@ -0,0 +1,138 @@
+package allocations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	modelAllocations "github.com/jorgepiresg/ChallangePismo/model/allocations"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestCreate(t *testing.T) {
+
+	reversalID := "reversal_id"
+
+	tests := map[string]struct {
+		input   []modelAllocations.Allocation
+		err     error
+		prepare func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to create allocations": {
+			input: []modelAllocations.Allocation{
+				{EntryID: "entry", PaymentID: "payment", DebitID: "1", Amount: modelMoney.MustParse("50"), Currency: "BRL"},
+				{EntryID: "entry", PaymentID: "payment", DebitID: "2", Amount: modelMoney.MustParse("-10"), Currency: "BRL", ReversalID: &reversalID},
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO allocations \\(entry_id, payment_id, debit_id, amount, currency, reversal_id\\)").
+					WithArgs("entry", "payment", "1", "50.00", "BRL", nil, "entry", "payment", "2", "-10.00", "BRL", "reversal_id").
+					WillReturnResult(sqlxmock.NewResult(0, 2))
+			},
+		},
+		"should be able to create no allocations": {
+			input:   nil,
+			prepare: func(mock sqlxmock.Sqlmock) {},
+		},
+		"should not be able to create allocations with error at sqlx": {
+			input: []modelAllocations.Allocation{
+				{EntryID: "entry", PaymentID: "payment", DebitID: "1", Amount: modelMoney.MustParse("50"), Currency: "BRL"},
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO allocations").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			err = store.Create(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestListByTransactionID(t *testing.T) {
+
+	appliedAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	reversalID := "reversal_id"
+
+	tests := map[string]struct {
+		input    string
+		expected []modelAllocations.Allocation
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to list allocations": {
+			input: "debit",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"allocation_id", "entry_id", "payment_id", "debit_id", "amount", "currency", "reversal_id", "applied_at"}).
+					AddRow(1, "entry", "payment", "debit", "50.00", "BRL", nil, appliedAt).
+					AddRow(2, "reversal_entry", "payment", "debit", "-20.00", "BRL", "reversal_id", appliedAt)
+
+				mock.ExpectQuery("SELECT allocation_id, entry_id, payment_id, debit_id, amount, currency, reversal_id, applied_at FROM allocations WHERE payment_id = \\$1 OR debit_id = \\$1").
+					WithArgs("debit").WillReturnRows(rows)
+			},
+			expected: []modelAllocations.Allocation{
+				{AllocationID: 1, EntryID: "entry", PaymentID: "payment", DebitID: "debit", Amount: modelMoney.MustParse("50"), Currency: "BRL", AppliedAt: appliedAt},
+				{AllocationID: 2, EntryID: "reversal_entry", PaymentID: "payment", DebitID: "debit", Amount: modelMoney.MustParse("-20"), Currency: "BRL", ReversalID: &reversalID, AppliedAt: appliedAt},
+			},
+		},
+		"should not be able to list allocations with error at sqlx": {
+			input: "debit",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT allocation_id").WithArgs("debit").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.ListByTransactionID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrRateNotFound = modelErrors.NotFound("EXCHANGE_RATE_NOT_FOUND", "exchange rate not found")
+
+// IRates provides exchange rates between ISO 4217 currencies. Implementations may be backed by anything, from a static
+// table to a market data service.
+//
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/fx_mock.go -package=mocksStore
+type IRates interface {
+	// Rate returns how much one unit of from is worth in to.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// Options configures the static rates provider. Rates are keyed by source then target currency, e.g.
+// {"USD": {"BRL": "4.95"}}, and File, when set, is a JSON file in the same format loaded on top of them.
+type Options struct {
+	Rates map[string]map[string]string
+	File  string
+	Log   *logrus.Logger
+}
+
+type static struct {
+	rates map[string]map[string]*big.Rat
+	log   *logrus.Logger
+}
+
+func New(opts Options) (IRates, error) {
+
+	s := static{
+		rates: map[string]map[string]*big.Rat{},
+		log:   opts.Log,
+	}
+
+	if err := s.load(opts.Rates); err != nil {
+		return nil, err
+	}
+
+	if opts.File == "" {
+		return s, nil
+	}
+
+	file, err := os.ReadFile(opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates map[string]map[string]json.Number
+	if err := json.Unmarshal(file, &rates); err != nil {
+		return nil, fmt.Errorf("fx rates file invalid: %w", err)
+	}
+
+	fromFile := map[string]map[string]string{}
+	for from, targets := range rates {
+		fromFile[from] = map[string]string{}
+		for to, rate := range targets {
+			fromFile[from][to] = rate.String()
+		}
+	}
+
+	if err := s.load(fromFile); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Rate looks for the rate from -> to and falls back to the inverse of to -> from. The same currency always has rate 1.
+func (s static) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	if rate, ok := s.rates[from][to]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+
+	if rate, ok := s.rates[to][from]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+
+	s.log.WithField("from", from).WithField("to", to).Warning(ErrRateNotFound)
+	return nil, ErrRateNotFound
+}
+
+func (s static) load(rates map[string]map[string]string) error {
+
+	for from, targets := range rates {
+
+		from = modelMoney.CleanCurrency(from)
+		if !modelMoney.ValidCurrency(from) {
+			return fmt.Errorf("fx rate currency invalid: %s", from)
+		}
+
+		for to, value := range targets {
+
+			to = modelMoney.CleanCurrency(to)
+			if !modelMoney.ValidCurrency(to) {
+				return fmt.Errorf("fx rate currency invalid: %s", to)
+			}
+
+			rate, ok := new(big.Rat).SetString(value)
+			if !ok || rate.Sign() <= 0 {
+				return fmt.Errorf("fx rate invalid: %s/%s", from, to)
+			}
+
+			if s.rates[from] == nil {
+				s.rates[from] = map[string]*big.Rat{}
+			}
+			s.rates[from][to] = rate
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestNew(t *testing.T) {
+
+	tests := map[string]struct {
+		input   Options
+		content string
+		err     error
+	}{
+		"should be able to create with static rates": {
+			input: Options{Rates: map[string]map[string]string{"usd": {"BRL": "4.95"}}},
+		},
+		"should be able to create with rates file": {
+			content: `{"USD": {"BRL": 4.95, "EUR": "0.92"}}`,
+		},
+		"should not be able to create with unknown currency": {
+			input: Options{Rates: map[string]map[string]string{"USD": {"XXX": "1"}}},
+			err:   fmt.Errorf("fx rate currency invalid: XXX"),
+		},
+		"should not be able to create with non positive rate": {
+			input: Options{Rates: map[string]map[string]string{"USD": {"BRL": "0"}}},
+			err:   fmt.Errorf("fx rate invalid: USD/BRL"),
+		},
+		"should not be able to create with invalid rates file": {
+			content: `["USD"]`,
+			err:     fmt.Errorf("fx rates file invalid: json: cannot unmarshal array into Go value of type map[string]map[string]json.Number"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			tt.input.Log = logrus.New()
+
+			if tt.content != "" {
+				tt.input.File = filepath.Join(t.TempDir(), "rates.json")
+				if err := os.WriteFile(tt.input.File, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := New(tt.input)
+
+			if (err == nil) != (tt.err == nil) || err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%v" got "%v"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+
+	rates, err := New(Options{
+		Rates: map[string]map[string]string{"USD": {"BRL": "5"}},
+		Log:   logrus.New(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		from     string
+		to       string
+		expected string
+		err      error
+	}{
+		"should be able to get rate":                  {from: "USD", to: "BRL", expected: "5"},
+		"should be able to get inverse rate":          {from: "BRL", to: "USD", expected: "1/5"},
+		"should be able to get same currency rate":    {from: "EUR", to: "EUR", expected: "1"},
+		"should not be able to get rate not provided": {from: "USD", to: "EUR", err: ErrRateNotFound},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res, err := rates.Rate(context.Background(), tt.from, tt.to)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && res.RatString() != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res.RatString())
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/health_mock.go -package=mocksStore
+type IHealth interface {
+	PingDatabase(ctx context.Context) error
+	PingCache(ctx context.Context) error
+}
+
+var errCacheNotConfigured = errors.New("cache not configured")
+
+type Options struct {
+	DB    sqlx.ExtContext
+	Log   *logrus.Logger
+	Cache *redis.Client
+}
+
+type health struct {
+	db    sqlx.ExtContext
+	log   *logrus.Logger
+	cache *redis.Client
+}
+
+func New(opts Options) IHealth {
+	return health{
+		db:    opts.DB,
+		log:   opts.Log,
+		cache: opts.Cache,
+	}
+}
+
+// PingDatabase runs a query on the database, so it fails when no connection can be made or used.
+func (h health) PingDatabase(ctx context.Context) error {
+	if _, err := h.db.ExecContext(ctx, `SELECT 1`); err != nil {
+		h.log.Warning(err)
+		return err
+	}
+	return nil
+}
+
+func (h health) PingCache(ctx context.Context) error {
+
+	if h.cache == nil {
+		return errCacheNotConfigured
+	}
+
+	if err := h.cache.Ping(ctx).Err(); err != nil {
+		h.log.Warning(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-redis/redismock/v8"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPingDatabase(t *testing.T) {
+
+	tests := map[string]struct {
+		err     error
+		prepare func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to ping database": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectExec("SELECT 1").WillReturnResult(sqlxmock.NewResult(0, 1))
+			},
+		},
+		"should not be able to ping database with error at sqlx": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectExec("SELECT 1").WillReturnError(fmt.Errorf("connection refused"))
+			},
+			err: fmt.Errorf("connection refused"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			err = store.PingDatabase(context.Background())
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPingCache(t *testing.T) {
+
+	tests := map[string]struct {
+		noCache bool
+		err     error
+		prepare func(mock redismock.ClientMock)
+	}{
+		"should be able to ping cache": {
+			prepare: func(mock redismock.ClientMock) {
+				mock.ExpectPing().SetVal("PONG")
+			},
+		},
+		"should not be able to ping cache with error at redis": {
+			prepare: func(mock redismock.ClientMock) {
+				mock.ExpectPing().SetErr(fmt.Errorf("connection refused"))
+			},
+			err: fmt.Errorf("connection refused"),
+		},
+		"should not be able to ping cache not configured": {
+			noCache: true,
+			prepare: func(mock redismock.ClientMock) {},
+			err:     errCacheNotConfigured,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			cache, mock := redismock.NewClientMock()
+
+			opts := Options{
+				Log:   logrus.New(),
+				Cache: cache,
+			}
+			if tt.noCache {
+				opts.Cache = nil
+			}
+
+			tt.prepare(mock)
+
+			err := New(opts).PingCache(context.Background())
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
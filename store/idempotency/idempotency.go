@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/idempotency_mock.go -package=mocksStore
+type IIdempotency interface {
+	Create(ctx context.Context, record modelIdempotency.Record) (bool, error)
+	GetByKey(ctx context.Context, key string) (modelIdempotency.Record, error)
+	Complete(ctx context.Context, record modelIdempotency.Record) error
+	SetTransaction(ctx context.Context, key, transactionID string) error
+	Delete(ctx context.Context, key string) error
+}
+
+type Options struct {
+	DB    sqlx.ExtContext
+	Log   *logrus.Logger
+	Cache *redis.Client
+
+	// Tasks tracks the cache writes left running after a query.
+	Tasks *utils.Tasks
+}
+
+type idempotency struct {
+	db    sqlx.ExtContext
+	log   *logrus.Logger
+	cache *redis.Client
+	tasks *utils.Tasks
+}
+
+func New(opts Options) IIdempotency {
+	return idempotency{
+		db:    opts.DB,
+		log:   opts.Log,
+		cache: opts.Cache,
+		tasks: opts.Tasks,
+	}
+}
+
+// Create reserves the key for a new request. It returns false when the key is already taken, unless it belongs to a
+// request that never completed nor made its transaction and was abandoned, which is then taken over.
+func (i idempotency) Create(ctx context.Context, record modelIdempotency.Record) (bool, error) {
+
+	rows, err := sqlx.NamedQueryContext(ctx, i.db, `INSERT INTO idempotency_keys (idempotency_key, request_hash) VALUES (:idempotency_key, :request_hash)
+	ON CONFLICT (idempotency_key) DO UPDATE SET request_hash = EXCLUDED.request_hash, created_at = CURRENT_TIMESTAMP
+	WHERE idempotency_keys.status_code = 0 AND idempotency_keys.transaction_id IS NULL AND
+	idempotency_keys.created_at < CURRENT_TIMESTAMP - INTERVAL '10 minutes'
+	RETURNING idempotency_key`, record)
+	if err != nil {
+		i.log.WithField("idempotency_key", record.Key).Error(err)
+		return false, err
+	}
+	defer rows.Close()
+
+	return rows.Next(), rows.Err()
+}
+
+func (i idempotency) GetByKey(ctx context.Context, key string) (modelIdempotency.Record, error) {
+
+	var record modelIdempotency.Record
+
+	cacheKey := fmt.Sprintf("idempotency_key_%s", key)
+
+	if err := i.getCache(ctx, cacheKey, &record); err == nil && record.Completed() {
+		return record, nil
+	}
+
+	err := sqlx.GetContext(ctx, i.db, &record, `SELECT idempotency_key, request_hash, status_code, COALESCE(body, '') AS body, transaction_id, created_at FROM idempotency_keys WHERE idempotency_key = $1`, key)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			i.log.WithField("idempotency_key", key).Error(err)
+		}
+		return record, err
+	}
+
+	if record.Completed() {
+		i.tasks.Go(func() { i.setCache(tracing.Detach(ctx), cacheKey, record) })
+	}
+
+	return record, nil
+}
+
+func (i idempotency) Complete(ctx context.Context, record modelIdempotency.Record) error {
+
+	_, err := i.db.ExecContext(ctx, `UPDATE idempotency_keys SET status_code = $1, body = $2 WHERE idempotency_key = $3`, record.StatusCode, record.Body, record.Key)
+	if err != nil {
+		i.log.WithField("idempotency_key", record.Key).Error(err)
+		return err
+	}
+
+	i.setCache(ctx, fmt.Sprintf("idempotency_key_%s", record.Key), record)
+
+	return nil
+}
+
+// SetTransaction records the transaction made by the request of the key. It must run in the database transaction that made
+// it, so the key of a request is never taken over once its transaction is committed.
+func (i idempotency) SetTransaction(ctx context.Context, key, transactionID string) error {
+
+	_, err := i.db.ExecContext(ctx, `UPDATE idempotency_keys SET transaction_id = $1 WHERE idempotency_key = $2`, transactionID, key)
+	if err != nil {
+		i.log.WithField("idempotency_key", key).WithField("transaction_id", transactionID).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Delete releases the key of a request that did not complete, unless it made its transaction.
+func (i idempotency) Delete(ctx context.Context, key string) error {
+
+	_, err := i.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND status_code = 0 AND transaction_id IS NULL`, key)
+	if err != nil {
+		i.log.WithField("idempotency_key", key).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (i idempotency) setCache(ctx context.Context, key string, record modelIdempotency.Record) {
+	err := i.cache.Set(ctx, key, utils.ToJSON(record), 24*time.Hour).Err()
+	if err != nil {
+		i.log.WithField("cache_key", key).Warning(err)
+	}
+}
+
+func (i idempotency) getCache(ctx context.Context, key string, record *modelIdempotency.Record) error {
+	res, err := i.cache.Get(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if err := utils.FromJson(res, record); err != nil {
+		i.log.WithField("cache_key", key).Error(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v8"
+	modelIdempotency "github.com/jorgepiresg/ChallangePismo/model/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/utils"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestCreate(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		input    modelIdempotency.Record
+		expected bool
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to reserve key": {
+			input: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"idempotency_key"}).AddRow("key")
+				f.sqlx.ExpectQuery("INSERT INTO idempotency_keys").WithArgs("key", "hash").WillReturnRows(rows)
+			},
+			expected: true,
+		},
+		"should not be able to reserve key already taken": {
+			input: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"idempotency_key"})
+				f.sqlx.ExpectQuery("INSERT INTO idempotency_keys").WithArgs("key", "hash").WillReturnRows(rows)
+			},
+		},
+		"should not be able to take over key whose transaction was made": {
+			input: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"idempotency_key"})
+				f.sqlx.ExpectQuery(`ON CONFLICT .+ WHERE idempotency_keys.status_code = 0 AND idempotency_keys.transaction_id IS NULL AND`).WithArgs("key", "hash").WillReturnRows(rows)
+			},
+		},
+		"should not be able to reserve key with error at sqlx": {
+			input: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("INSERT INTO idempotency_keys").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.Create(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetByKey(t *testing.T) {
+
+	type fields struct {
+		sqlx  sqlxmock.Sqlmock
+		redis redismock.ClientMock
+	}
+
+	completed := modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}
+	transactionID := "transaction_id"
+
+	tests := map[string]struct {
+		input    string
+		expected modelIdempotency.Record
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get completed record in cache": {
+			input: "key",
+			prepare: func(f *fields) {
+				f.redis.ExpectGet("idempotency_key_key").SetVal(string(utils.ToJSON(completed)))
+			},
+			expected: completed,
+		},
+		"should be able to get completed record from database": {
+			input: "key",
+			prepare: func(f *fields) {
+				f.redis.ExpectGet("idempotency_key_key").RedisNil()
+
+				rows := f.sqlx.NewRows([]string{"idempotency_key", "request_hash", "status_code", "body", "created_at"}).AddRow("key", "hash", 201, "", time.Time{})
+				f.sqlx.ExpectQuery("SELECT idempotency_key, request_hash, status_code").WithArgs("key").WillReturnRows(rows)
+
+				f.redis.ExpectSet("idempotency_key_key", utils.ToJSON(completed), 24*time.Hour).SetVal("")
+			},
+			expected: completed,
+		},
+		"should be able to get pending record from database": {
+			input: "key",
+			prepare: func(f *fields) {
+				f.redis.ExpectGet("idempotency_key_key").RedisNil()
+
+				rows := f.sqlx.NewRows([]string{"idempotency_key", "request_hash", "status_code", "body", "created_at"}).AddRow("key", "hash", 0, "", time.Time{})
+				f.sqlx.ExpectQuery("SELECT idempotency_key, request_hash, status_code").WithArgs("key").WillReturnRows(rows)
+			},
+			expected: modelIdempotency.Record{Key: "key", RequestHash: "hash"},
+		},
+		"should be able to get pending record whose transaction was made": {
+			input: "key",
+			prepare: func(f *fields) {
+				f.redis.ExpectGet("idempotency_key_key").RedisNil()
+
+				rows := f.sqlx.NewRows([]string{"idempotency_key", "request_hash", "status_code", "body", "transaction_id", "created_at"}).AddRow("key", "hash", 0, "", "transaction_id", time.Time{})
+				f.sqlx.ExpectQuery("SELECT idempotency_key, request_hash, status_code").WithArgs("key").WillReturnRows(rows)
+			},
+			expected: modelIdempotency.Record{Key: "key", RequestHash: "hash", TransactionID: &transactionID},
+		},
+		"should not be able to get record not found": {
+			input: "key",
+			prepare: func(f *fields) {
+				f.redis.ExpectGet("idempotency_key_key").RedisNil()
+				f.sqlx.ExpectQuery("SELECT idempotency_key, request_hash, status_code").WithArgs("key").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			cacheDB, cacheMock := redismock.NewClientMock()
+
+			store := New(Options{
+				DB:    db,
+				Log:   logrus.New(),
+				Cache: cacheDB,
+			})
+
+			tt.prepare(&fields{
+				sqlx:  mock,
+				redis: cacheMock,
+			})
+
+			res, err := store.GetByKey(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+
+	type fields struct {
+		sqlx  sqlxmock.Sqlmock
+		redis redismock.ClientMock
+	}
+
+	record := modelIdempotency.Record{Key: "key", RequestHash: "hash", StatusCode: 201}
+
+	tests := map[string]struct {
+		input   modelIdempotency.Record
+		err     error
+		prepare func(f *fields)
+	}{
+		"should be able to complete record": {
+			input: record,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE idempotency_keys SET status_code").WithArgs(201, "", "key").WillReturnResult(sqlxmock.NewResult(1, 1))
+				f.redis.ExpectSet("idempotency_key_key", utils.ToJSON(record), 24*time.Hour).SetVal("")
+			},
+		},
+		"should not be able to complete record with error at sqlx": {
+			input: record,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE idempotency_keys SET status_code").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			cacheDB, cacheMock := redismock.NewClientMock()
+
+			store := New(Options{
+				DB:    db,
+				Log:   logrus.New(),
+				Cache: cacheDB,
+			})
+
+			tt.prepare(&fields{
+				sqlx:  mock,
+				redis: cacheMock,
+			})
+
+			err = store.Complete(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSetTransaction(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		key           string
+		transactionID string
+		err           error
+		prepare       func(f *fields)
+	}{
+		"should be able to set transaction": {
+			key:           "key",
+			transactionID: "transaction_id",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE idempotency_keys SET transaction_id").WithArgs("transaction_id", "key").WillReturnResult(sqlxmock.NewResult(1, 1))
+			},
+		},
+		"should not be able to set transaction with error at sqlx": {
+			key:           "key",
+			transactionID: "transaction_id",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec("UPDATE idempotency_keys SET transaction_id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			err = store.SetTransaction(context.Background(), tt.key, tt.transactionID)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+		})
+	}
+}
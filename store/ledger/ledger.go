@@ -0,0 +1,100 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/ledger_mock.go -package=mocksStore
+type ILedger interface {
+	Post(ctx context.Context, entry modelLedger.Entry) (modelLedger.Entry, error)
+	GetSettlements(ctx context.Context, transactionID string) ([]modelLedger.Settlement, error)
+}
+
+type Options struct {
+	DB  sqlx.ExtContext
+	Log *logrus.Logger
+}
+
+type ledger struct {
+	db  sqlx.ExtContext
+	log *logrus.Logger
+}
+
+func New(opts Options) ILedger {
+	return ledger{
+		db:  opts.DB,
+		log: opts.Log,
+	}
+}
+
+// Post appends the entry and its postings to the journal. The journal is append-only and the database refuses, at commit,
+// entries whose debits and credits differ, so Post must run inside the database transaction of the movement it records.
+func (l ledger) Post(ctx context.Context, entry modelLedger.Entry) (modelLedger.Entry, error) {
+
+	if err := entry.Valid(); err != nil {
+		l.log.WithField("body", entry).Error(err)
+		return entry, err
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, l.db, `INSERT INTO ledger_entries (account_id, transaction_id, kind) VALUES (:account_id, :transaction_id, :kind) RETURNING entry_id, created_at`, entry)
+	if err != nil {
+		l.log.WithField("body", entry).Error(err)
+		return entry, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.Scan(&entry.EntryID, &entry.CreatedAt); err != nil {
+			l.log.WithField("body", entry).Error(err)
+			return entry, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		l.log.WithField("body", entry).Error(err)
+		return entry, err
+	}
+	rows.Close()
+
+	for i := range entry.Postings {
+		entry.Postings[i].EntryID = entry.EntryID
+	}
+
+	_, err = sqlx.NamedExecContext(ctx, l.db, `INSERT INTO ledger_postings (entry_id, account_id, ledger, transaction_id, currency, debit, credit, counterpart_transaction_id)
+	VALUES (:entry_id, :account_id, :ledger, :transaction_id, :currency, :debit, :credit, :counterpart_transaction_id)`, entry.Postings)
+	if err != nil {
+		l.log.WithField("body", entry).Error(err)
+		return entry, err
+	}
+
+	return entry, nil
+}
+
+// GetSettlements returns the counterparts the transaction is still settled with, their operation type and the net amount of
+// each, most recently settled first: the payments applied to a debit, or the debits a payment was applied to.
+func (l ledger) GetSettlements(ctx context.Context, transactionID string) ([]modelLedger.Settlement, error) {
+
+	var settlements []modelLedger.Settlement
+	err := sqlx.SelectContext(ctx, l.db, &settlements, `SELECT p.counterpart_transaction_id AS transaction_id, c.operation_type_id,
+	SUM(CASE WHEN p.ledger = 'RECEIVABLE' THEN p.credit - p.debit ELSE p.debit - p.credit END) AS amount,
+	MAX(e.created_at) AS settled_at
+	FROM ledger_postings p
+	JOIN ledger_entries e ON e.entry_id = p.entry_id
+	JOIN transactions c ON c.transaction_id = p.counterpart_transaction_id
+	WHERE p.transaction_id = $1 AND p.ledger IN ('RECEIVABLE', 'ACCOUNT') AND p.counterpart_transaction_id IS NOT NULL
+	GROUP BY p.counterpart_transaction_id, c.operation_type_id
+	HAVING SUM(CASE WHEN p.ledger = 'RECEIVABLE' THEN p.credit - p.debit ELSE p.debit - p.credit END) > 0
+	ORDER BY settled_at DESC;
+	`, transactionID)
+
+	if err != nil {
+		l.log.WithField("transaction_id", transactionID).Error(err)
+		return nil, err
+	}
+
+	return settlements, nil
+}
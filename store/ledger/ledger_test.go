@@ -0,0 +1,191 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	modelLedger "github.com/jorgepiresg/ChallangePismo/model/ledger"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestPost(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	createdAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	entry := modelLedger.Entry{
+		AccountID:     "id",
+		TransactionID: "1",
+		Kind:          modelLedger.KindTransaction,
+		Postings: []modelLedger.Posting{
+			{AccountID: "id", Ledger: modelLedger.LedgerReceivable, TransactionID: "1", Currency: "BRL", Debit: modelMoney.MustParse("10")},
+			{AccountID: "id", Ledger: modelLedger.LedgerCash, TransactionID: "1", Currency: "BRL", Credit: modelMoney.MustParse("10")},
+		},
+	}
+
+	tests := map[string]struct {
+		input    modelLedger.Entry
+		expected modelLedger.Entry
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to post entry": {
+			input: entry,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"entry_id", "created_at"}).AddRow("entry", createdAt)
+
+				f.sqlx.ExpectQuery("INSERT INTO ledger_entries").WithArgs("id", "1", modelLedger.KindTransaction).WillReturnRows(rows)
+				f.sqlx.ExpectExec("INSERT INTO ledger_postings").
+					WithArgs("entry", "id", modelLedger.LedgerReceivable, "1", "BRL", "10.00", "0.00", nil, "entry", "id", modelLedger.LedgerCash, "1", "BRL", "0.00", "10.00", nil).
+					WillReturnResult(sqlxmock.NewResult(0, 2))
+			},
+			expected: modelLedger.Entry{
+				EntryID:       "entry",
+				AccountID:     "id",
+				TransactionID: "1",
+				Kind:          modelLedger.KindTransaction,
+				CreatedAt:     createdAt,
+				Postings: []modelLedger.Posting{
+					{EntryID: "entry", AccountID: "id", Ledger: modelLedger.LedgerReceivable, TransactionID: "1", Currency: "BRL", Debit: modelMoney.MustParse("10")},
+					{EntryID: "entry", AccountID: "id", Ledger: modelLedger.LedgerCash, TransactionID: "1", Currency: "BRL", Credit: modelMoney.MustParse("10")},
+				},
+			},
+		},
+		"should not be able to post unbalanced entry": {
+			input: modelLedger.Entry{
+				Postings: []modelLedger.Posting{{Currency: "BRL", Debit: modelMoney.MustParse("10")}},
+			},
+			prepare: func(f *fields) {},
+			expected: modelLedger.Entry{
+				Postings: []modelLedger.Posting{{Currency: "BRL", Debit: modelMoney.MustParse("10")}},
+			},
+			err: fmt.Errorf("ledger entry unbalanced"),
+		},
+		"should not be able to post entry with error at entry": {
+			input: entry,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("INSERT INTO ledger_entries").WillReturnError(fmt.Errorf("any"))
+			},
+			expected: entry,
+			err:      fmt.Errorf("any"),
+		},
+		"should not be able to post entry with error at postings": {
+			input: entry,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"entry_id", "created_at"}).AddRow("entry", createdAt)
+
+				f.sqlx.ExpectQuery("INSERT INTO ledger_entries").WillReturnRows(rows)
+				f.sqlx.ExpectExec("INSERT INTO ledger_postings").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			input := tt.input
+			input.Postings = append([]modelLedger.Posting{}, tt.input.Postings...)
+
+			res, err := store.Post(context.Background(), input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if tt.err == nil && !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetSettlements(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	settledAt := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected []modelLedger.Settlement
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get settlements by transaction id": {
+			input: "1",
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "operation_type_id", "amount", "settled_at"}).
+					AddRow("3", 7, "10.00", settledAt).
+					AddRow("2", 1, "50.00", settledAt)
+
+				f.sqlx.ExpectQuery("SELECT p.counterpart_transaction_id AS transaction_id, c.operation_type_id").WithArgs("1").WillReturnRows(rows)
+			},
+			expected: []modelLedger.Settlement{
+				{TransactionID: "3", OperationTypeID: 7, Amount: modelMoney.MustParse("10"), SettledAt: settledAt},
+				{TransactionID: "2", OperationTypeID: 1, Amount: modelMoney.MustParse("50"), SettledAt: settledAt},
+			},
+		},
+		"should not be able to get settlements by transaction id with error": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT p.counterpart_transaction_id AS transaction_id").WithArgs("1").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.GetSettlements(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
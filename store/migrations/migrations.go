@@ -0,0 +1,354 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	modelMigrations "github.com/jorgepiresg/ChallangePismo/model/migrations"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/migrations_mock.go -package=mocksStore
+type IMigrations interface {
+	List(ctx context.Context) ([]modelMigrations.Migration, error)
+	Up(ctx context.Context) ([]modelMigrations.Migration, error)
+	Down(ctx context.Context, steps int) ([]modelMigrations.Migration, error)
+}
+
+// lockKey is the Postgres advisory lock held while migrating, so instances booting together do not apply the same
+// migration twice.
+const lockKey = 4_817_256_301
+
+// fileName matches the migration files, <version>_<name>.up.sql and <version>_<name>.down.sql.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name VARCHAR NOT NULL,
+	checksum VARCHAR(64) NOT NULL,
+	applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+)`
+
+type Options struct {
+	DB  *sqlx.DB
+	Log *logrus.Logger
+	FS  fs.FS
+
+	// Settings are set in the transaction of every migration, read by the migrations with current_setting, such as the
+	// defaults of the configuration a backfill needs.
+	Settings map[string]string
+}
+
+type migrations struct {
+	db         *sqlx.DB
+	log        *logrus.Logger
+	settings   map[string]string
+	migrations []modelMigrations.Migration
+}
+
+// New reads the migrations at the root of the file system. Files not named as migrations are ignored; a version with two
+// names, or without an up file, is an error.
+func New(opts Options) (IMigrations, error) {
+
+	loaded, err := load(opts.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations{
+		db:         opts.DB,
+		log:        opts.Log,
+		settings:   opts.Settings,
+		migrations: loaded,
+	}, nil
+}
+
+// List returns every migration, the ones read from the files and the ones recorded as applied, in the order of versions.
+func (m migrations) List(ctx context.Context) ([]modelMigrations.Migration, error) {
+
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		m.log.Error(err)
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		m.log.Error(err)
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]modelMigrations.Migration, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		if a, ok := applied[migration.Version]; ok {
+			migration.AppliedAt = a.AppliedAt
+			delete(applied, migration.Version)
+		}
+		res = append(res, migration)
+	}
+
+	for _, a := range applied {
+		res = append(res, a)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	return res, nil
+}
+
+// Up applies the pending migrations in the order of their versions, each in a transaction of its own, and returns them.
+// Nothing is applied when an applied migration was changed since.
+func (m migrations) Up(ctx context.Context) ([]modelMigrations.Migration, error) {
+
+	var res []modelMigrations.Migration
+
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if a, ok := applied[migration.Version]; ok && a.Checksum != migration.Checksum {
+				return fmt.Errorf("migration %d_%s changed after it was applied", migration.Version, migration.Name)
+			}
+		}
+
+		for _, migration := range m.migrations {
+
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err := m.inTx(ctx, conn, func(tx *sqlx.Tx) error {
+
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+					migration.Version, migration.Name, migration.Checksum)
+				return err
+			})
+			if err != nil {
+				m.log.WithField("version", migration.Version).Error(err)
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			now := time.Now()
+			migration.AppliedAt = &now
+			res = append(res, migration)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// Down rolls back the last steps applied migrations, the newest first, each in a transaction of its own, and returns them.
+func (m migrations) Down(ctx context.Context, steps int) ([]modelMigrations.Migration, error) {
+
+	var res []modelMigrations.Migration
+
+	if steps < 1 {
+		return res, fmt.Errorf("steps invalid")
+	}
+
+	files := make(map[int64]modelMigrations.Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		files[migration.Version] = migration
+	}
+
+	err := m.locked(ctx, func(conn *sqlx.Conn) error {
+
+		var applied []modelMigrations.Migration
+		err := sqlx.SelectContext(ctx, conn, &applied, `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version DESC LIMIT $1`, steps)
+		if err != nil {
+			m.log.Error(err)
+			return err
+		}
+
+		for _, migration := range applied {
+
+			file, ok := files[migration.Version]
+			if !ok || file.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			err := m.inTx(ctx, conn, func(tx *sqlx.Tx) error {
+
+				if _, err := tx.ExecContext(ctx, file.Down); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+				return err
+			})
+			if err != nil {
+				m.log.WithField("version", migration.Version).Error(err)
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			file.AppliedAt = nil
+			res = append(res, file)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// locked runs fn on a connection holding the migrations lock, creating the history table first.
+func (m migrations) locked(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		m.log.Error(err)
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		m.log.Error(err)
+		return err
+	}
+
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			m.log.Error(err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		m.log.Error(err)
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m migrations) inTx(ctx context.Context, conn *sqlx.Conn, fn func(tx *sqlx.Tx) error) error {
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(m.settings))
+	for name := range m.settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := tx.ExecContext(ctx, `SELECT set_config($1, $2, true)`, name, m.settings[name]); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			m.log.Error(rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m migrations) applied(ctx context.Context, conn *sqlx.Conn) (map[int64]modelMigrations.Migration, error) {
+
+	var rows []modelMigrations.Migration
+	if err := sqlx.SelectContext(ctx, conn, &rows, `SELECT version, name, checksum, applied_at FROM schema_migrations`); err != nil {
+		m.log.Error(err)
+		return nil, err
+	}
+
+	applied := make(map[int64]modelMigrations.Migration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	return applied, nil
+}
+
+func load(fsys fs.FS) ([]modelMigrations.Migration, error) {
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*modelMigrations.Migration{}
+
+	for _, entry := range entries {
+
+		parts := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || parts == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s version invalid", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &modelMigrations.Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != parts[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			sum := sha256.Sum256(content)
+			migration.Up = string(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	res := make([]modelMigrations.Migration, 0, len(byVersion))
+
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		res = append(res, *migration)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	return res, nil
+}
@@ -0,0 +1,297 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	migrationFiles "github.com/jorgepiresg/ChallangePismo/migrations"
+	modelMigrations "github.com/jorgepiresg/ChallangePismo/model/migrations"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+// changedChecksum is the checksum of a migration changed after it was applied.
+const changedChecksum = "5b7ba8d2b1a7a0c3f54d5c5fdba1b2dcd52a4baae8ebfb9e7fb6e3d5ad1fe2cf"
+
+var files = fstest.MapFS{
+	"1_accounts.up.sql":      {Data: []byte("CREATE TABLE accounts ();")},
+	"1_accounts.down.sql":    {Data: []byte("DROP TABLE accounts;")},
+	"2_backup.up.sql":        {Data: []byte("CREATE TABLE backup ();")},
+	"2_backup.down.sql":      {Data: []byte("DROP TABLE backup;")},
+	"10_transactions.up.sql": {Data: []byte("CREATE TABLE transactions ();")},
+	"migrations.go":          {Data: []byte("package migrations")},
+	"README.md":              {Data: []byte("up")},
+}
+
+func TestLoad(t *testing.T) {
+
+	tests := map[string]struct {
+		input    fs.FS
+		expected []int64
+		err      error
+	}{
+		"should be able to load migrations in the order of versions": {
+			input:    files,
+			expected: []int64{1, 2, 10},
+		},
+		"should be able to load the migrations of the service": {
+			input:    migrationFiles.FS,
+			expected: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22},
+		},
+		"should not be able to load a migration without up file": {
+			input: fstest.MapFS{"1_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")}},
+			err:   fmt.Errorf("migration 1_accounts has no up file"),
+		},
+		"should not be able to load a version with two names": {
+			input: fstest.MapFS{
+				"1_accounts.up.sql": {Data: []byte("CREATE TABLE accounts ();")},
+				"1_users.up.sql":    {Data: []byte("CREATE TABLE users ();")},
+			},
+			err: fmt.Errorf("migration 1 has two names, accounts and users"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			res, err := load(tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+
+			var versions []int64
+			for _, migration := range res {
+				versions = append(versions, migration.Version)
+			}
+
+			if !reflect.DeepEqual(versions, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, versions)
+			}
+		})
+	}
+}
+
+func TestUp(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	loaded, _ := load(files)
+	appliedAt := time.Now()
+
+	tests := map[string]struct {
+		settings map[string]string
+		expected []int64
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to apply the pending migrations": {
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations").WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}).AddRow(1, "accounts", loaded[0].Checksum, appliedAt))
+
+				for _, migration := range loaded[1:] {
+					f.sqlx.ExpectBegin()
+					f.sqlx.ExpectExec("CREATE TABLE " + migration.Name).WillReturnResult(sqlxmock.NewResult(0, 0))
+					f.sqlx.ExpectExec("INSERT INTO schema_migrations").WithArgs(migration.Version, migration.Name, migration.Checksum).WillReturnResult(sqlxmock.NewResult(0, 1))
+					f.sqlx.ExpectCommit()
+				}
+
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expected: []int64{2, 10},
+		},
+		"should be able to apply the pending migrations with the settings": {
+			settings: map[string]string{"pismo.default_credit_limit": "1000.00"},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations").WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}).AddRow(1, "accounts", loaded[0].Checksum, appliedAt))
+
+				for _, migration := range loaded[1:] {
+					f.sqlx.ExpectBegin()
+					f.sqlx.ExpectExec(`SELECT set_config\(\$1, \$2, true\)`).WithArgs("pismo.default_credit_limit", "1000.00").WillReturnResult(sqlxmock.NewResult(0, 0))
+					f.sqlx.ExpectExec("CREATE TABLE " + migration.Name).WillReturnResult(sqlxmock.NewResult(0, 0))
+					f.sqlx.ExpectExec("INSERT INTO schema_migrations").WithArgs(migration.Version, migration.Name, migration.Checksum).WillReturnResult(sqlxmock.NewResult(0, 1))
+					f.sqlx.ExpectCommit()
+				}
+
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expected: []int64{2, 10},
+		},
+		"should not be able to apply migrations when an applied one changed": {
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations").WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}).AddRow(1, "accounts", changedChecksum, appliedAt))
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			err: fmt.Errorf("migration 1_accounts changed after it was applied"),
+		},
+		"should not be able to apply migrations with error in a migration": {
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations").WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}))
+				f.sqlx.ExpectBegin()
+				f.sqlx.ExpectExec("CREATE TABLE accounts").WillReturnError(fmt.Errorf("any"))
+				f.sqlx.ExpectRollback()
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			err: fmt.Errorf("migration 1_accounts: any"),
+		},
+		"should not be able to apply migrations with error taking the lock": {
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store, err := New(Options{
+				DB:       db,
+				Log:      logrus.New(),
+				FS:       files,
+				Settings: tt.settings,
+			})
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when loading migrations", err)
+			}
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.Up(context.Background())
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if versions := versionsOf(res); !reflect.DeepEqual(versions, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, versions)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	loaded, _ := load(files)
+	appliedAt := time.Now()
+
+	tests := map[string]struct {
+		input    int
+		expected []int64
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to roll back the last migrations": {
+			input: 2,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version DESC").WithArgs(2).WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}).
+						AddRow(2, "backup", loaded[1].Checksum, appliedAt).
+						AddRow(1, "accounts", loaded[0].Checksum, appliedAt))
+
+				for _, migration := range []modelMigrations.Migration{loaded[1], loaded[0]} {
+					f.sqlx.ExpectBegin()
+					f.sqlx.ExpectExec("DROP TABLE " + migration.Name).WillReturnResult(sqlxmock.NewResult(0, 0))
+					f.sqlx.ExpectExec("DELETE FROM schema_migrations").WithArgs(migration.Version).WillReturnResult(sqlxmock.NewResult(0, 1))
+					f.sqlx.ExpectCommit()
+				}
+
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			expected: []int64{2, 1},
+		},
+		"should not be able to roll back a migration without down file": {
+			input: 1,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlxmock.NewResult(0, 0))
+				f.sqlx.ExpectQuery("SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version DESC").WithArgs(1).WillReturnRows(
+					f.sqlx.NewRows([]string{"version", "name", "checksum", "applied_at"}).AddRow(10, "transactions", loaded[2].Checksum, appliedAt))
+				f.sqlx.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).WithArgs(lockKey).WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			err: fmt.Errorf("migration 10_transactions has no down file"),
+		},
+		"should not be able to roll back with steps invalid": {
+			input:   0,
+			prepare: func(f *fields) {},
+			err:     fmt.Errorf("steps invalid"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store, err := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+				FS:  files,
+			})
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when loading migrations", err)
+			}
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.Down(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if versions := versionsOf(res); !reflect.DeepEqual(versions, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, versions)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func versionsOf(migrations []modelMigrations.Migration) []int64 {
+	var versions []int64
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
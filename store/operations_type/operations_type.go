@@ -10,7 +10,9 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
+	"github.com/jorgepiresg/ChallangePismo/metrics"
 	modelOperaTionsType "github.com/jorgepiresg/ChallangePismo/model/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
 	"github.com/jorgepiresg/ChallangePismo/utils"
 	"github.com/sirupsen/logrus"
 )
@@ -18,18 +20,28 @@ import (
 //go:generate mockgen -source=$GOFILE -destination=../../mocks/store/operations_type_mock.go -package=mocksStore
 type IOperationsType interface {
 	GetByID(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error)
+	Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error)
+	List(ctx context.Context) ([]modelOperaTionsType.OperationType, error)
+	Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error)
+	Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error)
+	Seed(ctx context.Context, operationType modelOperaTionsType.OperationType) (bool, error)
+	DeleteCache(ctx context.Context, ID int)
 }
 
 type Options struct {
-	DB    *sqlx.DB
+	DB    sqlx.ExtContext
 	Log   *logrus.Logger
 	Cache *redis.Client
+
+	// Tasks tracks the cache writes left running after a query.
+	Tasks *utils.Tasks
 }
 
 type operationsType struct {
-	db    *sqlx.DB
+	db    sqlx.ExtContext
 	log   *logrus.Logger
 	cache *redis.Client
+	tasks *utils.Tasks
 }
 
 func New(opts Options) IOperationsType {
@@ -37,6 +49,7 @@ func New(opts Options) IOperationsType {
 		db:    opts.DB,
 		log:   opts.Log,
 		cache: opts.Cache,
+		tasks: opts.Tasks,
 	}
 }
 
@@ -51,7 +64,7 @@ func (ot operationsType) GetByID(ctx context.Context, ID int) (modelOperaTionsTy
 		return operationsType, err
 	}
 
-	err = ot.db.GetContext(ctx, &operationsType, `SELECT operation_type_id, description, operation FROM operations_type where operation_type_id = $1`, ID)
+	err = sqlx.GetContext(ctx, ot.db, &operationsType, `SELECT operation_type_id, description, operation, deactivated_at FROM operations_type where operation_type_id = $1`, ID)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			ot.log.WithField("operation_type_id_", ID).Error(err)
@@ -59,11 +72,111 @@ func (ot operationsType) GetByID(ctx context.Context, ID int) (modelOperaTionsTy
 		return operationsType, err
 	}
 
-	go ot.setCache(context.Background(), cacheKey, operationsType)
+	ot.tasks.Go(func() { ot.setCache(tracing.Detach(ctx), cacheKey, operationsType) })
 
 	return operationsType, nil
 }
 
+func (ot operationsType) Create(ctx context.Context, create modelOperaTionsType.Create) (modelOperaTionsType.OperationType, error) {
+
+	var operationType modelOperaTionsType.OperationType
+
+	rows, err := sqlx.NamedQueryContext(ctx, ot.db, `INSERT INTO operations_type (description, operation) VALUES (:description, :operation)
+	RETURNING operation_type_id, description, operation, deactivated_at`, create)
+	if err != nil {
+		ot.log.WithField("body", create).Error(err)
+		return operationType, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := rows.StructScan(&operationType); err != nil {
+			ot.log.WithField("body", create).Error(err)
+			return operationType, err
+		}
+	}
+
+	return operationType, rows.Err()
+}
+
+func (ot operationsType) List(ctx context.Context) ([]modelOperaTionsType.OperationType, error) {
+
+	var operationsType []modelOperaTionsType.OperationType
+	err := sqlx.SelectContext(ctx, ot.db, &operationsType, `SELECT operation_type_id, description, operation, deactivated_at FROM operations_type ORDER BY operation_type_id`)
+	if err != nil {
+		ot.log.Error(err)
+		return nil, err
+	}
+
+	return operationsType, nil
+}
+
+// Update changes the description and operation that are set, keeping the others. The cached operation type is left as is,
+// callers must call DeleteCache.
+func (ot operationsType) Update(ctx context.Context, update modelOperaTionsType.Update) (modelOperaTionsType.OperationType, error) {
+
+	var operationType modelOperaTionsType.OperationType
+
+	err := sqlx.GetContext(ctx, ot.db, &operationType, `UPDATE operations_type SET
+	description = COALESCE($1, description),
+	operation = COALESCE($2, operation)
+	WHERE operation_type_id = $3
+	RETURNING operation_type_id, description, operation, deactivated_at`, update.Description, update.Operation, update.OperationTypeID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			ot.log.WithField("operation_type_id", update.OperationTypeID).Error(err)
+		}
+		return operationType, err
+	}
+
+	return operationType, nil
+}
+
+// Deactivate keeps the operation type, and the transactions made with it, but refuses new transactions. Deactivating it
+// again keeps the first deactivation date. The cached operation type is left as is, callers must call DeleteCache.
+func (ot operationsType) Deactivate(ctx context.Context, ID int) (modelOperaTionsType.OperationType, error) {
+
+	var operationType modelOperaTionsType.OperationType
+
+	err := sqlx.GetContext(ctx, ot.db, &operationType, `UPDATE operations_type SET deactivated_at = COALESCE(deactivated_at, CURRENT_TIMESTAMP)
+	WHERE operation_type_id = $1
+	RETURNING operation_type_id, description, operation, deactivated_at`, ID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			ot.log.WithField("operation_type_id", ID).Error(err)
+		}
+		return operationType, err
+	}
+
+	return operationType, nil
+}
+
+// Seed creates the operation type with its id unless there is one with that id already, which is kept as is. It reports
+// whether the operation type was created.
+func (ot operationsType) Seed(ctx context.Context, operationType modelOperaTionsType.OperationType) (bool, error) {
+
+	var ids []int
+	err := sqlx.SelectContext(ctx, ot.db, &ids, `INSERT INTO operations_type (operation_type_id, description, operation) VALUES ($1, $2, $3)
+	ON CONFLICT (operation_type_id) DO NOTHING
+	RETURNING operation_type_id`, operationType.OperationTypeID, operationType.Description, operationType.Operation)
+	if err != nil {
+		ot.log.WithField("body", operationType).Error(err)
+		return false, err
+	}
+
+	return len(ids) > 0, nil
+}
+
+func (ot operationsType) DeleteCache(ctx context.Context, ID int) {
+
+	key := fmt.Sprintf("operations_type_id_%d", ID)
+
+	err := ot.cache.Del(ctx, key).Err()
+	if err != nil {
+		ot.log.WithField("cache_key", key).Warning(err)
+	}
+}
+
 func (ot operationsType) setCache(ctx context.Context, key string, operationType modelOperaTionsType.OperationType) {
 	err := ot.cache.Set(ctx, key, utils.ToJSON(operationType), 6*time.Hour).Err()
 	if err != nil {
@@ -74,9 +187,12 @@ func (ot operationsType) setCache(ctx context.Context, key string, operationType
 func (ot operationsType) getCache(ctx context.Context, key string, operationType *modelOperaTionsType.OperationType) error {
 	res, err := ot.cache.Get(ctx, key).Result()
 	if err != nil {
+		metrics.CacheReads.WithLabelValues("operations_type", metrics.CacheResult(err)).Inc()
 		return nil
 	}
 
+	metrics.CacheReads.WithLabelValues("operations_type", metrics.CacheHit).Inc()
+
 	if err := utils.FromJson(res, operationType); err != nil {
 		ot.log.WithField("cache_key", key).Error(err)
 		return err
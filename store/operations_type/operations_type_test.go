@@ -2,6 +2,7 @@ package operationsType
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
@@ -35,7 +36,7 @@ func TestGetByID(t *testing.T) {
 
 				rows := f.sqlx.NewRows([]string{"operation_type_id", "description", "operation"}).AddRow(1, "COMPRA A VISTA", -1)
 
-				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation FROM operations_type").WithArgs(1).WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation, deactivated_at FROM operations_type").WithArgs(1).WillReturnRows(rows)
 
 				f.redis.ExpectSet("operations_type_id_1", utils.ToJSON(modelOperaTionsType.OperationType{
 					OperationTypeID: 1,
@@ -58,7 +59,7 @@ func TestGetByID(t *testing.T) {
 
 				rows := f.sqlx.NewRows([]string{"operation_type_id", "description", "operation"}).AddRow(1, "COMPRA A VISTA", -1)
 
-				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation FROM operations_type").WithArgs(1).WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation, deactivated_at FROM operations_type").WithArgs(1).WillReturnRows(rows)
 
 				f.redis.ExpectSet("operations_type_id_1", utils.ToJSON(modelOperaTionsType.OperationType{
 					OperationTypeID: 1,
@@ -81,7 +82,7 @@ func TestGetByID(t *testing.T) {
 
 				rows := f.sqlx.NewRows([]string{"operation_type_id", "description", "operation"}).AddRow(1, "COMPRA A VISTA", -1)
 
-				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation FROM operations_type").WithArgs(1).WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation, deactivated_at FROM operations_type").WithArgs(1).WillReturnRows(rows)
 
 				f.redis.ExpectSet("operations_type_id_1", utils.ToJSON(modelOperaTionsType.OperationType{
 					OperationTypeID: 1,
@@ -115,7 +116,7 @@ func TestGetByID(t *testing.T) {
 			prepare: func(f *fields) {
 				f.redis.ExpectGet("operation_type_id_1").RedisNil()
 
-				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation FROM operations_type").WillReturnError(fmt.Errorf("any"))
+				f.sqlx.ExpectQuery("SELECT operation_type_id, description, operation, deactivated_at FROM operations_type").WillReturnError(fmt.Errorf("any"))
 			},
 			err: fmt.Errorf("any"),
 		},
@@ -153,3 +154,367 @@ func TestGetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+
+	tests := map[string]struct {
+		input    modelOperaTionsType.Create
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to create operation type": {
+			input: modelOperaTionsType.Create{Description: "TARIFA", Operation: -1},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id", "description", "operation", "deactivated_at"}).AddRow(1000, "TARIFA", -1, nil)
+
+				mock.ExpectQuery("INSERT INTO operations_type \\(description, operation\\) VALUES").WithArgs("TARIFA", -1).WillReturnRows(rows)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA",
+				Operation:       -1,
+			},
+		},
+		"should not be able to create operation type with error at sqlx": {
+			input: modelOperaTionsType.Create{Description: "TARIFA", Operation: -1},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO operations_type").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Create(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+
+	deactivatedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		expected []modelOperaTionsType.OperationType
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to list operations type": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id", "description", "operation", "deactivated_at"}).
+					AddRow(1, "COMPRA A VISTA", -1, nil).
+					AddRow(1000, "TARIFA", -1, deactivatedAt)
+
+				mock.ExpectQuery("SELECT operation_type_id, description, operation, deactivated_at FROM operations_type ORDER BY operation_type_id").WillReturnRows(rows)
+			},
+			expected: []modelOperaTionsType.OperationType{
+				{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+				{OperationTypeID: 1000, Description: "TARIFA", Operation: -1, DeactivatedAt: &deactivatedAt},
+			},
+		},
+		"should not be able to list operations type with error at sqlx": {
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT operation_type_id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.List(context.Background())
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	description := "TARIFA ANUAL"
+	operation := 1
+
+	tests := map[string]struct {
+		input    modelOperaTionsType.Update
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to update operation type": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1000, Description: &description},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id", "description", "operation", "deactivated_at"}).AddRow(1000, "TARIFA ANUAL", -1, nil)
+
+				mock.ExpectQuery("UPDATE operations_type SET description = COALESCE\\(\\$1, description\\), operation = COALESCE\\(\\$2, operation\\)").
+					WithArgs(&description, nil, 1000).WillReturnRows(rows)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA ANUAL",
+				Operation:       -1,
+			},
+		},
+		"should be able to update operation type operation": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1000, Operation: &operation},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id", "description", "operation", "deactivated_at"}).AddRow(1000, "TARIFA", 1, nil)
+
+				mock.ExpectQuery("UPDATE operations_type SET").WithArgs(nil, &operation, 1000).WillReturnRows(rows)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA",
+				Operation:       1,
+			},
+		},
+		"should not be able to update operation type not found": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1001, Description: &description},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE operations_type SET").WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+		"should not be able to update operation type with error at sqlx": {
+			input: modelOperaTionsType.Update{OperationTypeID: 1000, Description: &description},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE operations_type SET").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Update(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestDeactivate(t *testing.T) {
+
+	deactivatedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    int
+		expected modelOperaTionsType.OperationType
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to deactivate operation type": {
+			input: 1000,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id", "description", "operation", "deactivated_at"}).AddRow(1000, "TARIFA", -1, deactivatedAt)
+
+				mock.ExpectQuery("UPDATE operations_type SET deactivated_at = COALESCE\\(deactivated_at, CURRENT_TIMESTAMP\\)").WithArgs(1000).WillReturnRows(rows)
+			},
+			expected: modelOperaTionsType.OperationType{
+				OperationTypeID: 1000,
+				Description:     "TARIFA",
+				Operation:       -1,
+				DeactivatedAt:   &deactivatedAt,
+			},
+		},
+		"should not be able to deactivate operation type not found": {
+			input: 1001,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE operations_type SET deactivated_at").WithArgs(1001).WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+		"should not be able to deactivate operation type with error at sqlx": {
+			input: 1000,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE operations_type SET deactivated_at").WithArgs(1000).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Deactivate(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+
+	tests := map[string]struct {
+		input    modelOperaTionsType.OperationType
+		expected bool
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to seed operation type": {
+			input: modelOperaTionsType.OperationType{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id"}).AddRow(1)
+
+				mock.ExpectQuery("INSERT INTO operations_type \\(operation_type_id, description, operation\\)").WithArgs(1, "COMPRA A VISTA", -1).WillReturnRows(rows)
+			},
+			expected: true,
+		},
+		"should be able to seed operation type already created": {
+			input: modelOperaTionsType.OperationType{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"operation_type_id"})
+
+				mock.ExpectQuery("ON CONFLICT \\(operation_type_id\\) DO NOTHING").WithArgs(1, "COMPRA A VISTA", -1).WillReturnRows(rows)
+			},
+		},
+		"should not be able to seed operation type with error at sqlx": {
+			input: modelOperaTionsType.OperationType{OperationTypeID: 1, Description: "COMPRA A VISTA", Operation: -1},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO operations_type").WithArgs(1, "COMPRA A VISTA", -1).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Seed(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestDeleteCache(t *testing.T) {
+
+	tests := map[string]struct {
+		input   int
+		prepare func(mock redismock.ClientMock)
+	}{
+		"should be able to delete operation type from cache": {
+			input: 1000,
+			prepare: func(mock redismock.ClientMock) {
+				mock.ExpectDel("operations_type_id_1000").SetVal(1)
+			},
+		},
+		"should be able to delete operation type from cache with error at cache": {
+			input: 1000,
+			prepare: func(mock redismock.ClientMock) {
+				mock.ExpectDel("operations_type_id_1000").SetErr(fmt.Errorf("any"))
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			cacheDB, cacheMock := redismock.NewClientMock()
+
+			store := New(Options{
+				Log:   logrus.New(),
+				Cache: cacheDB,
+			})
+
+			tt.prepare(cacheMock)
+
+			store.DeleteCache(context.Background(), tt.input)
+
+			if err := cacheMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
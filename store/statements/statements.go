@@ -0,0 +1,155 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/store/statements_mock.go -package=mocksStore
+type IStatements interface {
+	Create(ctx context.Context, statement modelStatements.Statement) (modelStatements.Statement, error)
+	GetByID(ctx context.Context, ID string) (modelStatements.Statement, error)
+	GetLastByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error)
+	GetMovements(ctx context.Context, accountID string, start *time.Time, end time.Time) ([]modelStatements.Movement, error)
+	ListByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error)
+}
+
+// ErrStatementClosed is returned by Create when the cycle of the statement was already closed.
+var ErrStatementClosed = modelErrors.Conflict("STATEMENT_CLOSED", "statement already closed")
+
+const columns = `statement_id, account_id, currency, period_start, closing_date, opening_balance, purchases, payments, closing_balance,
+	minimum_payment, due_date, created_at`
+
+type Options struct {
+	DB  sqlx.ExtContext
+	Log *logrus.Logger
+}
+
+type statements struct {
+	db  sqlx.ExtContext
+	log *logrus.Logger
+}
+
+func New(opts Options) IStatements {
+	return statements{
+		db:  opts.DB,
+		log: opts.Log,
+	}
+}
+
+func (s statements) Create(ctx context.Context, statement modelStatements.Statement) (modelStatements.Statement, error) {
+
+	rows, err := sqlx.NamedQueryContext(ctx, s.db, `INSERT INTO statements (account_id, currency, period_start, closing_date, opening_balance, purchases, payments,
+	closing_balance, minimum_payment, due_date)
+	VALUES (:account_id, :currency, :period_start, :closing_date, :opening_balance, :purchases, :payments, :closing_balance, :minimum_payment, :due_date)
+	ON CONFLICT (account_id, currency, closing_date) DO NOTHING
+	RETURNING `+columns, statement)
+	if err != nil {
+		s.log.WithField("body", statement).Error(err)
+		return statement, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			s.log.WithField("body", statement).Error(err)
+			return statement, err
+		}
+		return statement, ErrStatementClosed
+	}
+
+	var res modelStatements.Statement
+	if err := rows.StructScan(&res); err != nil {
+		s.log.WithField("body", statement).Error(err)
+		return statement, err
+	}
+
+	return res, nil
+}
+
+func (s statements) GetByID(ctx context.Context, ID string) (modelStatements.Statement, error) {
+
+	var statement modelStatements.Statement
+
+	// statement ids are UUIDs, a malformed one matches no statement and would only fail the query
+	if _, err := uuid.Parse(ID); err != nil {
+		return statement, sql.ErrNoRows
+	}
+
+	err := sqlx.GetContext(ctx, s.db, &statement, `SELECT `+columns+` FROM statements WHERE statement_id = $1`, ID)
+
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.log.WithField("statement_id", ID).Error(err)
+		}
+		return statement, err
+	}
+
+	return statement, nil
+}
+
+// GetLastByAccountID returns the statements of the last cycle closed for the account, one per currency.
+func (s statements) GetLastByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error) {
+
+	var statements []modelStatements.Statement
+	err := sqlx.SelectContext(ctx, s.db, &statements, `SELECT `+columns+` FROM statements
+	WHERE account_id = $1 AND closing_date = (SELECT MAX(closing_date) FROM statements WHERE account_id = $1)
+	ORDER BY currency;
+	`, accountID)
+
+	if err != nil {
+		s.log.WithField("account_id", accountID).Error(err)
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+// GetMovements sums, per currency, the transactions of the account from start, or from the first one when it is nil, until
+// end, exclusive. Installments count at their due date and purchases in installments only through them.
+func (s statements) GetMovements(ctx context.Context, accountID string, start *time.Time, end time.Time) ([]modelStatements.Movement, error) {
+
+	var movements []modelStatements.Movement
+	err := sqlx.SelectContext(ctx, s.db, &movements, `SELECT t.currency,
+	COALESCE(-SUM(t.amount) FILTER (WHERE t.amount < 0), 0) AS purchases,
+	COALESCE(SUM(t.amount) FILTER (WHERE t.amount > 0), 0) AS payments
+	FROM transactions t
+	WHERE t.account_id = $1 AND t.installments IS NULL AND
+	(CAST($2 AS TIMESTAMP WITH TIME ZONE) IS NULL OR COALESCE(t.due_date, t.event_date) >= CAST($2 AS TIMESTAMP WITH TIME ZONE)) AND
+	COALESCE(t.due_date, t.event_date) < $3
+	GROUP BY t.currency
+	ORDER BY t.currency;
+	`, accountID, start, end)
+
+	if err != nil {
+		s.log.WithField("account_id", accountID).Error(err)
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// ListByAccountID returns the statements of the account, the most recent cycle first.
+func (s statements) ListByAccountID(ctx context.Context, accountID string) ([]modelStatements.Statement, error) {
+
+	var statements []modelStatements.Statement
+	err := sqlx.SelectContext(ctx, s.db, &statements, `SELECT `+columns+` FROM statements
+	WHERE account_id = $1
+	ORDER BY closing_date DESC, currency;
+	`, accountID)
+
+	if err != nil {
+		s.log.WithField("account_id", accountID).Error(err)
+		return nil, err
+	}
+
+	return statements, nil
+}
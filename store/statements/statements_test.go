@@ -0,0 +1,349 @@
+package statements
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
+	modelStatements "github.com/jorgepiresg/ChallangePismo/model/statements"
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+var (
+	periodStart = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	closingDate = time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	dueDate     = time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+	createdAt   = time.Date(2024, 2, 10, 0, 5, 0, 0, time.UTC)
+)
+
+var statementColumns = []string{"statement_id", "account_id", "currency", "period_start", "closing_date", "opening_balance", "purchases", "payments",
+	"closing_balance", "minimum_payment", "due_date", "created_at"}
+
+func statement() modelStatements.Statement {
+	return modelStatements.Statement{
+		StatementID:    "statement_id",
+		AccountID:      "id",
+		Currency:       "BRL",
+		PeriodStart:    &periodStart,
+		ClosingDate:    closingDate,
+		OpeningBalance: modelMoney.MustParse("70"),
+		Purchases:      modelMoney.MustParse("100"),
+		Payments:       modelMoney.MustParse("70"),
+		ClosingBalance: modelMoney.MustParse("100"),
+		MinimumPayment: modelMoney.MustParse("15"),
+		DueDate:        dueDate,
+		CreatedAt:      createdAt,
+	}
+}
+
+func statementRow(mock sqlxmock.Sqlmock) *sqlxmock.Rows {
+	return mock.NewRows(statementColumns).
+		AddRow("statement_id", "id", "BRL", periodStart, closingDate, "70.00", "100.00", "70.00", "100.00", "15.00", dueDate, createdAt)
+}
+
+func TestCreate(t *testing.T) {
+
+	input := statement()
+	input.StatementID, input.CreatedAt = "", time.Time{}
+
+	tests := map[string]struct {
+		input    modelStatements.Statement
+		expected modelStatements.Statement
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to create statement": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO statements").
+					WithArgs("id", "BRL", periodStart, closingDate, "70.00", "100.00", "70.00", "100.00", "15.00", dueDate).
+					WillReturnRows(statementRow(mock))
+			},
+			expected: statement(),
+		},
+		"should not be able to create statement of a cycle already closed": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO statements .* ON CONFLICT \\(account_id, currency, closing_date\\) DO NOTHING").WillReturnRows(mock.NewRows(statementColumns))
+			},
+			expected: input,
+			err:      ErrStatementClosed,
+		},
+		"should not be able to create statement with error at sqlx": {
+			input: input,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO statements").WillReturnError(fmt.Errorf("any"))
+			},
+			expected: input,
+			err:      fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.Create(context.Background(), tt.input)
+
+			if (err != nil || tt.err != nil) && fmt.Sprint(err) != fmt.Sprint(tt.err) {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+
+	statementID := "8f14e45f-ceea-467f-a0e6-7e1a5b2c3d4e"
+
+	tests := map[string]struct {
+		input    string
+		expected modelStatements.Statement
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to get statement by id": {
+			input: statementID,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT statement_id, account_id, currency, period_start, closing_date, .* FROM statements WHERE statement_id = \\$1").
+					WithArgs(statementID).WillReturnRows(statementRow(mock))
+			},
+			expected: statement(),
+		},
+		"should not be able to get statement by malformed id": {
+			input:   "statement_id",
+			prepare: func(mock sqlxmock.Sqlmock) {},
+			err:     sql.ErrNoRows,
+		},
+		"should not be able to get statement by id not found": {
+			input: statementID,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT statement_id").WithArgs(statementID).WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.GetByID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetLastByAccountID(t *testing.T) {
+
+	tests := map[string]struct {
+		input    string
+		expected []modelStatements.Statement
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to get the statements of the last cycle": {
+			input: "id",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("FROM statements WHERE account_id = \\$1 AND closing_date = \\(SELECT MAX\\(closing_date\\) FROM statements WHERE account_id = \\$1\\)").
+					WithArgs("id").WillReturnRows(statementRow(mock))
+			},
+			expected: []modelStatements.Statement{statement()},
+		},
+		"should not be able to get the statements of the last cycle with error at sqlx": {
+			input: "id",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("FROM statements").WithArgs("id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.GetLastByAccountID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetMovements(t *testing.T) {
+
+	type input struct {
+		accountID string
+		start     *time.Time
+		end       time.Time
+	}
+
+	tests := map[string]struct {
+		input    input
+		expected []modelStatements.Movement
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to get the movements of a cycle": {
+			input: input{accountID: "id", start: &periodStart, end: closingDate},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"currency", "purchases", "payments"}).
+					AddRow("BRL", "100.00", "70.00").
+					AddRow("USD", "20.00", "0.00")
+
+				mock.ExpectQuery("FROM transactions t WHERE t.account_id = \\$1 AND t.installments IS NULL").WithArgs("id", &periodStart, closingDate).WillReturnRows(rows)
+			},
+			expected: []modelStatements.Movement{
+				{Currency: "BRL", Purchases: modelMoney.MustParse("100"), Payments: modelMoney.MustParse("70")},
+				{Currency: "USD", Purchases: modelMoney.MustParse("20")},
+			},
+		},
+		"should be able to get the movements of the first cycle": {
+			input: input{accountID: "id", end: closingDate},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				rows := mock.NewRows([]string{"currency", "purchases", "payments"}).AddRow("BRL", "100.00", "0.00")
+
+				mock.ExpectQuery("FROM transactions t").WithArgs("id", nil, closingDate).WillReturnRows(rows)
+			},
+			expected: []modelStatements.Movement{
+				{Currency: "BRL", Purchases: modelMoney.MustParse("100")},
+			},
+		},
+		"should not be able to get the movements of a cycle with error at sqlx": {
+			input: input{accountID: "id", end: closingDate},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("FROM transactions t").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.GetMovements(context.Background(), tt.input.accountID, tt.input.start, tt.input.end)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListByAccountID(t *testing.T) {
+
+	tests := map[string]struct {
+		input    string
+		expected []modelStatements.Statement
+		err      error
+		prepare  func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to list statements": {
+			input: "id",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("FROM statements WHERE account_id = \\$1 ORDER BY closing_date DESC, currency").WithArgs("id").WillReturnRows(statementRow(mock))
+			},
+			expected: []modelStatements.Statement{statement()},
+		},
+		"should not be able to list statements with error at sqlx": {
+			input: "id",
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("FROM statements").WithArgs("id").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(mock)
+
+			res, err := store.ListByAccountID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
@@ -1,48 +1,167 @@
 package store
 
 import (
+	"context"
+
 	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
+	"github.com/jorgepiresg/ChallangePismo/metrics"
 	"github.com/sirupsen/logrus"
 
 	"github.com/jorgepiresg/ChallangePismo/store/accounts"
+	"github.com/jorgepiresg/ChallangePismo/store/accruals"
+	"github.com/jorgepiresg/ChallangePismo/store/allocations"
+	"github.com/jorgepiresg/ChallangePismo/store/fx"
+	"github.com/jorgepiresg/ChallangePismo/store/health"
+	"github.com/jorgepiresg/ChallangePismo/store/idempotency"
+	"github.com/jorgepiresg/ChallangePismo/store/ledger"
 	operationsType "github.com/jorgepiresg/ChallangePismo/store/operations_type"
+	"github.com/jorgepiresg/ChallangePismo/store/statements"
 	"github.com/jorgepiresg/ChallangePismo/store/transactions"
+	"github.com/jorgepiresg/ChallangePismo/tracing"
+	"github.com/jorgepiresg/ChallangePismo/utils"
 )
 
 type Store struct {
 	Accounts       accounts.IAccounts
 	Transactions   transactions.ITransactions
 	OperationsType operationsType.IOperationsType
+	Idempotency    idempotency.IIdempotency
+	Ledger         ledger.ILedger
+	Allocations    allocations.IAllocations
+	Statements     statements.IStatements
+	Accruals       accruals.IAccruals
+	FX             fx.IRates
+	Health         health.IHealth
+
+	withTx func(ctx context.Context, fn func(tx Store) error) error
 }
 
 type Options struct {
 	DB    *sqlx.DB
 	Log   *logrus.Logger
 	Cache *redis.Client
+	FX    fx.IRates
+	Tasks *utils.Tasks
 }
 
 func New(opts Options) Store {
+	if opts.FX == nil {
+		opts.FX, _ = fx.New(fx.Options{Log: opts.Log})
+	}
+
+	s := build(opts.DB, opts)
+
+	s.withTx = func(ctx context.Context, fn func(tx Store) error) (err error) {
+
+		tx, err := opts.DB.BeginTxx(ctx, nil)
+		if err != nil {
+			opts.Log.Error(err)
+			return err
+		}
+
+		defer func() {
+			if p := recover(); p != nil {
+				tx.Rollback()
+				panic(p)
+			}
+		}()
+
+		if err := fn(build(tx, opts)); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				opts.Log.Error(rbErr)
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			opts.Log.Error(err)
+			return err
+		}
+
+		return nil
+	}
+
+	return s
+}
+
+// WithTx runs fn with a Store whose queries share a single database transaction, committed when fn returns nil and
+// rolled back otherwise. Calling WithTx on the Store received by fn reuses the same transaction.
+func (s Store) WithTx(ctx context.Context, fn func(tx Store) error) error {
+	if s.withTx == nil {
+		return fn(s)
+	}
+	return s.withTx(ctx, fn)
+}
+
+// instrument times and traces every query a store makes through db.
+func instrument(db sqlx.ExtContext, store string) sqlx.ExtContext {
+	return metrics.DB(tracing.DB(db, store), store)
+}
+
+func build(db sqlx.ExtContext, opts Options) Store {
 	accountsOpts := accounts.Options{
-		DB:    opts.DB,
+		DB:    instrument(db, "accounts"),
 		Log:   opts.Log,
 		Cache: opts.Cache,
+		Tasks: opts.Tasks,
 	}
 
 	transactionsOpts := transactions.Options{
-		DB:  opts.DB,
+		DB:  instrument(db, "transactions"),
 		Log: opts.Log,
 	}
 
 	operationsTypeOpts := operationsType.Options{
-		DB:    opts.DB,
+		DB:    instrument(db, "operations_type"),
+		Log:   opts.Log,
+		Cache: opts.Cache,
+		Tasks: opts.Tasks,
+	}
+
+	ledgerOpts := ledger.Options{
+		DB:  instrument(db, "ledger"),
+		Log: opts.Log,
+	}
+
+	allocationsOpts := allocations.Options{
+		DB:  instrument(db, "allocations"),
+		Log: opts.Log,
+	}
+
+	statementsOpts := statements.Options{
+		DB:  instrument(db, "statements"),
+		Log: opts.Log,
+	}
+
+	accrualsOpts := accruals.Options{
+		DB:  instrument(db, "accruals"),
+		Log: opts.Log,
+	}
+
+	healthOpts := health.Options{
+		DB:    instrument(db, "health"),
+		Log:   opts.Log,
+		Cache: opts.Cache,
+	}
+
+	idempotencyOpts := idempotency.Options{
+		DB:    instrument(db, "idempotency"),
 		Log:   opts.Log,
 		Cache: opts.Cache,
+		Tasks: opts.Tasks,
 	}
 
 	return Store{
 		Accounts:       accounts.New(accountsOpts),
 		Transactions:   transactions.New(transactionsOpts),
 		OperationsType: operationsType.New(operationsTypeOpts),
+		Idempotency:    idempotency.New(idempotencyOpts),
+		Ledger:         ledger.New(ledgerOpts),
+		Allocations:    allocations.New(allocationsOpts),
+		Statements:     statements.New(statementsOpts),
+		Accruals:       accruals.New(accrualsOpts),
+		FX:             opts.FX,
+		Health:         health.New(healthOpts),
 	}
 }
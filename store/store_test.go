@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+func TestWithTx(t *testing.T) {
+
+	tests := map[string]struct {
+		fn      func(tx Store) error
+		err     error
+		prepare func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to commit when fn succeeds": {
+			fn: func(tx Store) error {
+				return tx.Accounts.UpdateAvailableCreditLimit(context.Background(), "id", 0)
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE accounts SET available_credit_limit").WillReturnResult(sqlxmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		"should be able to reuse the transaction in nested calls": {
+			fn: func(tx Store) error {
+				return tx.WithTx(context.Background(), func(nested Store) error {
+					return nested.Accounts.UpdateAvailableCreditLimit(context.Background(), "id", 0)
+				})
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE accounts SET available_credit_limit").WillReturnResult(sqlxmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		"should be able to rollback when fn fails": {
+			fn: func(tx Store) error {
+				return fmt.Errorf("any")
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			err: fmt.Errorf("any"),
+		},
+		"should not be able to run fn with error to begin": {
+			fn: func(tx Store) error {
+				return nil
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+		"should not be able to commit with error at commit": {
+			fn: func(tx Store) error {
+				return nil
+			},
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			tt.prepare(mock)
+
+			s := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			err = s.WithTx(context.Background(), tt.fn)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if err == nil && tt.err != nil {
+				t.Errorf(`Expected err: "%s" got nil`, tt.err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWithTxWithoutDatabase(t *testing.T) {
+
+	calls := 0
+
+	err := Store{}.WithTx(context.Background(), func(tx Store) error {
+		calls++
+		return nil
+	})
+
+	if err != nil || calls != 1 {
+		t.Errorf("Expected fn to be called once without error, got %d calls and %v", calls, err)
+	}
+}
@@ -2,8 +2,17 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/sirupsen/logrus"
 )
@@ -11,17 +20,46 @@ import (
 //go:generate mockgen -source=$GOFILE -destination=../../mocks/store/transactions_mock.go -package=mocksStore
 type ITransactions interface {
 	Create(ctx context.Context, create modelTransactions.MakeTransaction) (modelTransactions.Transaction, error)
-	GetToDischargeByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error)
-	UpdateBalance(ctx context.Context, transaction modelTransactions.Transaction) error
+	GetByID(ctx context.Context, ID string) (modelTransactions.Transaction, error)
+	GetReversedAmount(ctx context.Context, ID string) (modelMoney.Money, error)
+	GetToDischargeByAccountID(ctx context.Context, accountID, currency string, dueBy *time.Time) ([]modelTransactions.Transaction, error)
+	GetOpenCreditsByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error)
+	GetOverdueByAccountID(ctx context.Context, accountID string, date time.Time) ([]modelAccruals.Overdue, error)
+	ListInstallmentsByParentID(ctx context.Context, parentID string) ([]modelTransactions.Transaction, error)
+	ListFutureInstallmentsByAccountID(ctx context.Context, accountID string, after time.Time) ([]modelTransactions.Transaction, error)
+	ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) ([]modelTransactions.Transaction, error)
+	GetBalanceByAccountID(ctx context.Context, accountID string) ([]modelTransactions.OperationTypeBalance, error)
 }
 
+var (
+	ErrInsufficientCreditLimit = modelErrors.LimitExceeded("INSUFFICIENT_CREDIT_LIMIT", "insufficient credit limit")
+	ErrAccountNotActive        = modelErrors.Unprocessable("ACCOUNT_NOT_ACTIVE", "account not active")
+)
+
+// takesTransaction tells whether the account takes the transaction: a closed account takes none, and a blocked one takes no
+// debit on its credit limit. Charges and the compensating transactions of reversals and refunds are still made on blocked
+// accounts.
+const takesTransaction = `status <> 'CLOSED' AND
+	(status = 'ACTIVE' OR CAST(:limit_amount AS NUMERIC) >= 0 OR CAST(:reversed_transaction_id AS UUID) IS NOT NULL)`
+
+// openBalance derives, as b.balance, the open balance of each transaction t from its ledger postings: what is still
+// receivable for a debit, or still owed to the cardholder for a payment.
+const openBalance = `CROSS JOIN LATERAL (
+	SELECT COALESCE(SUM(p.credit - p.debit), 0) AS balance FROM ledger_postings p
+	WHERE p.transaction_id = t.transaction_id AND p.ledger IN ('RECEIVABLE', 'ACCOUNT')
+) b`
+
+// columns selects every column of the transaction t, with the open balance derived by openBalance.
+const columns = `t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.original_amount, t.original_currency,
+	t.reversed_transaction_id, t.installments, t.parent_transaction_id, t.installment_number, t.due_date, t.event_date, t.limit_amount`
+
 type Options struct {
-	DB  *sqlx.DB
+	DB  sqlx.ExtContext
 	Log *logrus.Logger
 }
 
 type transactions struct {
-	db  *sqlx.DB
+	db  sqlx.ExtContext
 	log *logrus.Logger
 }
 
@@ -32,34 +70,149 @@ func New(opts Options) ITransactions {
 	}
 }
 
+// Create inserts the transaction and, for debits, takes its limit amount from the account available credit limit in the same
+// statement, keeping it on the transaction, so concurrent transactions cannot overspend it nor get in after the account is blocked or closed.
+// ErrAccountNotActive is returned when the account status refuses the transaction, and ErrInsufficientCreditLimit when
+// the limit does not cover the debit.
 func (t transactions) Create(ctx context.Context, create modelTransactions.MakeTransaction) (modelTransactions.Transaction, error) {
 
 	var transaction modelTransactions.Transaction
 
-	rows, err := t.db.NamedQueryContext(ctx, `INSERT INTO transactions (account_id, operation_type_id, amount, balance) VALUES (:account_id, :operation_type_id, :amount, :amount) RETURNING *`, create)
+	rows, err := sqlx.NamedQueryContext(ctx, t.db, `WITH account AS (
+		UPDATE accounts SET available_credit_limit = available_credit_limit + LEAST(CAST(:limit_amount AS NUMERIC), 0)
+		WHERE account_id = CAST(:account_id AS UUID) AND available_credit_limit + LEAST(CAST(:limit_amount AS NUMERIC), 0) >= 0 AND
+		`+takesTransaction+`
+		RETURNING account_id
+	)
+	INSERT INTO transactions (account_id, operation_type_id, amount, currency, original_amount, original_currency, reversed_transaction_id,
+	installments, parent_transaction_id, installment_number, due_date, limit_amount)
+	SELECT :account_id, CAST(:operation_type_id AS INT), CAST(:amount AS NUMERIC), CAST(:currency AS CHAR(3)),
+	CAST(:original_amount AS NUMERIC), CAST(:original_currency AS CHAR(3)), CAST(:reversed_transaction_id AS UUID),
+	NULLIF(CAST(:installments AS SMALLINT), 0), CAST(:parent_transaction_id AS UUID), NULLIF(CAST(:installment_number AS SMALLINT), 0),
+	CAST(:due_date AS TIMESTAMP WITH TIME ZONE), LEAST(CAST(:limit_amount AS NUMERIC), 0) FROM account
+	RETURNING transaction_id, account_id, operation_type_id, amount, amount AS balance, currency, original_amount, original_currency, reversed_transaction_id,
+	installments, parent_transaction_id, installment_number, due_date, event_date, limit_amount`, create)
 	if err != nil {
 		t.log.WithField("body", create).Error(err)
 		return transaction, err
 	}
+	defer rows.Close()
 
-	for rows.Next() {
-		err = rows.StructScan(&transaction)
-		if err != nil {
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
 			t.log.WithField("body", create).Error(err)
 			return transaction, err
 		}
+		return transaction, t.refused(ctx, create)
+	}
+
+	err = rows.StructScan(&transaction)
+	if err != nil {
+		t.log.WithField("body", create).Error(err)
+		return transaction, err
 	}
 
 	return transaction, nil
 }
 
-func (t transactions) GetToDischargeByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error) {
+// refused tells why the account refused the transaction Create could not insert.
+func (t transactions) refused(ctx context.Context, create modelTransactions.MakeTransaction) error {
+
+	rows, err := sqlx.NamedQueryContext(ctx, t.db, `SELECT `+takesTransaction+` FROM accounts WHERE account_id = CAST(:account_id AS UUID)`, create)
+	if err != nil {
+		t.log.WithField("body", create).Error(err)
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			t.log.WithField("body", create).Error(err)
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	var takes bool
+	if err := rows.Scan(&takes); err != nil {
+		t.log.WithField("body", create).Error(err)
+		return err
+	}
+
+	if !takes {
+		return ErrAccountNotActive
+	}
+
+	return ErrInsufficientCreditLimit
+}
+
+func (t transactions) GetByID(ctx context.Context, ID string) (modelTransactions.Transaction, error) {
+
+	var transaction modelTransactions.Transaction
+
+	// transaction ids are UUIDs, a malformed one matches no transaction and would only fail the query
+	if _, err := uuid.Parse(ID); err != nil {
+		return transaction, sql.ErrNoRows
+	}
+
+	err := sqlx.GetContext(ctx, t.db, &transaction, `SELECT `+columns+` FROM transactions t `+openBalance+` WHERE t.transaction_id = $1`, ID)
+
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.log.WithField("transaction_id", ID).Error(err)
+		}
+		return transaction, err
+	}
+
+	return transaction, nil
+}
+
+// GetReversedAmount returns how much of the transaction, as a positive amount, was already given back by reversals and refunds.
+func (t transactions) GetReversedAmount(ctx context.Context, ID string) (modelMoney.Money, error) {
+
+	var amount modelMoney.Money
+	err := sqlx.GetContext(ctx, t.db, &amount, `SELECT COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE reversed_transaction_id = $1`, ID)
+
+	if err != nil {
+		t.log.WithField("transaction_id", ID).Error(err)
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+// GetToDischargeByAccountID returns the open debits of the account in the currency, oldest due first. Installments due after
+// dueBy are left out, unless it is nil. Movements of an account are serialized by the lock Create takes on its row, so the
+// balances read here already include every committed discharge.
+func (t transactions) GetToDischargeByAccountID(ctx context.Context, accountID, currency string, dueBy *time.Time) ([]modelTransactions.Transaction, error) {
 
 	var transactions []modelTransactions.Transaction
-	err := t.db.SelectContext(ctx, &transactions, `SELECT transaction_id ,account_id, operation_type_id, amount, balance, event_date FROM transactions where 
-	account_id = $1 AND
-	balance < 0 
-	ORDER BY event_date asc;
+	err := sqlx.SelectContext(ctx, t.db, &transactions, `SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.event_date, t.limit_amount
+	FROM transactions t `+openBalance+` WHERE 
+	t.account_id = $1 AND
+	t.currency = $2 AND
+	b.balance < 0 AND
+	(t.due_date IS NULL OR CAST($3 AS TIMESTAMP WITH TIME ZONE) IS NULL OR t.due_date <= CAST($3 AS TIMESTAMP WITH TIME ZONE))
+	ORDER BY COALESCE(t.due_date, t.event_date) asc, t.event_date asc
+	FOR UPDATE OF t;
+	`, accountID, currency, dueBy)
+
+	if err != nil {
+		t.log.WithField("account_id", accountID).WithField("currency", currency).Error(err)
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// GetOpenCreditsByAccountID returns the credits of the account with a balance still owed to the cardholder, oldest first.
+func (t transactions) GetOpenCreditsByAccountID(ctx context.Context, accountID string) ([]modelTransactions.Transaction, error) {
+
+	var transactions []modelTransactions.Transaction
+	err := sqlx.SelectContext(ctx, t.db, &transactions, `SELECT `+columns+` FROM transactions t `+openBalance+`
+	WHERE t.account_id = $1 AND b.balance > 0
+	ORDER BY t.event_date, t.transaction_id
+	FOR UPDATE OF t;
 	`, accountID)
 
 	if err != nil {
@@ -70,13 +223,151 @@ func (t transactions) GetToDischargeByAccountID(ctx context.Context, accountID s
 	return transactions, nil
 }
 
-func (t transactions) UpdateBalance(ctx context.Context, transaction modelTransactions.Transaction) error {
+// GetOverdueByAccountID returns the debits of the account that were open at the start of the date while the statement of
+// their cycle was already past its due date, oldest due first. Balances are read as of the start of the date, so the same
+// date always gives the same debits, however late it is accrued.
+func (t transactions) GetOverdueByAccountID(ctx context.Context, accountID string, date time.Time) ([]modelAccruals.Overdue, error) {
+
+	var overdue []modelAccruals.Overdue
+	err := sqlx.SelectContext(ctx, t.db, &overdue, `SELECT t.transaction_id, t.currency, b.balance, s.statement_id, s.due_date,
+	EXISTS (SELECT 1 FROM accruals a WHERE a.account_id = t.account_id AND a.currency = t.currency AND a.accrual_date = $2::date AND a.statement_id IS NULL) AS interest_charged,
+	EXISTS (SELECT 1 FROM accruals a WHERE a.statement_id = s.statement_id) AS late_fee_charged
+	FROM transactions t
+	CROSS JOIN LATERAL (
+		SELECT COALESCE(SUM(p.credit - p.debit), 0) AS balance FROM ledger_postings p
+		JOIN ledger_entries e ON e.entry_id = p.entry_id
+		WHERE p.transaction_id = t.transaction_id AND p.ledger IN ('RECEIVABLE', 'ACCOUNT') AND e.created_at < $2
+	) b
+	CROSS JOIN LATERAL (
+		SELECT s.statement_id, s.due_date FROM statements s
+		WHERE s.account_id = t.account_id AND s.currency = t.currency AND s.closing_date > COALESCE(t.due_date, t.event_date)
+		ORDER BY s.closing_date
+		LIMIT 1
+	) s
+	WHERE 
+	t.account_id = $1 AND
+	b.balance < 0 AND
+	s.due_date < $2
+	ORDER BY COALESCE(t.due_date, t.event_date) asc, t.event_date asc;
+	`, accountID, date)
 
-	_, err := t.db.ExecContext(ctx, `UPDATE transactions SET balance = $1 where transaction_id = $2`, transaction.Balance, transaction.TransactionID)
 	if err != nil {
-		t.log.WithField("body", transaction).Error(err)
-		return err
+		t.log.WithField("account_id", accountID).WithField("date", date).Error(err)
+		return nil, err
+	}
+
+	return overdue, nil
+}
+
+// ListInstallmentsByParentID returns the installments of a purchase, the last one first.
+func (t transactions) ListInstallmentsByParentID(ctx context.Context, parentID string) ([]modelTransactions.Transaction, error) {
+
+	var transactions []modelTransactions.Transaction
+	err := sqlx.SelectContext(ctx, t.db, &transactions, `SELECT `+columns+` FROM transactions t `+openBalance+`
+	WHERE t.parent_transaction_id = $1
+	ORDER BY t.installment_number DESC;
+	`, parentID)
+
+	if err != nil {
+		t.log.WithField("parent_transaction_id", parentID).Error(err)
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// ListFutureInstallmentsByAccountID returns the installments of the account due after the date and not settled yet, in
+// the order they are due.
+func (t transactions) ListFutureInstallmentsByAccountID(ctx context.Context, accountID string, after time.Time) ([]modelTransactions.Transaction, error) {
+
+	var transactions []modelTransactions.Transaction
+	err := sqlx.SelectContext(ctx, t.db, &transactions, `SELECT `+columns+` FROM transactions t `+openBalance+`
+	WHERE t.account_id = $1 AND t.due_date > $2 AND b.balance < 0
+	ORDER BY t.due_date, t.parent_transaction_id, t.installment_number;
+	`, accountID, after)
+
+	if err != nil {
+		t.log.WithField("account_id", accountID).Error(err)
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (t transactions) ListByAccountID(ctx context.Context, filter modelTransactions.ListFilter) ([]modelTransactions.Transaction, error) {
+
+	conditions := []string{"t.account_id = $1"}
+	args := []interface{}{filter.AccountID}
+
+	where := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.OperationTypeID != 0 {
+		where("t.operation_type_id = %s", filter.OperationTypeID)
+	}
+
+	if !filter.StartDate.IsZero() {
+		where("t.event_date >= %s", filter.StartDate)
+	}
+
+	if !filter.EndDate.IsZero() {
+		where("t.event_date <= %s", filter.EndDate)
+	}
+
+	if filter.MinAmount > 0 {
+		where("ABS(t.amount) >= %s", filter.MinAmount)
+	}
+
+	if filter.MaxAmount > 0 {
+		where("ABS(t.amount) <= %s", filter.MaxAmount)
+	}
+
+	if filter.OpenBalanceOnly {
+		where("b.balance <> 0")
+	}
+
+	if filter.After != nil {
+		where("(t.event_date, t.transaction_id) < (%s, %s)", filter.After.EventDate, filter.After.TransactionID)
+	}
+
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`SELECT %s FROM transactions t %s WHERE %s ORDER BY t.event_date DESC, t.transaction_id DESC LIMIT $%d`, columns, openBalance, strings.Join(conditions, " AND "), len(args))
+
+	var transactions []modelTransactions.Transaction
+	err := sqlx.SelectContext(ctx, t.db, &transactions, query, args...)
+	if err != nil {
+		t.log.WithField("filter", filter).Error(err)
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (t transactions) GetBalanceByAccountID(ctx context.Context, accountID string) ([]modelTransactions.OperationTypeBalance, error) {
+
+	var balances []modelTransactions.OperationTypeBalance
+	err := sqlx.SelectContext(ctx, t.db, &balances, `SELECT t.operation_type_id, ot.description, t.currency,
+	COALESCE(-SUM(b.balance) FILTER (WHERE b.balance < 0), 0) AS outstanding_debt,
+	COALESCE(SUM(b.balance) FILTER (WHERE b.balance > 0), 0) AS unapplied_credit,
+	COUNT(*) FILTER (WHERE b.balance <> 0) AS open_transactions
+	FROM transactions t `+openBalance+`
+	JOIN operations_type ot ON ot.operation_type_id = t.operation_type_id
+	WHERE t.account_id = $1
+	GROUP BY t.operation_type_id, ot.description, t.currency
+	ORDER BY t.operation_type_id, t.currency;
+	`, accountID)
+
+	if err != nil {
+		t.log.WithField("account_id", accountID).Error(err)
+		return nil, err
 	}
 
-	return nil
+	return balances, nil
 }
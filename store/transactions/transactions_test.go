@@ -2,17 +2,23 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	modelAccruals "github.com/jorgepiresg/ChallangePismo/model/accruals"
+	modelMoney "github.com/jorgepiresg/ChallangePismo/model/money"
 	modelTransactions "github.com/jorgepiresg/ChallangePismo/model/transactions"
 	"github.com/sirupsen/logrus"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 )
 
 func TestCreate(t *testing.T) {
+
+	originalAmount, originalCurrency := modelMoney.MustParse("10"), "USD"
+	noLimit := modelMoney.Money(0)
 	type fields struct {
 		sqlx sqlxmock.Sqlmock
 	}
@@ -27,11 +33,11 @@ func TestCreate(t *testing.T) {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "account_id",
 				OperationTypeID: 1,
-				Amount:          -10,
+				Amount:          modelMoney.MustParse("-10"),
 			},
 			prepare: func(f *fields) {
 
-				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "event_date"}).AddRow("id", "account_id", 1, -10, time.Time{})
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "currency", "event_date"}).AddRow("id", "account_id", 1, -10, "BRL", time.Time{})
 
 				f.sqlx.ExpectQuery("INSERT INTO transactions").WillReturnRows(rows)
 			},
@@ -39,14 +45,44 @@ func TestCreate(t *testing.T) {
 				TransactionID:   "id",
 				AccountID:       "account_id",
 				OperationTypeID: 1,
-				Amount:          -10,
+				Amount:          modelMoney.MustParse("-10"),
+				Currency:        "BRL",
+			},
+		},
+		"should be able to insert converted transaction": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:        "account_id",
+				OperationTypeID:  4,
+				Amount:           modelMoney.MustParse("50"),
+				Currency:         "BRL",
+				OriginalAmount:   &originalAmount,
+				OriginalCurrency: &originalCurrency,
+			},
+			prepare: func(f *fields) {
+
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "original_amount", "original_currency", "event_date", "limit_amount"}).
+					AddRow("id", "account_id", 4, 50, 50, "BRL", "10.00", "USD", time.Time{}, "0.00")
+
+				f.sqlx.ExpectQuery("INSERT INTO transactions").
+					WithArgs("0.00", "account_id", "0.00", "0.00", nil, "account_id", 4, "50.00", "BRL", "10.00", "USD", nil, 0, nil, 0, nil, "0.00").WillReturnRows(rows)
+			},
+			expected: modelTransactions.Transaction{
+				TransactionID:    "id",
+				AccountID:        "account_id",
+				OperationTypeID:  4,
+				Amount:           modelMoney.MustParse("50"),
+				Balance:          modelMoney.MustParse("50"),
+				Currency:         "BRL",
+				OriginalAmount:   &originalAmount,
+				OriginalCurrency: &originalCurrency,
+				LimitAmount:      &noLimit,
 			},
 		},
 		"should not be able to insert transaction with error at scan": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "account_id",
 				OperationTypeID: 1,
-				Amount:          -10,
+				Amount:          modelMoney.MustParse("-10"),
 			},
 			prepare: func(f *fields) {
 				rows := f.sqlx.NewRows([]string{"id"}).AddRow("id")
@@ -55,11 +91,40 @@ func TestCreate(t *testing.T) {
 			},
 			err: fmt.Errorf("missing destination name id in *modelTransactions.Transaction"),
 		},
+		"should not be able to insert transaction with error insufficient credit limit": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "account_id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("-10"),
+			},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "event_date"})
+
+				f.sqlx.ExpectQuery("UPDATE accounts SET available_credit_limit").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT status <> 'CLOSED'").WithArgs("0.00", nil, "account_id").WillReturnRows(f.sqlx.NewRows([]string{"takes"}).AddRow(true))
+			},
+			err: ErrInsufficientCreditLimit,
+		},
+		"should not be able to insert transaction in an account not active": {
+			input: modelTransactions.MakeTransaction{
+				AccountID:       "account_id",
+				OperationTypeID: 1,
+				Amount:          modelMoney.MustParse("-10"),
+				LimitAmount:     modelMoney.MustParse("-10"),
+			},
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "event_date"})
+
+				f.sqlx.ExpectQuery(`UPDATE accounts SET available_credit_limit .+ AND\s+status <> 'CLOSED' AND\s+\(status = 'ACTIVE' OR CAST\(\? AS NUMERIC\) >= 0 OR CAST\(\? AS UUID\) IS NOT NULL\)`).WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT status <> 'CLOSED'").WithArgs("-10.00", nil, "account_id").WillReturnRows(f.sqlx.NewRows([]string{"takes"}).AddRow(false))
+			},
+			err: ErrAccountNotActive,
+		},
 		"should not be able to insert transaction with error at sqlx": {
 			input: modelTransactions.MakeTransaction{
 				AccountID:       "account_id",
 				OperationTypeID: 1,
-				Amount:          -10,
+				Amount:          modelMoney.MustParse("-10"),
 			},
 			prepare: func(f *fields) {
 				f.sqlx.ExpectQuery("INSERT INTO transactions").WillReturnError(fmt.Errorf("any"))
@@ -97,14 +162,149 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestGetByID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	transactionID, reversedTransactionID := "8f14e45f-ceea-467f-a0e6-7e1a5b2c3d4e", "2"
+
+	tests := map[string]struct {
+		input    string
+		expected modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get transaction by id": {
+			input: transactionID,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "reversed_transaction_id", "event_date"}).
+					AddRow("1", "account_id", 5, 60, 0, "BRL", "2", time.Time{})
+
+				f.sqlx.ExpectQuery(`SELECT t.transaction_id, .* FROM transactions t CROSS JOIN LATERAL \(.*\) b WHERE t.transaction_id = \$1`).WithArgs(transactionID).WillReturnRows(rows)
+			},
+			expected: modelTransactions.Transaction{
+				TransactionID:         "1",
+				AccountID:             "account_id",
+				OperationTypeID:       5,
+				Amount:                modelMoney.MustParse("60"),
+				Currency:              "BRL",
+				ReversedTransactionID: &reversedTransactionID,
+			},
+		},
+		"should not be able to get transaction by malformed id": {
+			input:   "1",
+			prepare: func(f *fields) {},
+			err:     sql.ErrNoRows,
+		},
+		"should not be able to get transaction by id with error": {
+			input: transactionID,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT t.transaction_id").WithArgs(transactionID).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.GetByID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetReversedAmount(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected modelMoney.Money
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get reversed amount": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT COALESCE\\(SUM\\(ABS\\(amount\\)\\), 0\\) FROM transactions WHERE reversed_transaction_id = \\$1").WithArgs("1").
+					WillReturnRows(f.sqlx.NewRows([]string{"coalesce"}).AddRow("30.00"))
+			},
+			expected: modelMoney.MustParse("30"),
+		},
+		"should not be able to get reversed amount with error": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT COALESCE").WithArgs("1").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.GetReversedAmount(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if res != tt.expected {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
 func TestGetToDischargeByAccountID(t *testing.T) {
 
 	type fields struct {
 		sqlx sqlxmock.Sqlmock
 	}
 
+	dueBy := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
 	tests := map[string]struct {
 		input    string
+		dueBy    *time.Time
 		expected []modelTransactions.Transaction
 		err      error
 		prepare  func(f *fields)
@@ -113,9 +313,9 @@ func TestGetToDischargeByAccountID(t *testing.T) {
 			input: "1",
 			prepare: func(f *fields) {
 
-				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "event_date"}).AddRow("1", "1", 1, -60, -60, time.Time{}).AddRow("2", "1", 1, -23.50, -23.50, time.Time{})
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "event_date"}).AddRow("1", "1", 1, -60, -60, "BRL", time.Time{}).AddRow("2", "1", 1, -23.50, -23.50, "BRL", time.Time{})
 
-				f.sqlx.ExpectQuery("SELECT transaction_id ,account_id, operation_type_id, amount, balance, event_date FROM transactions").WithArgs("1").WillReturnRows(rows)
+				f.sqlx.ExpectQuery("SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.event_date").WithArgs("1", "BRL", nil).WillReturnRows(rows)
 
 			},
 			expected: []modelTransactions.Transaction{
@@ -123,26 +323,49 @@ func TestGetToDischargeByAccountID(t *testing.T) {
 					TransactionID:   "1",
 					AccountID:       "1",
 					OperationTypeID: 1,
-					Amount:          -60,
-					Balance:         -60,
+					Amount:          modelMoney.MustParse("-60"),
+					Balance:         modelMoney.MustParse("-60"),
+					Currency:        "BRL",
 					EventDate:       time.Time{},
 				},
 				{
 					TransactionID:   "2",
 					AccountID:       "1",
 					OperationTypeID: 1,
-					Amount:          -23.50,
-					Balance:         -23.50,
+					Amount:          modelMoney.MustParse("-23.50"),
+					Balance:         modelMoney.MustParse("-23.50"),
+					Currency:        "BRL",
 					EventDate:       time.Time{},
 				},
 			},
 		},
 
+		"should be able to get transactions to dischard by account id leaving out installments not due": {
+			input: "1",
+			dueBy: &dueBy,
+			prepare: func(f *fields) {
+
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "event_date"}).AddRow("1", "1", 2, -10, -10, "BRL", time.Time{})
+
+				f.sqlx.ExpectQuery(`t.due_date IS NULL OR CAST\(\$3 AS TIMESTAMP WITH TIME ZONE\) IS NULL OR t.due_date <= CAST\(\$3 AS TIMESTAMP WITH TIME ZONE\)`).WithArgs("1", "BRL", dueBy).WillReturnRows(rows)
+
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:   "1",
+					AccountID:       "1",
+					OperationTypeID: 2,
+					Amount:          modelMoney.MustParse("-10"),
+					Balance:         modelMoney.MustParse("-10"),
+					Currency:        "BRL",
+				},
+			},
+		},
 		"should not be able to get transactions to dischard by account id with error": {
 			input: "1",
 			prepare: func(f *fields) {
 
-				f.sqlx.ExpectQuery("SELECT transaction_id ,account_id, operation_type_id, amount, balance, event_date FROM transactions").WithArgs("1").WillReturnError(fmt.Errorf("any"))
+				f.sqlx.ExpectQuery("SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.event_date").WithArgs("1", "BRL", nil).WillReturnError(fmt.Errorf("any"))
 
 			},
 			err: fmt.Errorf("any"),
@@ -166,7 +389,7 @@ func TestGetToDischargeByAccountID(t *testing.T) {
 				sqlx: mock,
 			})
 
-			res, err := store.GetToDischargeByAccountID(context.Background(), tt.input)
+			res, err := store.GetToDischargeByAccountID(context.Background(), tt.input, "BRL", tt.dueBy)
 
 			if err != nil && err.Error() != tt.err.Error() {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
@@ -178,48 +401,375 @@ func TestGetToDischargeByAccountID(t *testing.T) {
 	}
 }
 
-func TestUpdateBalance(t *testing.T) {
+func TestGetOpenCreditsByAccountID(t *testing.T) {
 
 	type fields struct {
 		sqlx sqlxmock.Sqlmock
 	}
 
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
 	tests := map[string]struct {
-		input   modelTransactions.Transaction
-		err     error
-		prepare func(f *fields)
+		input    string
+		expected []modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
 	}{
-		"should be able to update balance": {
-			input: modelTransactions.Transaction{
-				TransactionID:   "1",
-				AccountID:       "1",
-				OperationTypeID: 1,
-				Amount:          -60,
-				Balance:         0,
-				EventDate:       time.Time{},
+		"should be able to get open credits by account id": {
+			input: "1",
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "event_date"}).
+					AddRow("3", "1", 4, 100, 40, "BRL", eventDate)
+
+				f.sqlx.ExpectQuery(`WHERE t.account_id = \$1 AND b.balance > 0`).WithArgs("1").WillReturnRows(rows)
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:   "3",
+					AccountID:       "1",
+					OperationTypeID: 4,
+					Amount:          modelMoney.MustParse("100"),
+					Balance:         modelMoney.MustParse("40"),
+					Currency:        "BRL",
+					EventDate:       eventDate,
+				},
+			},
+		},
+		"should not be able to get open credits by account id with error": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WHERE t.account_id").WithArgs("1").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.GetOpenCreditsByAccountID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetOverdueByAccountID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	date := time.Date(2024, 2, 21, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    string
+		expected []modelAccruals.Overdue
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get overdue transactions by account id": {
+			input: "1",
+			prepare: func(f *fields) {
+
+				rows := f.sqlx.NewRows([]string{"transaction_id", "currency", "balance", "statement_id", "due_date", "interest_charged", "late_fee_charged"}).AddRow("1", "BRL", -60, "statement_id", dueDate, false, false).AddRow("2", "BRL", -23.50, "statement_id", dueDate, false, false)
+
+				f.sqlx.ExpectQuery(`SELECT t.transaction_id, t.currency, b.balance, s.statement_id, s.due_date, EXISTS \(SELECT 1 FROM accruals a WHERE a.account_id = t.account_id AND a.currency = t.currency AND a.accrual_date = \$2::date AND a.statement_id IS NULL\) AS interest_charged, EXISTS \(SELECT 1 FROM accruals a WHERE a.statement_id = s.statement_id\) AS late_fee_charged FROM transactions t`).WithArgs("1", date).WillReturnRows(rows)
+
+			},
+			expected: []modelAccruals.Overdue{
+				{TransactionID: "1", Currency: "BRL", Balance: modelMoney.MustParse("-60"), StatementID: "statement_id", DueDate: dueDate},
+				{TransactionID: "2", Currency: "BRL", Balance: modelMoney.MustParse("-23.50"), StatementID: "statement_id", DueDate: dueDate},
 			},
+		},
+		"should not be able to get overdue transactions by account id with error": {
+			input: "1",
 			prepare: func(f *fields) {
 
-				f.sqlx.ExpectExec("UPDATE transactions SET balance ").WithArgs(float64(0), "1").WillReturnResult(sqlxmock.NewResult(1, 1))
+				f.sqlx.ExpectQuery("SELECT t.transaction_id, t.currency, b.balance, s.statement_id, s.due_date").WithArgs("1", date).WillReturnError(fmt.Errorf("any"))
 
 			},
+			err: fmt.Errorf("any"),
 		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
 
-		"should not be able to update balance with error any": {
-			input: modelTransactions.Transaction{
-				TransactionID:   "1",
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.GetOverdueByAccountID(context.Background(), tt.input, date)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListInstallmentsByParentID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	parentID, number := "parent", 2
+
+	tests := map[string]struct {
+		input    string
+		expected []modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list installments by parent id": {
+			input: parentID,
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "parent_transaction_id", "installment_number", "event_date"}).
+					AddRow("2", "1", 2, -50, -50, "BRL", parentID, number, time.Time{})
+
+				f.sqlx.ExpectQuery(`WHERE t.parent_transaction_id = \$1 ORDER BY t.installment_number DESC`).WithArgs(parentID).WillReturnRows(rows)
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:       "2",
+					AccountID:           "1",
+					OperationTypeID:     2,
+					Amount:              modelMoney.MustParse("-50"),
+					Balance:             modelMoney.MustParse("-50"),
+					Currency:            "BRL",
+					ParentTransactionID: &parentID,
+					InstallmentNumber:   &number,
+				},
+			},
+		},
+		"should not be able to list installments by parent id with error": {
+			input: parentID,
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WHERE t.parent_transaction_id").WithArgs(parentID).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.ListInstallmentsByParentID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListFutureInstallmentsByAccountID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	after := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := after.AddDate(0, 1, 0)
+
+	tests := map[string]struct {
+		input    string
+		expected []modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list future installments by account id": {
+			input: "1",
+			prepare: func(f *fields) {
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "currency", "due_date", "event_date"}).
+					AddRow("2", "1", 2, -50, -50, "BRL", dueDate, after)
+
+				f.sqlx.ExpectQuery(`WHERE t.account_id = \$1 AND t.due_date > \$2 AND b.balance < 0`).WithArgs("1", after).WillReturnRows(rows)
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:   "2",
+					AccountID:       "1",
+					OperationTypeID: 2,
+					Amount:          modelMoney.MustParse("-50"),
+					Balance:         modelMoney.MustParse("-50"),
+					Currency:        "BRL",
+					DueDate:         &dueDate,
+					EventDate:       after,
+				},
+			},
+		},
+		"should not be able to list future installments by account id with error": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("WHERE t.account_id").WithArgs("1", after).WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
+
+			res, err := store.ListFutureInstallmentsByAccountID(context.Background(), tt.input, after)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestListByAccountID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	eventDate := time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		input    modelTransactions.ListFilter
+		expected []modelTransactions.Transaction
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to list transactions by account id": {
+			input: modelTransactions.ListFilter{
+				AccountID: "1",
+				Limit:     2,
+			},
+			prepare: func(f *fields) {
+
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "event_date"}).AddRow("2", "1", 4, 60, 0, eventDate).AddRow("1", "1", 1, -60, 0, eventDate)
+
+				f.sqlx.ExpectQuery(`SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.original_amount, t.original_currency, t.reversed_transaction_id, t.installments, t.parent_transaction_id, t.installment_number, t.due_date, t.event_date, t.limit_amount FROM transactions t CROSS JOIN LATERAL \(.*\) b WHERE t.account_id = \$1 ORDER BY t.event_date DESC, t.transaction_id DESC LIMIT \$2`).WithArgs("1", 2).WillReturnRows(rows)
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:   "2",
+					AccountID:       "1",
+					OperationTypeID: 4,
+					Amount:          modelMoney.MustParse("60"),
+					EventDate:       eventDate,
+				},
+				{
+					TransactionID:   "1",
+					AccountID:       "1",
+					OperationTypeID: 1,
+					Amount:          modelMoney.MustParse("-60"),
+					EventDate:       eventDate,
+				},
+			},
+		},
+		"should be able to list transactions by account id with all filters": {
+			input: modelTransactions.ListFilter{
 				AccountID:       "1",
 				OperationTypeID: 1,
-				Amount:          -60,
-				Balance:         0,
-				EventDate:       time.Time{},
+				StartDate:       eventDate,
+				EndDate:         eventDate,
+				MinAmount:       modelMoney.MustParse("10"),
+				MaxAmount:       modelMoney.MustParse("100"),
+				OpenBalanceOnly: true,
+				Limit:           10,
+				After:           &modelTransactions.Cursor{EventDate: eventDate, TransactionID: "3"},
 			},
-			err: fmt.Errorf("any"),
 			prepare: func(f *fields) {
 
-				f.sqlx.ExpectExec("UPDATE transactions SET balance ").WithArgs(float64(0), "1").WillReturnError(fmt.Errorf("any"))
+				rows := f.sqlx.NewRows([]string{"transaction_id", "account_id", "operation_type_id", "amount", "balance", "event_date"}).AddRow("1", "1", 1, -60, -60, eventDate)
 
+				f.sqlx.ExpectQuery(`WHERE t.account_id = \$1 AND t.operation_type_id = \$2 AND t.event_date >= \$3 AND t.event_date <= \$4 AND ABS\(t.amount\) >= \$5 AND ABS\(t.amount\) <= \$6 AND b.balance <> 0 AND \(t.event_date, t.transaction_id\) < \(\$7, \$8\) ORDER BY t.event_date DESC, t.transaction_id DESC LIMIT \$9`).
+					WithArgs("1", 1, eventDate, eventDate, "10.00", "100.00", eventDate, "3", 10).WillReturnRows(rows)
+			},
+			expected: []modelTransactions.Transaction{
+				{
+					TransactionID:   "1",
+					AccountID:       "1",
+					OperationTypeID: 1,
+					Amount:          modelMoney.MustParse("-60"),
+					Balance:         modelMoney.MustParse("-60"),
+					EventDate:       eventDate,
+				},
+			},
+		},
+		"should not be able to list transactions by account id with error": {
+			input: modelTransactions.ListFilter{
+				AccountID: "1",
+				Limit:     2,
+			},
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT t.transaction_id, t.account_id, t.operation_type_id, t.amount, b.balance, t.currency, t.original_amount, t.original_currency, t.reversed_transaction_id, t.installments, t.parent_transaction_id, t.installment_number, t.due_date, t.event_date, t.limit_amount FROM transactions t").WillReturnError(fmt.Errorf("any"))
 			},
+			err: fmt.Errorf("any"),
 		},
 	}
 
@@ -240,12 +790,79 @@ func TestUpdateBalance(t *testing.T) {
 				sqlx: mock,
 			})
 
-			err = store.UpdateBalance(context.Background(), tt.input)
+			res, err := store.ListByAccountID(context.Background(), tt.input)
 
 			if err != nil && err.Error() != tt.err.Error() {
 				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
 			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetBalanceByAccountID(t *testing.T) {
+
+	type fields struct {
+		sqlx sqlxmock.Sqlmock
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected []modelTransactions.OperationTypeBalance
+		err      error
+		prepare  func(f *fields)
+	}{
+		"should be able to get balance by account id": {
+			input: "1",
+			prepare: func(f *fields) {
+
+				rows := f.sqlx.NewRows([]string{"operation_type_id", "description", "currency", "outstanding_debt", "unapplied_credit", "open_transactions"}).
+					AddRow(1, "COMPRA A VISTA", "BRL", 13.50, 0, 1).AddRow(1, "COMPRA A VISTA", "USD", 5, 0, 1).AddRow(4, "PAGAMENTO", "BRL", 0, 10, 1)
+
+				f.sqlx.ExpectQuery("SELECT t.operation_type_id, ot.description, t.currency").WithArgs("1").WillReturnRows(rows)
+			},
+			expected: []modelTransactions.OperationTypeBalance{
+				{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "BRL", OutstandingDebt: modelMoney.MustParse("13.50"), OpenTransactions: 1},
+				{OperationTypeID: 1, Description: "COMPRA A VISTA", Currency: "USD", OutstandingDebt: modelMoney.MustParse("5"), OpenTransactions: 1},
+				{OperationTypeID: 4, Description: "PAGAMENTO", Currency: "BRL", UnappliedCredit: modelMoney.MustParse("10"), OpenTransactions: 1},
+			},
+		},
+		"should not be able to get balance by account id with error": {
+			input: "1",
+			prepare: func(f *fields) {
+				f.sqlx.ExpectQuery("SELECT t.operation_type_id, ot.description").WithArgs("1").WillReturnError(fmt.Errorf("any"))
+			},
+			err: fmt.Errorf("any"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			store := New(Options{
+				DB:  db,
+				Log: logrus.New(),
+			})
+
+			tt.prepare(&fields{
+				sqlx: mock,
+			})
 
+			res, err := store.GetBalanceByAccountID(context.Background(), tt.input)
+
+			if err != nil && err.Error() != tt.err.Error() {
+				t.Errorf(`Expected err: "%s" got "%s"`, tt.err, err)
+			}
+			if !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
 		})
 	}
 }
@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type db struct {
+	sqlx.ExtContext
+	store string
+}
+
+// DB starts a span for every query made through ext, named after store and the SQL operation, with the statement.
+func DB(ext sqlx.ExtContext, store string) sqlx.ExtContext {
+	return db{ExtContext: ext, store: store}
+}
+
+func (d db) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := d.start(ctx, query)
+	rows, err := d.ExtContext.QueryContext(ctx, query, args...)
+	end(span, err)
+	return rows, err
+}
+
+func (d db) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	ctx, span := d.start(ctx, query)
+	rows, err := d.ExtContext.QueryxContext(ctx, query, args...)
+	end(span, err)
+	return rows, err
+}
+
+func (d db) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx, span := d.start(ctx, query)
+	row := d.ExtContext.QueryRowxContext(ctx, query, args...)
+	end(span, row.Err())
+	return row
+}
+
+func (d db) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := d.start(ctx, query)
+	res, err := d.ExtContext.ExecContext(ctx, query, args...)
+	end(span, err)
+	return res, err
+}
+
+func (d db) start(ctx context.Context, query string) (context.Context, trace.Span) {
+
+	operation := "QUERY"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	return Start(ctx, d.store+" "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(query),
+			attribute.String("store", d.store),
+		),
+	)
+}
+
+// end ends the span of a query. No rows is an answer, not a failure.
+func end(span trace.Span, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	End(span, err)
+}
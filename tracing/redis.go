@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redis is the hook starting a span for every command of the client it is added to.
+type Redis struct{}
+
+var _ redis.Hook = Redis{}
+
+func (Redis) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = startRedis(ctx, cmd.Name())
+	return ctx, nil
+}
+
+func (Redis) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endRedis(ctx, cmd.Err())
+	return nil
+}
+
+func (Redis) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, _ = startRedis(ctx, "pipeline")
+	return ctx, nil
+}
+
+func (Redis) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			endRedis(ctx, err)
+			return nil
+		}
+	}
+	endRedis(ctx, nil)
+	return nil
+}
+
+func startRedis(ctx context.Context, command string) (context.Context, trace.Span) {
+	return Start(ctx, "redis "+strings.ToUpper(command),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperation(command),
+		),
+	)
+}
+
+// endRedis ends the span of a command. A key not found is an answer, not a failure.
+func endRedis(ctx context.Context, err error) {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	End(trace.SpanFromContext(ctx), err)
+}
@@ -0,0 +1,33 @@
+// Package tracing starts the OpenTelemetry spans of the service, for the handlers, the app calls, the SQL queries and the
+// Redis calls. Spans go to the tracer provider set globally, a no-op one until the server sets it up.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/jorgepiresg/ChallangePismo"
+
+// Start starts a span named name, child of the one in ctx.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, opts...)
+}
+
+// End ends the span, marked as failed when err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Detach returns a context that is never canceled but keeps the span of ctx, for work that outlives the request, such as
+// filling the cache, and still belongs to its trace.
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+}
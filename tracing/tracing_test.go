@@ -0,0 +1,164 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record sets a tracer provider keeping the spans in memory until the test ends.
+func record(t *testing.T) *tracetest.InMemoryExporter {
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return exporter
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]string {
+	res := map[attribute.Key]string{}
+	for _, kv := range span.Attributes {
+		res[kv.Key] = kv.Value.Emit()
+	}
+	return res
+}
+
+func TestDB(t *testing.T) {
+
+	tests := map[string]struct {
+		status  codes.Code
+		prepare func(mock sqlxmock.Sqlmock)
+	}{
+		"should be able to trace a query": {
+			status: codes.Unset,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT account_id").WillReturnRows(mock.NewRows([]string{"account_id"}).AddRow("id"))
+			},
+		},
+		"should be able to trace a query without rows as answered": {
+			status: codes.Unset,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT account_id").WillReturnError(sql.ErrNoRows)
+			},
+		},
+		"should be able to trace a query failed": {
+			status: codes.Error,
+			prepare: func(mock sqlxmock.Sqlmock) {
+				mock.ExpectQuery("SELECT account_id").WillReturnError(fmt.Errorf("any"))
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			exporter := record(t)
+
+			db, mock, err := sqlxmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			tt.prepare(mock)
+
+			var accountID string
+			DB(db, "accounts").QueryRowxContext(context.Background(), "\n\tSELECT account_id FROM accounts").Scan(&accountID)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("Expected 1 span got %d", len(spans))
+			}
+
+			if spans[0].Name != "accounts SELECT" {
+				t.Errorf("Expected span accounts SELECT got %s", spans[0].Name)
+			}
+			if res := attributes(spans[0])["db.statement"]; res != "\n\tSELECT account_id FROM accounts" {
+				t.Errorf("Expected statement got %q", res)
+			}
+			if spans[0].Status.Code != tt.status {
+				t.Errorf("Expected status %v got %v", tt.status, spans[0].Status.Code)
+			}
+		})
+	}
+}
+
+func TestRedis(t *testing.T) {
+
+	tests := map[string]struct {
+		err    error
+		status codes.Code
+	}{
+		"should be able to trace a command": {
+			status: codes.Unset,
+		},
+		"should be able to trace a command without the key as answered": {
+			err:    redis.Nil,
+			status: codes.Unset,
+		},
+		"should be able to trace a command failed": {
+			err:    fmt.Errorf("connection refused"),
+			status: codes.Error,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			exporter := record(t)
+
+			cmd := redis.NewStringCmd(context.Background(), "get", "key")
+
+			ctx, _ := Redis{}.BeforeProcess(context.Background(), cmd)
+			cmd.SetErr(tt.err)
+			Redis{}.AfterProcess(ctx, cmd)
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("Expected 1 span got %d", len(spans))
+			}
+
+			if spans[0].Name != "redis GET" {
+				t.Errorf("Expected span redis GET got %s", spans[0].Name)
+			}
+			if spans[0].Status.Code != tt.status {
+				t.Errorf("Expected status %v got %v", tt.status, spans[0].Status.Code)
+			}
+		})
+	}
+}
+
+func TestDetach(t *testing.T) {
+
+	t.Run("should be able to keep the span of a canceled context", func(t *testing.T) {
+
+		record(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ctx, span := Start(ctx, "request")
+		defer span.End()
+		cancel()
+
+		detached := Detach(ctx)
+
+		if detached.Err() != nil {
+			t.Errorf(`Expected err: nil got "%s"`, detached.Err())
+		}
+		if res := trace.SpanContextFromContext(detached); !res.Equal(span.SpanContext()) {
+			t.Errorf("Expected span context %v got %v", span.SpanContext(), res)
+		}
+	})
+}
@@ -2,8 +2,8 @@ package utils
 
 import "strings"
 
+// CleanDocument strips the punctuation and spaces of a formatted CPF or CNPJ, such as 111.444.777-35 or
+// 11.222.333/0001-81.
 func CleanDocument(document string) string {
-	document = strings.ReplaceAll(document, "-", "")
-	document = strings.ReplaceAll(document, ".", "")
-	return document
+	return strings.NewReplacer("-", "", ".", "", "/", "", " ", "").Replace(document)
 }
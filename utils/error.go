@@ -1,18 +1,43 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
 	"github.com/labstack/echo/v4"
 )
 
 type Error struct {
 	HTTPCode int         `mapstructure:"code" json:"-"`
+	Code     string      `mapstructure:"error_code" json:"code,omitempty"`
 	Message  string      `mapstructure:"message" json:"message,omitempty"`
 	Detail   interface{} `mapstructure:"detail,omitempty" swaggerignore:"true" json:"detail,omitempty"`
 }
 
+// httpCodes are the status of each kind of error of the domain.
+var httpCodes = map[modelErrors.Kind]int{
+	modelErrors.KindValidation:    http.StatusBadRequest,
+	modelErrors.KindNotFound:      http.StatusNotFound,
+	modelErrors.KindConflict:      http.StatusConflict,
+	modelErrors.KindLimitExceeded: http.StatusUnprocessableEntity,
+	modelErrors.KindUnprocessable: http.StatusUnprocessableEntity,
+	modelErrors.KindUnavailable:   http.StatusServiceUnavailable,
+}
+
+// codes are the codes of errors built only with a status.
+var codes = map[int]string{
+	http.StatusBadRequest:            "BAD_REQUEST",
+	http.StatusNotFound:              "NOT_FOUND",
+	http.StatusMethodNotAllowed:      "METHOD_NOT_ALLOWED",
+	http.StatusConflict:              "CONFLICT",
+	http.StatusRequestEntityTooLarge: "PAYLOAD_TOO_LARGE",
+	http.StatusUnprocessableEntity:   "UNPROCESSABLE",
+	http.StatusInternalServerError:   "INTERNAL",
+	http.StatusServiceUnavailable:    modelErrors.CodeUnavailable,
+}
+
 func NewError(httpCode int, message string, detail interface{}) *Error {
 	return &Error{
 		HTTPCode: httpCode,
@@ -30,20 +55,54 @@ func NewErrorContext(c echo.Context, httpCode int, message string, detail interf
 	}
 }
 
+// GetError returns err as sent to clients, always with a status and a code. Errors of the domain get the status of their
+// kind, errors of echo keep theirs and any other error is internal, its message not sent.
 func GetError(err error) *Error {
 	if err == nil {
 		return nil
 	}
 
-	e, ok := err.(*Error)
-	if !ok {
+	var e *Error
+	if errors.As(err, &e) {
+		if e.Code == "" {
+			return &Error{HTTPCode: e.HTTPCode, Code: codeOf(e.HTTPCode), Message: e.Message, Detail: e.Detail}
+		}
+		return e
+	}
+
+	if domain, ok := modelErrors.As(err); ok {
+		httpCode, ok := httpCodes[domain.Kind]
+		if !ok {
+			httpCode = http.StatusInternalServerError
+		}
 		return &Error{
-			HTTPCode: http.StatusInternalServerError,
-			Message:  err.Error(),
+			HTTPCode: httpCode,
+			Code:     domain.Code,
+			Message:  domain.Message,
 		}
 	}
 
-	return e
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return &Error{
+			HTTPCode: he.Code,
+			Code:     codeOf(he.Code),
+			Message:  fmt.Sprint(he.Message),
+		}
+	}
+
+	return &Error{
+		HTTPCode: http.StatusInternalServerError,
+		Code:     codeOf(http.StatusInternalServerError),
+		Message:  "internal error",
+	}
+}
+
+func codeOf(httpCode int) string {
+	if code, ok := codes[httpCode]; ok {
+		return code
+	}
+	return "ERROR"
 }
 
 func (e *Error) Error() string {
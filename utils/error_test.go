@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	modelErrors "github.com/jorgepiresg/ChallangePismo/model/errors"
+	"github.com/labstack/echo/v4"
+)
+
+func TestGetError(t *testing.T) {
+
+	tests := map[string]struct {
+		input    error
+		expected *Error
+	}{
+		"should be able to get a validation error": {
+			input:    modelErrors.Validation("AMOUNT_INVALID", "amount invalid"),
+			expected: &Error{HTTPCode: http.StatusBadRequest, Code: "AMOUNT_INVALID", Message: "amount invalid"},
+		},
+		"should be able to get a not found error": {
+			input:    modelErrors.NotFound("ACCOUNT_NOT_FOUND", "account not found"),
+			expected: &Error{HTTPCode: http.StatusNotFound, Code: "ACCOUNT_NOT_FOUND", Message: "account not found"},
+		},
+		"should be able to get a conflict error": {
+			input:    modelErrors.Conflict("TRANSACTION_REVERSED", "transaction already reversed"),
+			expected: &Error{HTTPCode: http.StatusConflict, Code: "TRANSACTION_REVERSED", Message: "transaction already reversed"},
+		},
+		"should be able to get a limit exceeded error": {
+			input:    modelErrors.LimitExceeded("CREDIT_LIMIT_EXCEEDED", "credit limit exceeded"),
+			expected: &Error{HTTPCode: http.StatusUnprocessableEntity, Code: "CREDIT_LIMIT_EXCEEDED", Message: "credit limit exceeded"},
+		},
+		"should be able to get an unavailable error without its cause": {
+			input:    modelErrors.Unavailable("fail to get account", fmt.Errorf("connection refused")),
+			expected: &Error{HTTPCode: http.StatusServiceUnavailable, Code: "UNAVAILABLE", Message: "fail to get account"},
+		},
+		"should be able to get an error with the code of its status": {
+			input:    NewError(http.StatusBadRequest, "payload invalid", nil),
+			expected: &Error{HTTPCode: http.StatusBadRequest, Code: "BAD_REQUEST", Message: "payload invalid"},
+		},
+		"should be able to get an error of echo": {
+			input:    echo.ErrNotFound,
+			expected: &Error{HTTPCode: http.StatusNotFound, Code: "NOT_FOUND", Message: "Not Found"},
+		},
+		"should be able to get any other error as internal without its message": {
+			input:    fmt.Errorf("pq: relation does not exist"),
+			expected: &Error{HTTPCode: http.StatusInternalServerError, Code: "INTERNAL", Message: "internal error"},
+		},
+		"should be able to get no error": {},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+
+			if res := GetError(tt.input); !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected result %v got %v", tt.expected, res)
+			}
+		})
+	}
+}
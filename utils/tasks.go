@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// Tasks tracks work left running in the background after a request, such as filling the cache, so it can be waited for
+// before the process exits. A nil Tasks runs the work without tracking it.
+type Tasks struct {
+	mu      sync.Mutex
+	running int
+	idle    chan struct{}
+}
+
+// Go runs fn in a goroutine of its own, tracked until it returns.
+func (t *Tasks) Go(fn func()) {
+
+	if t == nil {
+		go fn()
+		return
+	}
+
+	t.mu.Lock()
+	t.running++
+	t.mu.Unlock()
+
+	go func() {
+		defer t.done()
+		fn()
+	}()
+}
+
+// Wait blocks until no task is running, or the context is done, returning its error then.
+func (t *Tasks) Wait(ctx context.Context) error {
+
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	if t.running == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Tasks) done() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.running--
+	if t.running == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTasks(t *testing.T) {
+
+	t.Run("should be able to wait for the tasks running", func(t *testing.T) {
+
+		var tasks Tasks
+		var finished int32
+		release := make(chan struct{})
+
+		for i := 0; i < 3; i++ {
+			tasks.Go(func() {
+				<-release
+				atomic.AddInt32(&finished, 1)
+			})
+		}
+
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+		if err := tasks.Wait(context.Background()); err != nil {
+			t.Errorf(`Expected err: nil got "%s"`, err)
+		}
+		if n := atomic.LoadInt32(&finished); n != 3 {
+			t.Errorf("Expected 3 tasks finished got %d", n)
+		}
+	})
+
+	t.Run("should be able to wait without tasks", func(t *testing.T) {
+
+		var tasks Tasks
+
+		if err := tasks.Wait(context.Background()); err != nil {
+			t.Errorf(`Expected err: nil got "%s"`, err)
+		}
+	})
+
+	t.Run("should not be able to wait past the deadline", func(t *testing.T) {
+
+		var tasks Tasks
+		release := make(chan struct{})
+		defer close(release)
+
+		tasks.Go(func() { <-release })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if err := tasks.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf(`Expected err: "%s" got "%v"`, context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("should be able to run tasks without tracking them", func(t *testing.T) {
+
+		var tasks *Tasks
+		done := make(chan struct{})
+
+		tasks.Go(func() { close(done) })
+		<-done
+
+		if err := tasks.Wait(context.Background()); err != nil {
+			t.Errorf(`Expected err: nil got "%s"`, err)
+		}
+	})
+}